@@ -0,0 +1,454 @@
+package retailcrm
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// Maximum amount of the entities which can be uploaded by a single request.
+const (
+	OrdersUploadLimit             = 50
+	CustomersUploadLimit          = 50
+	CorporateCustomersUploadLimit = 50
+	InventoriesUploadLimit        = 250
+	PricesUploadLimit             = 250
+	CostsUploadLimit              = 50
+)
+
+// DefaultUploadConcurrency is the amount of chunks which are uploaded simultaneously by BulkUploader.
+const DefaultUploadConcurrency = 2
+
+// UploadedItem is the successfully uploaded entity. Index is the position of the entity in the uploaded slice.
+type UploadedItem struct {
+	Index      int
+	ID         int
+	ExternalID string
+}
+
+// UploadFailure is the entity which was not uploaded. Index is the position of the entity in the uploaded slice.
+// Err contains the error of the whole request if the request has failed, it is nil if only this entity was rejected.
+type UploadFailure struct {
+	Index      int
+	ExternalID string
+	Reason     string
+	Err        error
+}
+
+// UploadReport contains merged results of all chunks uploaded by BulkUploader.
+type UploadReport struct {
+	Uploaded []UploadedItem
+	Failed   []UploadFailure
+	// Processed is the amount of offers processed by the API. It is filled only for inventories and prices.
+	Processed int
+	Chunks    int
+}
+
+// HasFailures returns true if any of the entities was not uploaded.
+func (r UploadReport) HasFailures() bool {
+	return len(r.Failed) > 0
+}
+
+// FailedIndexes returns positions of the entities which were not uploaded.
+func (r UploadReport) FailedIndexes() []int {
+	indexes := make([]int, len(r.Failed))
+	for i, failure := range r.Failed {
+		indexes[i] = failure.Index
+	}
+
+	return indexes
+}
+
+// BulkUploader splits the entities into chunks which fit into the API limits and uploads them concurrently.
+// Requests are made by the Client, so they are rate limited and retried as any other request.
+//
+// Partial failures (status code 460) and failed requests don't stop the upload: every entity is either in the
+// UploadReport.Uploaded or in the UploadReport.Failed list of the report.
+//
+// Example:
+//
+//	var client = retailcrm.New("https://demo.url", "09jIJ")
+//
+//	report, err := retailcrm.NewBulkUploader(client).WithConcurrency(4).Orders(ctx, orders)
+//	if err != nil {
+//		log.Fatalf("upload was interrupted: %s", err)
+//	}
+//
+//	for _, failure := range report.Failed {
+//		log.Printf("order #%d (%s) was not uploaded: %s", failure.Index, failure.ExternalID, failure.Reason)
+//	}
+type BulkUploader struct {
+	client      *Client
+	concurrency int
+	chunkSize   int
+	site        []string
+}
+
+// NewBulkUploader instantiates new BulkUploader.
+func NewBulkUploader(client *Client) *BulkUploader {
+	return &BulkUploader{client: client, concurrency: DefaultUploadConcurrency}
+}
+
+// WithConcurrency sets the amount of chunks which are uploaded simultaneously.
+func (u *BulkUploader) WithConcurrency(concurrency int) *BulkUploader {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	u.concurrency = concurrency
+	return u
+}
+
+// WithChunkSize sets the amount of entities in a single request. Sizes above the API limit are reduced to the limit.
+func (u *BulkUploader) WithChunkSize(size int) *BulkUploader {
+	u.chunkSize = size
+	return u
+}
+
+// WithSite sets the site code for the orders and customers uploads.
+func (u *BulkUploader) WithSite(site string) *BulkUploader {
+	u.site = []string{site}
+	return u
+}
+
+// Orders uploads the orders using OrdersUpload. Returned error is not nil only if the context was canceled.
+func (u *BulkUploader) Orders(ctx context.Context, orders []Order) (UploadReport, error) {
+	return runUpload(ctx, u, orders, OrdersUploadLimit, func(ctx context.Context, chunk []Order) chunkResult {
+		resp, _, err := u.client.OrdersUploadCtx(ctx, chunk, u.site...)
+		ids := make([]string, len(chunk))
+		for i, order := range chunk {
+			ids[i] = order.ExternalID
+		}
+
+		return identifiedChunkResult(ids, resp.UploadedOrders, resp.FailedOrders, err)
+	})
+}
+
+// Customers uploads the customers using CustomersUpload. Returned error is not nil only if the context was canceled.
+func (u *BulkUploader) Customers(ctx context.Context, customers []Customer) (UploadReport, error) {
+	return runUpload(ctx, u, customers, CustomersUploadLimit, func(ctx context.Context, chunk []Customer) chunkResult {
+		resp, _, err := u.client.CustomersUploadCtx(ctx, chunk, u.site...)
+		ids := make([]string, len(chunk))
+		for i, customer := range chunk {
+			ids[i] = customer.ExternalID
+		}
+
+		return identifiedChunkResult(ids, resp.UploadedCustomers, resp.FailedCustomers, err)
+	})
+}
+
+// CorporateCustomers uploads the corporate customers using CorporateCustomersUpload.
+// Returned error is not nil only if the context was canceled.
+func (u *BulkUploader) CorporateCustomers(ctx context.Context, customers []CorporateCustomer) (UploadReport, error) {
+	return runUpload(ctx, u, customers, CorporateCustomersUploadLimit,
+		func(ctx context.Context, chunk []CorporateCustomer) chunkResult {
+			resp, _, err := u.client.CorporateCustomersUploadCtx(ctx, chunk, u.site...)
+			ids := make([]string, len(chunk))
+			for i, customer := range chunk {
+				ids[i] = customer.ExternalID
+			}
+
+			return identifiedChunkResult(ids, resp.UploadedCustomers, resp.FailedCustomers, err)
+		})
+}
+
+// Inventories uploads the stock balances using InventoriesUpload. Offers which were not found are reported
+// as failures. Returned error is not nil only if the context was canceled.
+func (u *BulkUploader) Inventories(ctx context.Context, inventories []InventoryUpload) (UploadReport, error) {
+	return runUpload(ctx, u, inventories, InventoriesUploadLimit,
+		func(ctx context.Context, chunk []InventoryUpload) chunkResult {
+			resp, _, err := u.client.InventoriesUploadCtx(ctx, chunk, u.site...)
+			offers := make([]Offer, len(chunk))
+			for i, item := range chunk {
+				offers[i] = Offer{ID: item.ID, ExternalID: item.ExternalID, XMLID: item.XMLID}
+			}
+
+			return offersChunkResult(offers, resp, err)
+		})
+}
+
+// Prices uploads the offer prices using PricesUpload. Offers which were not found are reported as failures.
+// Returned error is not nil only if the context was canceled.
+func (u *BulkUploader) Prices(ctx context.Context, prices []OfferPriceUpload) (UploadReport, error) {
+	return runUpload(ctx, u, prices, PricesUploadLimit, func(ctx context.Context, chunk []OfferPriceUpload) chunkResult {
+		resp, _, err := u.client.PricesUploadCtx(ctx, chunk)
+		offers := make([]Offer, len(chunk))
+		for i, item := range chunk {
+			offers[i] = Offer{ID: item.ID, ExternalID: item.ExternalID, XMLID: item.XMLID}
+		}
+
+		return offersChunkResult(offers, resp, err)
+	})
+}
+
+// Costs uploads the costs using CostsUpload. Returned error is not nil only if the context was canceled.
+func (u *BulkUploader) Costs(ctx context.Context, costs []CostRecord) (UploadReport, error) {
+	return runUpload(ctx, u, costs, CostsUploadLimit, func(ctx context.Context, chunk []CostRecord) chunkResult {
+		resp, _, err := u.client.CostsUploadCtx(ctx, chunk)
+		if err != nil {
+			return failedChunkResult(len(chunk), err)
+		}
+
+		var result chunkResult
+		for i := range chunk {
+			if i < len(resp.UploadedCosts) {
+				result.uploaded = append(result.uploaded, UploadedItem{Index: i, ID: resp.UploadedCosts[i]})
+				continue
+			}
+
+			result.failed = append(result.failed, UploadFailure{Index: i, Reason: "cost was not uploaded"})
+		}
+
+		return result
+	})
+}
+
+// chunkResult contains result of the single chunk. Indexes are relative to the chunk.
+type chunkResult struct {
+	uploaded  []UploadedItem
+	failed    []UploadFailure
+	processed int
+}
+
+func runUpload[T any](
+	ctx context.Context, u *BulkUploader, items []T, limit int, upload func(context.Context, []T) chunkResult,
+) (UploadReport, error) {
+	size := limit
+	if u.chunkSize > 0 && u.chunkSize < limit {
+		size = u.chunkSize
+	}
+
+	chunks := (len(items) + size - 1) / size
+	results := make([]chunkResult, chunks)
+	semaphore := make(chan struct{}, u.concurrency)
+
+	var wg sync.WaitGroup
+
+	for chunk := 0; chunk < chunks; chunk++ {
+		start := chunk * size
+		end := start + size
+		if end > len(items) {
+			end = len(items)
+		}
+
+		if !acquire(ctx, semaphore) {
+			results[chunk] = failedChunkResult(end-start, ctx.Err())
+			continue
+		}
+
+		wg.Add(1)
+		go func(chunk int, items []T) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+
+			results[chunk] = upload(ctx, items)
+		}(chunk, items[start:end])
+	}
+
+	wg.Wait()
+
+	return mergeChunkResults(results, size), ctx.Err()
+}
+
+// acquire takes the slot of the semaphore. It returns false if the context was canceled.
+func acquire(ctx context.Context, semaphore chan struct{}) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	select {
+	case semaphore <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func mergeChunkResults(results []chunkResult, size int) UploadReport {
+	report := UploadReport{Chunks: len(results)}
+
+	for chunk, result := range results {
+		offset := chunk * size
+
+		for _, item := range result.uploaded {
+			item.Index += offset
+			report.Uploaded = append(report.Uploaded, item)
+		}
+
+		for _, failure := range result.failed {
+			failure.Index += offset
+			report.Failed = append(report.Failed, failure)
+		}
+
+		report.Processed += result.processed
+	}
+
+	sort.Slice(report.Uploaded, func(i, j int) bool { return report.Uploaded[i].Index < report.Uploaded[j].Index })
+	sort.Slice(report.Failed, func(i, j int) bool { return report.Failed[i].Index < report.Failed[j].Index })
+
+	return report
+}
+
+func failedChunkResult(size int, err error) chunkResult {
+	result := chunkResult{failed: make([]UploadFailure, size)}
+	for i := range result.failed {
+		result.failed[i] = UploadFailure{Index: i, Reason: err.Error(), Err: err}
+	}
+
+	return result
+}
+
+// identifiedChunkResult maps the uploaded and failed entities of the orders and customers uploads to the chunk
+// positions using external IDs. Uploaded entities without external ID take the remaining positions in order.
+func identifiedChunkResult(externalIDs []string, uploaded []IdentifiersPair, failed []ExternalID, err error) chunkResult {
+	var partial *PartialUploadError
+	if err != nil && !errors.As(err, &partial) {
+		return failedChunkResult(len(externalIDs), err)
+	}
+
+	var errs APIErrorsList
+	reasons := map[string]string{}
+	if partial != nil {
+		errs = partial.Errors()
+		for _, item := range partial.Failed {
+			reasons[item.ExternalID] = item.Reason
+		}
+	}
+
+	positions := newChunkPositions(externalIDs)
+
+	var result chunkResult
+	for _, item := range failed {
+		i, ok := positions.take(item.ExternalID)
+		if !ok {
+			continue
+		}
+
+		result.failed = append(result.failed, UploadFailure{
+			Index:      i,
+			ExternalID: item.ExternalID,
+			Reason:     failureReason(reasons, errs, item.ExternalID, i, err),
+		})
+	}
+
+	var anonymous []IdentifiersPair
+	for _, item := range uploaded {
+		if i, ok := positions.take(item.ExternalID); ok {
+			result.uploaded = append(result.uploaded, UploadedItem{Index: i, ID: item.ID, ExternalID: item.ExternalID})
+			continue
+		}
+
+		anonymous = append(anonymous, item)
+	}
+
+	for i := range externalIDs {
+		if positions.taken[i] {
+			continue
+		}
+
+		if len(anonymous) > 0 {
+			result.uploaded = append(result.uploaded, UploadedItem{Index: i, ID: anonymous[0].ID, ExternalID: anonymous[0].ExternalID})
+			anonymous = anonymous[1:]
+			continue
+		}
+
+		if partial != nil {
+			result.failed = append(result.failed, UploadFailure{
+				Index:      i,
+				ExternalID: externalIDs[i],
+				Reason:     failureReason(reasons, errs, externalIDs[i], i, err),
+			})
+			continue
+		}
+
+		result.uploaded = append(result.uploaded, UploadedItem{Index: i, ExternalID: externalIDs[i]})
+	}
+
+	return result
+}
+
+// chunkPositions finds positions of the entities in the chunk by their external IDs. Every position is taken once,
+// so duplicated external IDs are mapped to the different positions.
+type chunkPositions struct {
+	positions map[string][]int
+	taken     []bool
+}
+
+func newChunkPositions(externalIDs []string) *chunkPositions {
+	p := &chunkPositions{positions: map[string][]int{}, taken: make([]bool, len(externalIDs))}
+	for i, id := range externalIDs {
+		if id != "" {
+			p.positions[id] = append(p.positions[id], i)
+		}
+	}
+
+	return p
+}
+
+func (p *chunkPositions) take(externalID string) (int, bool) {
+	for _, i := range p.positions[externalID] {
+		if !p.taken[i] {
+			p.taken[i] = true
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+// failureReason returns the reason of the rejected entity found by its external ID or position in the chunk.
+// The whole error is used if there is no reason.
+func failureReason(reasons map[string]string, errs APIErrorsList, externalID string, index int, err error) string {
+	if reason := reasons[externalID]; reason != "" && externalID != "" {
+		return reason
+	}
+
+	if reason, ok := errs[strconv.Itoa(index)]; ok {
+		return reason
+	}
+
+	if err != nil {
+		return err.Error()
+	}
+
+	return "entity was not uploaded"
+}
+
+// offersChunkResult reports offers from StoreUploadResponse.NotFoundOffers as failures.
+func offersChunkResult(offers []Offer, resp StoreUploadResponse, err error) chunkResult {
+	if err != nil {
+		return failedChunkResult(len(offers), err)
+	}
+
+	result := chunkResult{processed: resp.ProcessedOffersCount}
+	for i, offer := range offers {
+		if offerNotFound(offer, resp.NotFoundOffers) {
+			result.failed = append(result.failed, UploadFailure{
+				Index:      i,
+				ExternalID: offer.ExternalID,
+				Reason:     "offer not found",
+			})
+			continue
+		}
+
+		result.uploaded = append(result.uploaded, UploadedItem{Index: i, ID: offer.ID, ExternalID: offer.ExternalID})
+	}
+
+	return result
+}
+
+func offerNotFound(offer Offer, notFound []Offer) bool {
+	for _, item := range notFound {
+		if (offer.ID != 0 && item.ID == offer.ID) ||
+			(offer.ExternalID != "" && item.ExternalID == offer.ExternalID) ||
+			(offer.XMLID != "" && item.XMLID == offer.XMLID) {
+			return true
+		}
+	}
+
+	return false
+}
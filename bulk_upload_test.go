@@ -0,0 +1,162 @@
+package retailcrm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gock "gopkg.in/h2non/gock.v1"
+)
+
+func uploadOrders(count int) []Order {
+	orders := make([]Order, count)
+	for i := range orders {
+		orders[i] = Order{ExternalID: fmt.Sprintf("ext-%d", i)}
+	}
+
+	return orders
+}
+
+func TestBulkUploader_Orders(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(crmURL).
+		Post("/orders/upload").
+		BodyString(`ext-0.*ext-1`).
+		Reply(http.StatusOK).
+		BodyString(`{"success": true, "uploadedOrders": [{"id": 1, "externalId": "ext-0"}, {"id": 2, "externalId": "ext-1"}]}`)
+	gock.New(crmURL).
+		Post("/orders/upload").
+		BodyString(`ext-2.*ext-3`).
+		Reply(HTTPStatusUnknown).
+		BodyString(`{
+			"success": false,
+			"errorMsg": "Orders are loaded with errors",
+			"errors": {"ext-3": "Order with externalId=ext-3 already exists"},
+			"uploadedOrders": [{"id": 3, "externalId": "ext-2"}],
+			"failedOrders": [{"externalId": "ext-3"}]
+		}`)
+	gock.New(crmURL).
+		Post("/orders/upload").
+		BodyString(`ext-4`).
+		Reply(http.StatusBadRequest).
+		BodyString(`{"success": false, "errorMsg": "Errors in the entity format"}`)
+
+	report, err := NewBulkUploader(client()).
+		WithChunkSize(2).
+		WithConcurrency(3).
+		Orders(context.Background(), uploadOrders(5))
+
+	require.NoError(t, err)
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, 3, report.Chunks)
+	assert.Equal(t, []UploadedItem{
+		{Index: 0, ID: 1, ExternalID: "ext-0"},
+		{Index: 1, ID: 2, ExternalID: "ext-1"},
+		{Index: 2, ID: 3, ExternalID: "ext-2"},
+	}, report.Uploaded)
+
+	require.True(t, report.HasFailures())
+	assert.Equal(t, []int{3, 4}, report.FailedIndexes())
+	assert.Equal(t, "ext-3", report.Failed[0].ExternalID)
+	assert.Equal(t, "Order with externalId=ext-3 already exists", report.Failed[0].Reason)
+	assert.NoError(t, report.Failed[0].Err)
+	assert.ErrorIs(t, report.Failed[1].Err, ErrValidation)
+}
+
+func TestBulkUploader_ReasonByChunkPosition(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(crmURL).
+		Post("/orders/upload").
+		BodyString(`ext-0.*ext-1`).
+		Reply(http.StatusOK).
+		BodyString(`{"success": true, "uploadedOrders": [{"id": 1, "externalId": "ext-0"}, {"id": 2, "externalId": "ext-1"}]}`)
+	gock.New(crmURL).
+		Post("/orders/upload").
+		BodyString(`ext-2.*ext-3`).
+		Reply(HTTPStatusUnknown).
+		BodyString(`{
+			"success": false,
+			"errorMsg": "Orders are loaded with errors",
+			"errors": {"1": "Invalid status"},
+			"uploadedOrders": [{"id": 3, "externalId": "ext-2"}],
+			"failedOrders": [{"externalId": "ext-3"}]
+		}`)
+
+	report, err := NewBulkUploader(client()).
+		WithChunkSize(2).
+		Orders(context.Background(), uploadOrders(4))
+
+	require.NoError(t, err)
+	assert.Equal(t, []int{3}, report.FailedIndexes())
+	assert.Equal(t, "Invalid status", report.Failed[0].Reason)
+}
+
+func TestBulkUploader_ChunkSizeLimit(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(crmURL).
+		Post("/customers/upload").
+		Times(2).
+		Reply(http.StatusOK).
+		BodyString(`{"success": true}`)
+
+	customers := make([]Customer, CustomersUploadLimit+1)
+	report, err := NewBulkUploader(client()).WithChunkSize(1000).Customers(context.Background(), customers)
+
+	require.NoError(t, err)
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, 2, report.Chunks)
+	assert.Len(t, report.Uploaded, CustomersUploadLimit+1)
+	assert.False(t, report.HasFailures())
+}
+
+func TestBulkUploader_Inventories(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(crmURL).
+		Post("/store/inventories/upload").
+		Reply(http.StatusOK).
+		BodyString(`{"success": true, "processedOffersCount": 2, "notFoundOffers": [{"externalId": "missing"}]}`)
+
+	report, err := NewBulkUploader(client()).Inventories(context.Background(), []InventoryUpload{
+		{ExternalID: "first"},
+		{ExternalID: "missing"},
+		{XMLID: "third"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Processed)
+	assert.Equal(t, []int{1}, report.FailedIndexes())
+	assert.Equal(t, "offer not found", report.Failed[0].Reason)
+	assert.Len(t, report.Uploaded, 2)
+}
+
+func TestBulkUploader_Costs(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(crmURL).
+		Post("/costs/upload").
+		Reply(http.StatusOK).
+		BodyString(`{"success": true, "uploadedCosts": [10, 11]}`)
+
+	report, err := NewBulkUploader(client()).Costs(context.Background(), []CostRecord{{Summ: 1}, {Summ: 2}})
+
+	require.NoError(t, err)
+	assert.Equal(t, []UploadedItem{{Index: 0, ID: 10}, {Index: 1, ID: 11}}, report.Uploaded)
+}
+
+func TestBulkUploader_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	report, err := NewBulkUploader(client()).WithConcurrency(1).Orders(ctx, uploadOrders(OrdersUploadLimit*2))
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Len(t, report.Failed, OrdersUploadLimit*2)
+	assert.Empty(t, report.Uploaded)
+}
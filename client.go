@@ -74,8 +74,28 @@ func (c *Client) EnableCustomRateLimiter(limiter Limiter, maxAttempts uint) *Cli
 	return c
 }
 
+// WithRetryPolicy sets the RetryPolicy which decides whether the failed requests must be sent again.
+// Policy is used even if the rate limiter is not enabled. Pass nil to restore the default behavior.
+func (c *Client) WithRetryPolicy(policy RetryPolicy) *Client {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.retryPolicy = policy
+	return c
+}
+
+// defaultContext returns context.Context which was set via WithContext or context.Background() if none was provided.
+// It is used by the methods without the Ctx suffix.
+func (c *Client) defaultContext() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+
+	return c.ctx
+}
+
 // applyRateLimit applies rate limiting before sending a request.
-func (c *Client) applyRateLimit(uri string) error {
+func (c *Client) applyRateLimit(ctx context.Context, uri string) error {
 	c.mutex.RLock()
 	defer c.mutex.RUnlock()
 
@@ -83,11 +103,6 @@ func (c *Client) applyRateLimit(uri string) error {
 		return nil
 	}
 
-	ctx := c.ctx
-	if ctx == nil {
-		ctx = context.Background()
-	}
-
 	return c.limiter.Limit(ctx, uri, c.Key)
 }
 
@@ -106,10 +121,11 @@ func (c *Client) triggerResponseAwareLimiter(resp *http.Response) {
 }
 
 func (c *Client) executeWithRetryBytes(
-	uri string,
-	executeFunc func() (interface{}, *http.Response, int, error),
+	ctx context.Context,
+	method, uri string,
+	executeFunc func(ctx context.Context) (interface{}, *http.Response, int, error),
 ) ([]byte, int, error) {
-	res, status, err := c.executeWithRetry(uri, executeFunc)
+	res, status, err := c.executeWithRetry(ctx, method, uri, executeFunc)
 	if res == nil {
 		return nil, status, err
 	}
@@ -117,81 +133,146 @@ func (c *Client) executeWithRetryBytes(
 }
 
 func (c *Client) executeWithRetryReadCloser(
-	uri string,
-	executeFunc func() (interface{}, *http.Response, int, error),
+	ctx context.Context,
+	method, uri string,
+	executeFunc func(ctx context.Context) (interface{}, *http.Response, int, error),
 ) (io.ReadCloser, int, error) {
-	res, status, err := c.executeWithRetry(uri, executeFunc)
+	res, status, err := c.executeWithRetry(ctx, method, uri, executeFunc)
 	if res == nil {
 		return nil, status, err
 	}
 	return res.(io.ReadCloser), status, err
 }
 
-// executeWithRetry executes a request with retry logic for rate limiting.
+// currentRetryPolicy returns RetryPolicy which was set via WithRetryPolicy. If there is no such policy and
+// the rate limiter is enabled, the policy which retries only rate limited requests is returned.
+func (c *Client) currentRetryPolicy() RetryPolicy {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if c.retryPolicy != nil {
+		return c.retryPolicy
+	}
+
+	if c.limiter != nil {
+		return rateLimitRetryPolicy{maxAttempts: c.maxAttempts}
+	}
+
+	return nil
+}
+
+// executeWithRetry executes a request with retry logic provided by the RetryPolicy and notifies the Observer.
+// Provided context.Context is used for the limiter and for the delays between attempts.
 func (c *Client) executeWithRetry(
-	uri string,
-	executeFunc func() (interface{}, *http.Response, int, error),
+	ctx context.Context,
+	method, uri string,
+	executeFunc func(ctx context.Context) (interface{}, *http.Response, int, error),
 ) (interface{}, int, error) {
-	if c.limiter == nil {
-		resp, _, st, err := executeFunc()
+	obs := c.observe(ctx, method, uri)
+	ctx = obs.start(ctx)
+
+	res, statusCode, err := c.executeAttempts(ctx, method, uri, obs, executeFunc)
+	obs.done(ctx, statusCode, err)
+
+	return res, statusCode, err
+}
+
+func (c *Client) executeAttempts(
+	ctx context.Context,
+	method, uri string,
+	obs *observation,
+	executeFunc func(ctx context.Context) (interface{}, *http.Response, int, error),
+) (interface{}, int, error) {
+	policy := c.currentRetryPolicy()
+	if policy == nil {
+		obs.attempt()
+		resp, _, st, err := executeFunc(withRequestAttempt(ctx, 1))
 		return resp, st, err
 	}
 
 	var (
-		res           interface{}
-		httpResp      *http.Response
-		statusCode    int
-		err           error
-		lastAttempt   bool
-		attempt       uint = 1
-		maxAttempts        = c.maxAttempts
-		totalAttempts      = "∞"
-		infinite           = maxAttempts == 0
+		attempt    uint = 1
+		delay      time.Duration
+		idempotent = isIdempotentRequest(ctx, method)
 	)
 
-	var baseDelay time.Duration
-	if strings.HasPrefix(uri, "/telephony") {
-		baseDelay = telephonyDelay
-	} else {
-		baseDelay = regularDelay
-	}
-
-	if !infinite {
-		totalAttempts = strconv.FormatUint(uint64(maxAttempts), 10)
-	}
-
-	for infinite || attempt <= maxAttempts {
-		if err := c.applyRateLimit(uri); err != nil {
+	for {
+		if err := c.limitAttempt(ctx, uri, obs); err != nil {
 			return nil, 0, err
 		}
 
-		res, httpResp, statusCode, err = executeFunc()
+		obs.attempt()
+		res, httpResp, statusCode, err := executeFunc(withRequestAttempt(ctx, attempt))
 		c.triggerResponseAwareLimiter(httpResp)
-		lastAttempt = !infinite && attempt == maxAttempts
-		isRateLimited := statusCode == http.StatusServiceUnavailable || statusCode == http.StatusTooManyRequests
 
-		// If rate limited on final attempt, set error to ErrRateLimited. Return results otherwise.
-		if isRateLimited && lastAttempt {
-			return res, statusCode, ErrRateLimited
+		reason := ClassifyRetry(statusCode, err)
+		retryAttempt := RetryAttempt{
+			Method:     method,
+			URI:        uri,
+			Attempt:    attempt,
+			StatusCode: statusCode,
+			Response:   httpResp,
+			Err:        err,
+			Reason:     reason,
+			Idempotent: idempotent,
+			PrevDelay:  delay,
 		}
 
-		// If not rate limited or on final attempt, return result.
-		if !isRateLimited || lastAttempt {
+		next, retry := policy.Retry(retryAttempt)
+		if !retry {
+			// If rate limited on final attempt, set error to ErrRateLimited. Return results otherwise.
+			if reason == RetryReasonRateLimited {
+				return res, statusCode, ErrRateLimited
+			}
+
 			return res, statusCode, err
 		}
 
-		// Calculate exponential backoff delay: baseDelay * 2^(attempt-1).
-		backoffDelay := baseDelay * (1 << (attempt - 1))
-		if c.Debug {
-			c.writeLog("API Error: rate limited (503), retrying in %v (attempt %d/%s)",
-				backoffDelay, attempt, totalAttempts)
+		if httpResp != nil && httpResp.Body != nil {
+			_ = httpResp.Body.Close()
+		}
+
+		c.logRetry(ctx, retryAttempt, next)
+		obs.retry(ctx, retryAttempt, next)
+
+		if err := sleepWithContext(ctx, next); err != nil {
+			return res, statusCode, err
 		}
 
-		time.Sleep(backoffDelay)
+		delay = next
 		attempt++
 	}
+}
 
-	return res, statusCode, err
+// limitAttempt applies rate limiting and reports the time spent in the limiter to the Observer.
+func (c *Client) limitAttempt(ctx context.Context, uri string, obs *observation) error {
+	c.mutex.RLock()
+	limited := c.limiter != nil
+	c.mutex.RUnlock()
+
+	if !limited {
+		return nil
+	}
+
+	start := time.Now()
+	err := c.applyRateLimit(ctx, uri)
+	obs.rateLimitWait(ctx, time.Since(start))
+
+	return err
+}
+
+// sleepWithContext pauses the current goroutine for the provided duration.
+// It returns context error if context.Context was canceled before the duration has elapsed.
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
 
 // writeLog writes to the log.
@@ -206,6 +287,11 @@ func (c *Client) writeLog(format string, v ...interface{}) {
 
 // GetRequest implements GET Request.
 func (c *Client) GetRequest(urlWithParameters string, versioned ...bool) ([]byte, int, error) {
+	return c.GetRequestCtx(c.defaultContext(), urlWithParameters, versioned...)
+}
+
+// GetRequestCtx implements GET Request which uses the provided context.Context.
+func (c *Client) GetRequestCtx(ctx context.Context, urlWithParameters string, versioned ...bool) ([]byte, int, error) {
 	var prefix = "/api/v5"
 
 	if len(versioned) > 0 {
@@ -216,26 +302,33 @@ func (c *Client) GetRequest(urlWithParameters string, versioned ...bool) ([]byte
 
 	uri := urlWithParameters
 
-	return c.executeWithRetryBytes(uri, func() (interface{}, *http.Response, int, error) {
+	if prefix == "/api/v5" {
+		if data, ok := c.cachedReference(uri); ok {
+			c.observeCacheHit(ctx, uri)
+			return data, http.StatusOK, nil
+		}
+	}
+
+	referenceVersion := c.currentReferenceVersion()
+
+	data, status, err := c.executeWithRetryBytes(ctx, http.MethodGet, uri, func(ctx context.Context) (interface{}, *http.Response, int, error) {
 		var res []byte
 
-		req, err := http.NewRequest("GET", fmt.Sprintf("%s%s%s", c.URL, prefix, urlWithParameters), nil)
+		req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s%s%s", c.URL, prefix, urlWithParameters), nil)
 		if err != nil {
 			return res, nil, 0, err
 		}
 
-		req.Header.Set("X-API-KEY", c.Key)
-
-		if c.Debug {
-			c.writeLog("API Request: %s %s", fmt.Sprintf("%s%s%s", c.URL, prefix, urlWithParameters), c.Key)
-		}
-
-		resp, err := c.httpClient.Do(req)
+		started := time.Now()
+		resp, err := c.do(req)
 		if err != nil {
 			return res, resp, 0, err
 		}
 
 		if resp.StatusCode >= http.StatusInternalServerError && resp.StatusCode != http.StatusServiceUnavailable {
+			body, _ := buildRawResponse(resp)
+			c.logResponse(ctx, resp.StatusCode, body, time.Since(started))
+
 			return res, resp, resp.StatusCode, CreateGenericAPIError(
 				fmt.Sprintf("HTTP request error. Status code: %d.", resp.StatusCode))
 		}
@@ -245,18 +338,22 @@ func (c *Client) GetRequest(urlWithParameters string, versioned ...bool) ([]byte
 			return res, resp, 0, err
 		}
 
+		c.logResponse(ctx, resp.StatusCode, res, time.Since(started))
+
 		if resp.StatusCode >= http.StatusBadRequest &&
 			resp.StatusCode < http.StatusInternalServerError &&
 			resp.StatusCode != http.StatusServiceUnavailable {
 			return res, resp, resp.StatusCode, CreateAPIError(res)
 		}
 
-		if c.Debug {
-			c.writeLog("API Response: %s", res)
-		}
-
 		return res, resp, resp.StatusCode, nil
 	})
+
+	if prefix == "/api/v5" && err == nil && status < http.StatusMultipleChoices {
+		c.storeReference(uri, data, referenceVersion)
+	}
+
+	return data, status, err
 }
 
 // PostRequest implements POST Request with generic body data.
@@ -264,6 +361,16 @@ func (c *Client) PostRequest(
 	uri string,
 	postData interface{},
 	contType ...string,
+) ([]byte, int, error) {
+	return c.PostRequestCtx(c.defaultContext(), uri, postData, contType...)
+}
+
+// PostRequestCtx implements POST Request with generic body data which uses the provided context.Context.
+func (c *Client) PostRequestCtx(
+	ctx context.Context,
+	uri string,
+	postData interface{},
+	contType ...string,
 ) ([]byte, int, error) {
 	var contentType string
 
@@ -275,32 +382,38 @@ func (c *Client) PostRequest(
 
 	prefix := "/api/v5"
 
-	return c.executeWithRetryBytes(uri, func() (interface{}, *http.Response, int, error) {
+	defer c.invalidateReferenceURI(uri)
+
+	bodyReader, err := getPostBodyReader(postData, c.currentRetryPolicy() != nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return c.executeWithRetryBytes(ctx, http.MethodPost, uri, func(ctx context.Context) (interface{}, *http.Response, int, error) {
 		var res []byte
 
-		reader, err := getReaderForPostData(postData)
+		reader, err := bodyReader()
 		if err != nil {
 			return res, nil, 0, err
 		}
 
-		req, err := http.NewRequest("POST", fmt.Sprintf("%s%s%s", c.URL, prefix, uri), reader)
+		req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s%s%s", c.URL, prefix, uri), reader)
 		if err != nil {
 			return res, nil, 0, err
 		}
 
 		req.Header.Set("Content-Type", contentType)
-		req.Header.Set("X-API-KEY", c.Key)
-
-		if c.Debug {
-			c.writeLog("API Request: %s %s", uri, c.Key)
-		}
 
-		resp, err := c.httpClient.Do(req)
+		started := time.Now()
+		resp, err := c.do(req)
 		if err != nil {
 			return res, resp, 0, err
 		}
 
 		if resp.StatusCode >= http.StatusInternalServerError && resp.StatusCode != http.StatusServiceUnavailable {
+			body, _ := buildRawResponse(resp)
+			c.logResponse(ctx, resp.StatusCode, body, time.Since(started))
+
 			return res, resp, resp.StatusCode, CreateGenericAPIError(
 				fmt.Sprintf("HTTP request error. Status code: %d.", resp.StatusCode))
 		}
@@ -310,36 +423,53 @@ func (c *Client) PostRequest(
 			return res, resp, 0, err
 		}
 
+		c.logResponse(ctx, resp.StatusCode, res, time.Since(started))
+
 		if resp.StatusCode >= http.StatusBadRequest &&
 			resp.StatusCode < http.StatusInternalServerError &&
 			resp.StatusCode != http.StatusServiceUnavailable {
 			return res, resp, resp.StatusCode, CreateAPIError(res)
 		}
 
-		if c.Debug {
-			c.writeLog("API Response: %s", res)
-		}
-
 		return res, resp, resp.StatusCode, nil
 	})
 }
 
-func getReaderForPostData(postData interface{}) (io.Reader, error) {
-	var reader io.Reader
-
+// getPostBodyReader returns the function which creates the request body for every attempt. The body which can't
+// be rewound is read once and buffered if the request can be retried, so the retries don't send an empty body.
+func getPostBodyReader(postData interface{}, retryable bool) (func() (io.Reader, error), error) {
 	switch d := postData.(type) {
 	case url.Values:
-		reader = strings.NewReader(d.Encode())
-	default:
-		if i, ok := d.(io.Reader); ok {
-			reader = i
-		} else {
-			err := errors.New("postData should be url.Values or implement io.Reader")
+		encoded := d.Encode()
+		return func() (io.Reader, error) { return strings.NewReader(encoded), nil }, nil
+	case io.ReadSeeker:
+		start, err := d.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, err
+		}
+
+		return func() (io.Reader, error) {
+			if _, err := d.Seek(start, io.SeekStart); err != nil {
+				return nil, err
+			}
+
+			// The wrapper hides io.Closer, so http.Client doesn't close the body before the retry.
+			return struct{ io.Reader }{d}, nil
+		}, nil
+	case io.Reader:
+		if !retryable {
+			return func() (io.Reader, error) { return d, nil }, nil
+		}
+
+		data, err := io.ReadAll(d)
+		if err != nil {
 			return nil, err
 		}
+
+		return func() (io.Reader, error) { return bytes.NewReader(data), nil }, nil
 	}
 
-	return reader, nil
+	return nil, errors.New("postData should be url.Values or implement io.Reader")
 }
 
 func buildRawResponse(resp *http.Response) ([]byte, error) {
@@ -397,9 +527,16 @@ func fillSite(p *url.Values, site []string) {
 //		log.Printf("%v\n", value)
 //	}
 func (c *Client) APIVersions() (VersionResponse, int, error) {
+	return c.APIVersionsCtx(c.defaultContext())
+}
+
+// APIVersionsCtx is the same as APIVersions, but uses the provided context.Context.
+func (c *Client) APIVersionsCtx(ctx context.Context) (VersionResponse, int, error) {
+	ctx = withAPIMethod(ctx, "APIVersions")
+
 	var resp VersionResponse
 
-	data, status, err := c.GetRequest("/api-versions", false)
+	data, status, err := c.GetRequestCtx(ctx, "/api-versions", false)
 	if err != nil {
 		return resp, status, err
 	}
@@ -434,9 +571,16 @@ func (c *Client) APIVersions() (VersionResponse, int, error) {
 //		log.Printf("%v\n", value)
 //	}
 func (c *Client) APICredentials() (CredentialResponse, int, error) {
+	return c.APICredentialsCtx(c.defaultContext())
+}
+
+// APICredentialsCtx is the same as APICredentials, but uses the provided context.Context.
+func (c *Client) APICredentialsCtx(ctx context.Context) (CredentialResponse, int, error) {
+	ctx = withAPIMethod(ctx, "APICredentials")
+
 	var resp CredentialResponse
 
-	data, status, err := c.GetRequest("/credentials", false)
+	data, status, err := c.GetRequestCtx(ctx, "/credentials", false)
 	if err != nil {
 		return resp, status, err
 	}
@@ -469,9 +613,16 @@ func (c *Client) APICredentials() (CredentialResponse, int, error) {
 //
 //	log.Printf("%v\n", data)
 func (c *Client) APISystemInfo() (SystemInfoResponse, int, error) {
+	return c.APISystemInfoCtx(c.defaultContext())
+}
+
+// APISystemInfoCtx is the same as APISystemInfo, but uses the provided context.Context.
+func (c *Client) APISystemInfoCtx(ctx context.Context) (SystemInfoResponse, int, error) {
+	ctx = withAPIMethod(ctx, "APISystemInfo")
+
 	var resp SystemInfoResponse
 
-	data, status, err := c.GetRequest("/system-info", false)
+	data, status, err := c.GetRequestCtx(ctx, "/system-info", false)
 	if err != nil {
 		return resp, status, err
 	}
@@ -511,11 +662,18 @@ func (c *Client) APISystemInfo() (SystemInfoResponse, int, error) {
 //		log.Printf("%v\n", value)
 //	}
 func (c *Client) Customers(parameters CustomersRequest) (CustomersResponse, int, error) {
+	return c.CustomersCtx(c.defaultContext(), parameters)
+}
+
+// CustomersCtx is the same as Customers, but uses the provided context.Context.
+func (c *Client) CustomersCtx(ctx context.Context, parameters CustomersRequest) (CustomersResponse, int, error) {
+	ctx = withAPIMethod(ctx, "Customers")
+
 	var resp CustomersResponse
 
 	params, _ := query.Values(parameters)
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/customers?%s", params.Encode()))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/customers?%s", params.Encode()))
 	if err != nil {
 		return resp, status, err
 	}
@@ -546,6 +704,15 @@ func (c *Client) Customers(parameters CustomersRequest) (CustomersResponse, int,
 //		log.Fatalf("http status: %d, error: %s", status, err)
 //	}
 func (c *Client) CustomersCombine(customers []Customer, resultCustomer Customer) (SuccessfulResponse, int, error) {
+	return c.CustomersCombineCtx(c.defaultContext(), customers, resultCustomer)
+}
+
+// CustomersCombineCtx is the same as CustomersCombine, but uses the provided context.Context.
+func (c *Client) CustomersCombineCtx(
+	ctx context.Context, customers []Customer, resultCustomer Customer,
+) (SuccessfulResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CustomersCombine")
+
 	var resp SuccessfulResponse
 
 	combineJSONIn, _ := json.Marshal(&customers)
@@ -556,7 +723,7 @@ func (c *Client) CustomersCombine(customers []Customer, resultCustomer Customer)
 		"resultCustomer": {string(combineJSONOut)},
 	}
 
-	data, status, err := c.PostRequest("/customers/combine", p)
+	data, status, err := c.PostRequestCtx(ctx, "/customers/combine", p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -601,8 +768,23 @@ func (c *Client) CustomersCombine(customers []Customer, resultCustomer Customer)
 //		fmt.Printf("%v", data.ID)
 //	}
 func (c *Client) CustomerCreate(customer Customer, site ...string) (CustomerChangeResponse, int, error) {
+	return c.CustomerCreateCtx(c.defaultContext(), customer, site...)
+}
+
+// CustomerCreateCtx is the same as CustomerCreate, but uses the provided context.Context.
+func (c *Client) CustomerCreateCtx(
+	ctx context.Context, customer Customer, site ...string,
+) (CustomerChangeResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CustomerCreate")
+
 	var resp CustomerChangeResponse
 
+	if v := c.currentValidator(); v != nil {
+		if err := v.ValidateCustomerCreate(customer); err != nil {
+			return resp, 0, err
+		}
+	}
+
 	customerJSON, _ := json.Marshal(&customer)
 
 	p := url.Values{
@@ -611,7 +793,7 @@ func (c *Client) CustomerCreate(customer Customer, site ...string) (CustomerChan
 
 	fillSite(&p, site)
 
-	data, status, err := c.PostRequest("/customers/create", p)
+	data, status, err := c.PostRequestCtx(ctx, "/customers/create", p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -645,6 +827,15 @@ func (c *Client) CustomerCreate(customer Customer, site ...string) (CustomerChan
 //		log.Fatalf("http status: %d, error: %s", status, err)
 //	}
 func (c *Client) CustomersFixExternalIds(customers []IdentifiersPair) (SuccessfulResponse, int, error) {
+	return c.CustomersFixExternalIdsCtx(c.defaultContext(), customers)
+}
+
+// CustomersFixExternalIdsCtx is the same as CustomersFixExternalIds, but uses the provided context.Context.
+func (c *Client) CustomersFixExternalIdsCtx(
+	ctx context.Context, customers []IdentifiersPair,
+) (SuccessfulResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CustomersFixExternalIds")
+
 	var resp SuccessfulResponse
 
 	customersJSON, _ := json.Marshal(&customers)
@@ -653,7 +844,7 @@ func (c *Client) CustomersFixExternalIds(customers []IdentifiersPair) (Successfu
 		"customers": {string(customersJSON)},
 	}
 
-	data, status, err := c.PostRequest("/customers/fix-external-ids", p)
+	data, status, err := c.PostRequestCtx(ctx, "/customers/fix-external-ids", p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -692,11 +883,20 @@ func (c *Client) CustomersFixExternalIds(customers []IdentifiersPair) (Successfu
 //		log.Printf("%v\n", value)
 //	}
 func (c *Client) CustomersHistory(parameters CustomersHistoryRequest) (CustomersHistoryResponse, int, error) {
+	return c.CustomersHistoryCtx(c.defaultContext(), parameters)
+}
+
+// CustomersHistoryCtx is the same as CustomersHistory, but uses the provided context.Context.
+func (c *Client) CustomersHistoryCtx(
+	ctx context.Context, parameters CustomersHistoryRequest,
+) (CustomersHistoryResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CustomersHistory")
+
 	var resp CustomersHistoryResponse
 
 	params, _ := query.Values(parameters)
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/customers/history?%s", params.Encode()))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/customers/history?%s", params.Encode()))
 	if err != nil {
 		return resp, status, err
 	}
@@ -736,11 +936,18 @@ func (c *Client) CustomersHistory(parameters CustomersHistoryRequest) (Customers
 //		log.Printf("%v\n", value)
 //	}
 func (c *Client) CustomerNotes(parameters NotesRequest) (NotesResponse, int, error) {
+	return c.CustomerNotesCtx(c.defaultContext(), parameters)
+}
+
+// CustomerNotesCtx is the same as CustomerNotes, but uses the provided context.Context.
+func (c *Client) CustomerNotesCtx(ctx context.Context, parameters NotesRequest) (NotesResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CustomerNotes")
+
 	var resp NotesResponse
 
 	params, _ := query.Values(parameters)
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/customers/notes?%s", params.Encode()))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/customers/notes?%s", params.Encode()))
 	if err != nil {
 		return resp, status, err
 	}
@@ -781,6 +988,13 @@ func (c *Client) CustomerNotes(parameters NotesRequest) (NotesResponse, int, err
 //		log.Printf("%v\n", data.ID)
 //	}
 func (c *Client) CustomerNoteCreate(note Note, site ...string) (CreateResponse, int, error) {
+	return c.CustomerNoteCreateCtx(c.defaultContext(), note, site...)
+}
+
+// CustomerNoteCreateCtx is the same as CustomerNoteCreate, but uses the provided context.Context.
+func (c *Client) CustomerNoteCreateCtx(ctx context.Context, note Note, site ...string) (CreateResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CustomerNoteCreate")
+
 	var resp CreateResponse
 
 	noteJSON, _ := json.Marshal(&note)
@@ -791,7 +1005,7 @@ func (c *Client) CustomerNoteCreate(note Note, site ...string) (CreateResponse,
 
 	fillSite(&p, site)
 
-	data, status, err := c.PostRequest("/customers/notes/create", p)
+	data, status, err := c.PostRequestCtx(ctx, "/customers/notes/create", p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -821,13 +1035,20 @@ func (c *Client) CustomerNoteCreate(note Note, site ...string) (CreateResponse,
 //		log.Fatalf("http status: %d, error: %s", status, err)
 //	}
 func (c *Client) CustomerNoteDelete(id int) (SuccessfulResponse, int, error) {
+	return c.CustomerNoteDeleteCtx(c.defaultContext(), id)
+}
+
+// CustomerNoteDeleteCtx is the same as CustomerNoteDelete, but uses the provided context.Context.
+func (c *Client) CustomerNoteDeleteCtx(ctx context.Context, id int) (SuccessfulResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CustomerNoteDelete")
+
 	var resp SuccessfulResponse
 
 	p := url.Values{
 		"id": {strconv.Itoa(id)},
 	}
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/customers/notes/%d/delete", id), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/customers/notes/%d/delete", id), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -844,6 +1065,8 @@ func (c *Client) CustomerNoteDelete(id int) (SuccessfulResponse, int, error) {
 //
 // # This method can return response together with error if http status is equal 460
 //
+// The error is *PartialUploadError in this case, it can be matched with errors.As.
+//
 // For more information see http://www.simla.com/docs/Developers/API/APIVersions/APIv5#post--api-v5-customers-upload
 //
 // Example:
@@ -879,6 +1102,15 @@ func (c *Client) CustomerNoteDelete(id int) (SuccessfulResponse, int, error) {
 //		log.Printf("%v\n", data.UploadedCustomers)
 //	}
 func (c *Client) CustomersUpload(customers []Customer, site ...string) (CustomersUploadResponse, int, error) {
+	return c.CustomersUploadCtx(c.defaultContext(), customers, site...)
+}
+
+// CustomersUploadCtx is the same as CustomersUpload, but uses the provided context.Context.
+func (c *Client) CustomersUploadCtx(
+	ctx context.Context, customers []Customer, site ...string,
+) (CustomersUploadResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CustomersUpload")
+
 	var resp CustomersUploadResponse
 
 	uploadJSON, _ := json.Marshal(&customers)
@@ -889,7 +1121,7 @@ func (c *Client) CustomersUpload(customers []Customer, site ...string) (Customer
 
 	fillSite(&p, site)
 
-	data, status, err := c.PostRequest("/customers/upload", p)
+	data, status, err := c.PostRequestCtx(ctx, "/customers/upload", p)
 	if err != nil && status != HTTPStatusUnknown {
 		return resp, status, err
 	}
@@ -900,7 +1132,12 @@ func (c *Client) CustomersUpload(customers []Customer, site ...string) (Customer
 	}
 
 	if status == HTTPStatusUnknown {
-		return resp, status, err
+		ids := make([]string, len(customers))
+		for i, customer := range customers {
+			ids[i] = customer.ExternalID
+		}
+
+		return resp, status, newPartialUploadError(err, ids, resp.UploadedCustomers, resp.FailedCustomers)
 	}
 
 	return resp, status, nil
@@ -928,13 +1165,20 @@ func (c *Client) CustomersUpload(customers []Customer, site ...string) (Customer
 //		log.Printf("%v\n", data.Customer)
 //	}
 func (c *Client) Customer(id, by, site string) (CustomerResponse, int, error) {
+	return c.CustomerCtx(c.defaultContext(), id, by, site)
+}
+
+// CustomerCtx is the same as Customer, but uses the provided context.Context.
+func (c *Client) CustomerCtx(ctx context.Context, id, by, site string) (CustomerResponse, int, error) {
+	ctx = withAPIMethod(ctx, "Customer")
+
 	var resp CustomerResponse
 	var context = checkBy(by)
 
 	fw := CustomerRequest{context, site}
 	params, _ := query.Values(fw)
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/customers/%s?%s", id, params.Encode()))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/customers/%s?%s", id, params.Encode()))
 	if err != nil {
 		return resp, status, err
 	}
@@ -978,6 +1222,15 @@ func (c *Client) Customer(id, by, site string) (CustomerResponse, int, error) {
 //		log.Printf("%v\n", data.Customer)
 //	}
 func (c *Client) CustomerEdit(customer Customer, by string, site ...string) (CustomerChangeResponse, int, error) {
+	return c.CustomerEditCtx(c.defaultContext(), customer, by, site...)
+}
+
+// CustomerEditCtx is the same as CustomerEdit, but uses the provided context.Context.
+func (c *Client) CustomerEditCtx(
+	ctx context.Context, customer Customer, by string, site ...string,
+) (CustomerChangeResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CustomerEdit")
+
 	var resp CustomerChangeResponse
 	var uid = strconv.Itoa(customer.ID)
 	var context = checkBy(by)
@@ -986,6 +1239,12 @@ func (c *Client) CustomerEdit(customer Customer, by string, site ...string) (Cus
 		uid = customer.ExternalID
 	}
 
+	if v := c.currentValidator(); v != nil {
+		if err := v.ValidateCustomerEdit(customer, by); err != nil {
+			return resp, 0, err
+		}
+	}
+
 	customerJSON, _ := json.Marshal(&customer)
 
 	p := url.Values{
@@ -995,7 +1254,7 @@ func (c *Client) CustomerEdit(customer Customer, by string, site ...string) (Cus
 
 	fillSite(&p, site)
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/customers/%s/edit", uid), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/customers/%s/edit", uid), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -1035,11 +1294,20 @@ func (c *Client) CustomerEdit(customer Customer, by string, site ...string) (Cus
 //		log.Printf("%v\n", value)
 //	}
 func (c *Client) CorporateCustomers(parameters CorporateCustomersRequest) (CorporateCustomersResponse, int, error) {
+	return c.CorporateCustomersCtx(c.defaultContext(), parameters)
+}
+
+// CorporateCustomersCtx is the same as CorporateCustomers, but uses the provided context.Context.
+func (c *Client) CorporateCustomersCtx(
+	ctx context.Context, parameters CorporateCustomersRequest,
+) (CorporateCustomersResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CorporateCustomers")
+
 	var resp CorporateCustomersResponse
 
 	params, _ := query.Values(parameters)
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/customers-corporate?%s", params.Encode()))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/customers-corporate?%s", params.Encode()))
 	if err != nil {
 		return resp, status, err
 	}
@@ -1078,6 +1346,15 @@ func (c *Client) CorporateCustomers(parameters CorporateCustomersRequest) (Corpo
 func (c *Client) CorporateCustomerCreate(customer CorporateCustomer, site ...string) (
 	CorporateCustomerChangeResponse, int, error,
 ) {
+	return c.CorporateCustomerCreateCtx(c.defaultContext(), customer, site...)
+}
+
+// CorporateCustomerCreateCtx is the same as CorporateCustomerCreate, but uses the provided context.Context.
+func (c *Client) CorporateCustomerCreateCtx(ctx context.Context, customer CorporateCustomer, site ...string) (
+	CorporateCustomerChangeResponse, int, error,
+) {
+	ctx = withAPIMethod(ctx, "CorporateCustomerCreate")
+
 	var resp CorporateCustomerChangeResponse
 
 	customerJSON, _ := json.Marshal(&customer)
@@ -1088,7 +1365,7 @@ func (c *Client) CorporateCustomerCreate(customer CorporateCustomer, site ...str
 
 	fillSite(&p, site)
 
-	data, status, err := c.PostRequest("/customers-corporate/create", p)
+	data, status, err := c.PostRequestCtx(ctx, "/customers-corporate/create", p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -1122,6 +1399,15 @@ func (c *Client) CorporateCustomerCreate(customer CorporateCustomer, site ...str
 //		log.Fatalf("http status: %d, error: %s", status, err)
 //	}
 func (c *Client) CorporateCustomersFixExternalIds(customers []IdentifiersPair) (SuccessfulResponse, int, error) {
+	return c.CorporateCustomersFixExternalIdsCtx(c.defaultContext(), customers)
+}
+
+// CorporateCustomersFixExternalIdsCtx is the same as CorporateCustomersFixExternalIds, but uses the provided context.Context.
+func (c *Client) CorporateCustomersFixExternalIdsCtx(
+	ctx context.Context, customers []IdentifiersPair,
+) (SuccessfulResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CorporateCustomersFixExternalIds")
+
 	var resp SuccessfulResponse
 
 	customersJSON, _ := json.Marshal(&customers)
@@ -1130,7 +1416,7 @@ func (c *Client) CorporateCustomersFixExternalIds(customers []IdentifiersPair) (
 		"customersCorporate": {string(customersJSON)},
 	}
 
-	data, status, err := c.PostRequest("/customers-corporate/fix-external-ids", p)
+	data, status, err := c.PostRequestCtx(ctx, "/customers-corporate/fix-external-ids", p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -1171,11 +1457,20 @@ func (c *Client) CorporateCustomersFixExternalIds(customers []IdentifiersPair) (
 func (c *Client) CorporateCustomersHistory(parameters CorporateCustomersHistoryRequest) (
 	CorporateCustomersHistoryResponse, int, error,
 ) {
+	return c.CorporateCustomersHistoryCtx(c.defaultContext(), parameters)
+}
+
+// CorporateCustomersHistoryCtx is the same as CorporateCustomersHistory, but uses the provided context.Context.
+func (c *Client) CorporateCustomersHistoryCtx(ctx context.Context, parameters CorporateCustomersHistoryRequest) (
+	CorporateCustomersHistoryResponse, int, error,
+) {
+	ctx = withAPIMethod(ctx, "CorporateCustomersHistory")
+
 	var resp CorporateCustomersHistoryResponse
 
 	params, _ := query.Values(parameters)
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/customers-corporate/history?%s", params.Encode()))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/customers-corporate/history?%s", params.Encode()))
 	if err != nil {
 		return resp, status, err
 	}
@@ -1217,11 +1512,20 @@ func (c *Client) CorporateCustomersHistory(parameters CorporateCustomersHistoryR
 func (c *Client) CorporateCustomersNotes(parameters CorporateCustomersNotesRequest) (
 	CorporateCustomersNotesResponse, int, error,
 ) {
+	return c.CorporateCustomersNotesCtx(c.defaultContext(), parameters)
+}
+
+// CorporateCustomersNotesCtx is the same as CorporateCustomersNotes, but uses the provided context.Context.
+func (c *Client) CorporateCustomersNotesCtx(ctx context.Context, parameters CorporateCustomersNotesRequest) (
+	CorporateCustomersNotesResponse, int, error,
+) {
+	ctx = withAPIMethod(ctx, "CorporateCustomersNotes")
+
 	var resp CorporateCustomersNotesResponse
 
 	params, _ := query.Values(parameters)
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/customers-corporate/notes?%s", params.Encode()))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/customers-corporate/notes?%s", params.Encode()))
 	if err != nil {
 		return resp, status, err
 	}
@@ -1261,6 +1565,15 @@ func (c *Client) CorporateCustomersNotes(parameters CorporateCustomersNotesReque
 //			fmt.Printf("%v", data.ID)
 //		}
 func (c *Client) CorporateCustomerNoteCreate(note CorporateCustomerNote, site ...string) (CreateResponse, int, error) {
+	return c.CorporateCustomerNoteCreateCtx(c.defaultContext(), note, site...)
+}
+
+// CorporateCustomerNoteCreateCtx is the same as CorporateCustomerNoteCreate, but uses the provided context.Context.
+func (c *Client) CorporateCustomerNoteCreateCtx(
+	ctx context.Context, note CorporateCustomerNote, site ...string,
+) (CreateResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CorporateCustomerNoteCreate")
+
 	var resp CreateResponse
 
 	noteJSON, _ := json.Marshal(&note)
@@ -1271,7 +1584,7 @@ func (c *Client) CorporateCustomerNoteCreate(note CorporateCustomerNote, site ..
 
 	fillSite(&p, site)
 
-	data, status, err := c.PostRequest("/customers-corporate/notes/create", p)
+	data, status, err := c.PostRequestCtx(ctx, "/customers-corporate/notes/create", p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -1302,13 +1615,20 @@ func (c *Client) CorporateCustomerNoteCreate(note CorporateCustomerNote, site ..
 //		log.Fatalf("http status: %d, error: %s", status, err)
 //	}
 func (c *Client) CorporateCustomerNoteDelete(id int) (SuccessfulResponse, int, error) {
+	return c.CorporateCustomerNoteDeleteCtx(c.defaultContext(), id)
+}
+
+// CorporateCustomerNoteDeleteCtx is the same as CorporateCustomerNoteDelete, but uses the provided context.Context.
+func (c *Client) CorporateCustomerNoteDeleteCtx(ctx context.Context, id int) (SuccessfulResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CorporateCustomerNoteDelete")
+
 	var resp SuccessfulResponse
 
 	p := url.Values{
 		"id": {strconv.Itoa(id)},
 	}
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/customers-corporate/notes/%d/delete", id), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/customers-corporate/notes/%d/delete", id), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -1325,6 +1645,8 @@ func (c *Client) CorporateCustomerNoteDelete(id int) (SuccessfulResponse, int, e
 //
 // # This method can return response together with error if http status is equal 460
 //
+// The error is *PartialUploadError in this case, it can be matched with errors.As.
+//
 // For more information see http://help.retailcrm.pro/Developers/ApiVersion5#post--api-v5-customers-corporate-upload
 //
 // Example:
@@ -1356,6 +1678,16 @@ func (c *Client) CorporateCustomerNoteDelete(id int) (SuccessfulResponse, int, e
 func (c *Client) CorporateCustomersUpload(
 	customers []CorporateCustomer, site ...string,
 ) (CorporateCustomersUploadResponse, int, error) {
+	return c.CorporateCustomersUploadCtx(c.defaultContext(), customers, site...)
+}
+
+// CorporateCustomersUploadCtx is the same as CorporateCustomersUpload, but uses the provided context.Context.
+func (c *Client) CorporateCustomersUploadCtx(
+	ctx context.Context,
+	customers []CorporateCustomer, site ...string,
+) (CorporateCustomersUploadResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CorporateCustomersUpload")
+
 	var resp CorporateCustomersUploadResponse
 
 	uploadJSON, _ := json.Marshal(&customers)
@@ -1366,7 +1698,7 @@ func (c *Client) CorporateCustomersUpload(
 
 	fillSite(&p, site)
 
-	data, status, err := c.PostRequest("/customers-corporate/upload", p)
+	data, status, err := c.PostRequestCtx(ctx, "/customers-corporate/upload", p)
 	if err != nil && status != HTTPStatusUnknown {
 		return resp, status, err
 	}
@@ -1377,7 +1709,12 @@ func (c *Client) CorporateCustomersUpload(
 	}
 
 	if status == HTTPStatusUnknown {
-		return resp, status, err
+		ids := make([]string, len(customers))
+		for i, customer := range customers {
+			ids[i] = customer.ExternalID
+		}
+
+		return resp, status, newPartialUploadError(err, ids, resp.UploadedCustomers, resp.FailedCustomers)
 	}
 
 	return resp, status, nil
@@ -1405,13 +1742,22 @@ func (c *Client) CorporateCustomersUpload(
 //		log.Printf("%v\n", data.CorporateCustomer)
 //	}
 func (c *Client) CorporateCustomer(id, by, site string) (CorporateCustomerResponse, int, error) {
+	return c.CorporateCustomerCtx(c.defaultContext(), id, by, site)
+}
+
+// CorporateCustomerCtx is the same as CorporateCustomer, but uses the provided context.Context.
+func (c *Client) CorporateCustomerCtx(
+	ctx context.Context, id, by, site string,
+) (CorporateCustomerResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CorporateCustomer")
+
 	var resp CorporateCustomerResponse
 	var context = checkBy(by)
 
 	fw := CustomerRequest{context, site}
 	params, _ := query.Values(fw)
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/customers-corporate/%s?%s", id, params.Encode()))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/customers-corporate/%s?%s", id, params.Encode()))
 	if err != nil {
 		return resp, status, err
 	}
@@ -1456,12 +1802,22 @@ func (c *Client) CorporateCustomer(id, by, site string) (CorporateCustomerRespon
 func (c *Client) CorporateCustomerAddresses(
 	id string, parameters CorporateCustomerAddressesRequest,
 ) (CorporateCustomersAddressesResponse, int, error) {
+	return c.CorporateCustomerAddressesCtx(c.defaultContext(), id, parameters)
+}
+
+// CorporateCustomerAddressesCtx is the same as CorporateCustomerAddresses, but uses the provided context.Context.
+func (c *Client) CorporateCustomerAddressesCtx(
+	ctx context.Context,
+	id string, parameters CorporateCustomerAddressesRequest,
+) (CorporateCustomersAddressesResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CorporateCustomerAddresses")
+
 	var resp CorporateCustomersAddressesResponse
 
 	parameters.By = checkBy(parameters.By)
 	params, _ := query.Values(parameters)
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/customers-corporate/%s/addresses?%s", id, params.Encode()))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/customers-corporate/%s/addresses?%s", id, params.Encode()))
 	if err != nil {
 		return resp, status, err
 	}
@@ -1501,6 +1857,16 @@ func (c *Client) CorporateCustomerAddresses(
 func (c *Client) CorporateCustomerAddressesCreate(
 	id string, by string, address CorporateCustomerAddress, site ...string,
 ) (CreateResponse, int, error) {
+	return c.CorporateCustomerAddressesCreateCtx(c.defaultContext(), id, by, address, site...)
+}
+
+// CorporateCustomerAddressesCreateCtx is the same as CorporateCustomerAddressesCreate, but uses the provided context.Context.
+func (c *Client) CorporateCustomerAddressesCreateCtx(
+	ctx context.Context,
+	id string, by string, address CorporateCustomerAddress, site ...string,
+) (CreateResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CorporateCustomerAddressesCreate")
+
 	var resp CreateResponse
 
 	addressJSON, _ := json.Marshal(&address)
@@ -1512,7 +1878,7 @@ func (c *Client) CorporateCustomerAddressesCreate(
 
 	fillSite(&p, site)
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/customers-corporate/%s/addresses/create", id), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/customers-corporate/%s/addresses/create", id), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -1557,6 +1923,16 @@ func (c *Client) CorporateCustomerAddressesCreate(
 func (c *Client) CorporateCustomerAddressesEdit(
 	customerID, customerBy, entityBy string, address CorporateCustomerAddress, site ...string,
 ) (CreateResponse, int, error) {
+	return c.CorporateCustomerAddressesEditCtx(c.defaultContext(), customerID, customerBy, entityBy, address, site...)
+}
+
+// CorporateCustomerAddressesEditCtx is the same as CorporateCustomerAddressesEdit, but uses the provided context.Context.
+func (c *Client) CorporateCustomerAddressesEditCtx(
+	ctx context.Context,
+	customerID, customerBy, entityBy string, address CorporateCustomerAddress, site ...string,
+) (CreateResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CorporateCustomerAddressesEdit")
+
 	var (
 		resp CreateResponse
 		uid  string
@@ -1582,7 +1958,7 @@ func (c *Client) CorporateCustomerAddressesEdit(
 
 	fillSite(&p, site)
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/customers-corporate/%s/addresses/%s/edit", customerID, uid), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/customers-corporate/%s/addresses/%s/edit", customerID, uid), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -1627,12 +2003,22 @@ func (c *Client) CorporateCustomerAddressesEdit(
 func (c *Client) CorporateCustomerCompanies(
 	id string, parameters IdentifiersPairRequest,
 ) (CorporateCustomerCompaniesResponse, int, error) {
+	return c.CorporateCustomerCompaniesCtx(c.defaultContext(), id, parameters)
+}
+
+// CorporateCustomerCompaniesCtx is the same as CorporateCustomerCompanies, but uses the provided context.Context.
+func (c *Client) CorporateCustomerCompaniesCtx(
+	ctx context.Context,
+	id string, parameters IdentifiersPairRequest,
+) (CorporateCustomerCompaniesResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CorporateCustomerCompanies")
+
 	var resp CorporateCustomerCompaniesResponse
 
 	parameters.By = checkBy(parameters.By)
 	params, _ := query.Values(parameters)
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/customers-corporate/%s/companies?%s", id, params.Encode()))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/customers-corporate/%s/companies?%s", id, params.Encode()))
 	if err != nil {
 		return resp, status, err
 	}
@@ -1671,6 +2057,16 @@ func (c *Client) CorporateCustomerCompanies(
 func (c *Client) CorporateCustomerCompaniesCreate(
 	id string, by string, company Company, site ...string,
 ) (CreateResponse, int, error) {
+	return c.CorporateCustomerCompaniesCreateCtx(c.defaultContext(), id, by, company, site...)
+}
+
+// CorporateCustomerCompaniesCreateCtx is the same as CorporateCustomerCompaniesCreate, but uses the provided context.Context.
+func (c *Client) CorporateCustomerCompaniesCreateCtx(
+	ctx context.Context,
+	id string, by string, company Company, site ...string,
+) (CreateResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CorporateCustomerCompaniesCreate")
+
 	var resp CreateResponse
 
 	companyJSON, _ := json.Marshal(&company)
@@ -1682,7 +2078,7 @@ func (c *Client) CorporateCustomerCompaniesCreate(
 
 	fillSite(&p, site)
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/customers-corporate/%s/companies/create", id), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/customers-corporate/%s/companies/create", id), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -1727,6 +2123,16 @@ func (c *Client) CorporateCustomerCompaniesCreate(
 func (c *Client) CorporateCustomerCompaniesEdit(
 	customerID, customerBy, entityBy string, company Company, site ...string,
 ) (CreateResponse, int, error) {
+	return c.CorporateCustomerCompaniesEditCtx(c.defaultContext(), customerID, customerBy, entityBy, company, site...)
+}
+
+// CorporateCustomerCompaniesEditCtx is the same as CorporateCustomerCompaniesEdit, but uses the provided context.Context.
+func (c *Client) CorporateCustomerCompaniesEditCtx(
+	ctx context.Context,
+	customerID, customerBy, entityBy string, company Company, site ...string,
+) (CreateResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CorporateCustomerCompaniesEdit")
+
 	var (
 		resp CreateResponse
 		uid  string
@@ -1752,7 +2158,7 @@ func (c *Client) CorporateCustomerCompaniesEdit(
 
 	fillSite(&p, site)
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/customers-corporate/%s/companies/%s/edit", customerID, uid), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/customers-corporate/%s/companies/%s/edit", customerID, uid), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -1797,12 +2203,22 @@ func (c *Client) CorporateCustomerCompaniesEdit(
 func (c *Client) CorporateCustomerContacts(
 	id string, parameters IdentifiersPairRequest,
 ) (CorporateCustomerContactsResponse, int, error) {
+	return c.CorporateCustomerContactsCtx(c.defaultContext(), id, parameters)
+}
+
+// CorporateCustomerContactsCtx is the same as CorporateCustomerContacts, but uses the provided context.Context.
+func (c *Client) CorporateCustomerContactsCtx(
+	ctx context.Context,
+	id string, parameters IdentifiersPairRequest,
+) (CorporateCustomerContactsResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CorporateCustomerContacts")
+
 	var resp CorporateCustomerContactsResponse
 
 	parameters.By = checkBy(parameters.By)
 	params, _ := query.Values(parameters)
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/customers-corporate/%s/contacts?%s", id, params.Encode()))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/customers-corporate/%s/contacts?%s", id, params.Encode()))
 	if err != nil {
 		return resp, status, err
 	}
@@ -1846,6 +2262,16 @@ func (c *Client) CorporateCustomerContacts(
 func (c *Client) CorporateCustomerContactsCreate(
 	id string, by string, contact CorporateCustomerContact, site ...string,
 ) (CreateResponse, int, error) {
+	return c.CorporateCustomerContactsCreateCtx(c.defaultContext(), id, by, contact, site...)
+}
+
+// CorporateCustomerContactsCreateCtx is the same as CorporateCustomerContactsCreate, but uses the provided context.Context.
+func (c *Client) CorporateCustomerContactsCreateCtx(
+	ctx context.Context,
+	id string, by string, contact CorporateCustomerContact, site ...string,
+) (CreateResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CorporateCustomerContactsCreate")
+
 	var resp CreateResponse
 
 	companyJSON, _ := json.Marshal(&contact)
@@ -1857,7 +2283,7 @@ func (c *Client) CorporateCustomerContactsCreate(
 
 	fillSite(&p, site)
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/customers-corporate/%s/contacts/create", id), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/customers-corporate/%s/contacts/create", id), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -1899,6 +2325,16 @@ func (c *Client) CorporateCustomerContactsCreate(
 func (c *Client) CorporateCustomerContactsEdit(
 	customerID, customerBy, entityBy string, contact CorporateCustomerContact, site ...string,
 ) (CreateResponse, int, error) {
+	return c.CorporateCustomerContactsEditCtx(c.defaultContext(), customerID, customerBy, entityBy, contact, site...)
+}
+
+// CorporateCustomerContactsEditCtx is the same as CorporateCustomerContactsEdit, but uses the provided context.Context.
+func (c *Client) CorporateCustomerContactsEditCtx(
+	ctx context.Context,
+	customerID, customerBy, entityBy string, contact CorporateCustomerContact, site ...string,
+) (CreateResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CorporateCustomerContactsEdit")
+
 	var (
 		resp CreateResponse
 		uid  string
@@ -1924,7 +2360,7 @@ func (c *Client) CorporateCustomerContactsEdit(
 
 	fillSite(&p, site)
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/customers-corporate/%s/contacts/%s/edit", customerID, uid), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/customers-corporate/%s/contacts/%s/edit", customerID, uid), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -1970,6 +2406,17 @@ func (c *Client) CorporateCustomerContactsEdit(
 func (c *Client) CorporateCustomerEdit(customer CorporateCustomer, by string, site ...string) (
 	CustomerChangeResponse, int, error,
 ) {
+	return c.CorporateCustomerEditCtx(c.defaultContext(), customer, by, site...)
+}
+
+// CorporateCustomerEditCtx is the same as CorporateCustomerEdit, but uses the provided context.Context.
+func (c *Client) CorporateCustomerEditCtx(
+	ctx context.Context, customer CorporateCustomer, by string, site ...string,
+) (
+	CustomerChangeResponse, int, error,
+) {
+	ctx = withAPIMethod(ctx, "CorporateCustomerEdit")
+
 	var resp CustomerChangeResponse
 	var uid = strconv.Itoa(customer.ID)
 	var context = checkBy(by)
@@ -1987,7 +2434,7 @@ func (c *Client) CorporateCustomerEdit(customer CorporateCustomer, by string, si
 
 	fillSite(&p, site)
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/customers-corporate/%s/edit", uid), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/customers-corporate/%s/edit", uid), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -2036,6 +2483,15 @@ func (c *Client) CorporateCustomerEdit(customer CorporateCustomer, by string, si
 func (c *Client) ClearCart(site string, filter SiteFilter, req ClearCartRequest) (
 	SuccessfulResponse, int, error,
 ) {
+	return c.ClearCartCtx(c.defaultContext(), site, filter, req)
+}
+
+// ClearCartCtx is the same as ClearCart, but uses the provided context.Context.
+func (c *Client) ClearCartCtx(ctx context.Context, site string, filter SiteFilter, req ClearCartRequest) (
+	SuccessfulResponse, int, error,
+) {
+	ctx = withAPIMethod(ctx, "ClearCart")
+
 	var resp SuccessfulResponse
 
 	updateJSON, err := json.Marshal(&req)
@@ -2049,7 +2505,7 @@ func (c *Client) ClearCart(site string, filter SiteFilter, req ClearCartRequest)
 
 	params, _ := query.Values(filter)
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/customer-interaction/%s/cart/clear?%s", site, params.Encode()), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/customer-interaction/%s/cart/clear?%s", site, params.Encode()), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -2106,6 +2562,15 @@ func (c *Client) ClearCart(site string, filter SiteFilter, req ClearCartRequest)
 func (c *Client) SetCart(site string, filter SiteFilter, req SetCartRequest) (
 	SuccessfulResponse, int, error,
 ) {
+	return c.SetCartCtx(c.defaultContext(), site, filter, req)
+}
+
+// SetCartCtx is the same as SetCart, but uses the provided context.Context.
+func (c *Client) SetCartCtx(ctx context.Context, site string, filter SiteFilter, req SetCartRequest) (
+	SuccessfulResponse, int, error,
+) {
+	ctx = withAPIMethod(ctx, "SetCart")
+
 	var resp SuccessfulResponse
 
 	updateJSON, err := json.Marshal(&req)
@@ -2119,7 +2584,7 @@ func (c *Client) SetCart(site string, filter SiteFilter, req SetCartRequest) (
 
 	params, _ := query.Values(filter)
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/customer-interaction/%s/cart/set?%s", site, params.Encode()), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/customer-interaction/%s/cart/set?%s", site, params.Encode()), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -2151,11 +2616,20 @@ func (c *Client) SetCart(site string, filter SiteFilter, req SetCartRequest) (
 //		log.Fatalf("http status: %d, error: %s", status, err)
 //	}
 func (c *Client) GetCart(site, customer string, filter GetCartFilter) (CartResponse, int, error) {
+	return c.GetCartCtx(c.defaultContext(), site, customer, filter)
+}
+
+// GetCartCtx is the same as GetCart, but uses the provided context.Context.
+func (c *Client) GetCartCtx(
+	ctx context.Context, site, customer string, filter GetCartFilter,
+) (CartResponse, int, error) {
+	ctx = withAPIMethod(ctx, "GetCart")
+
 	var resp CartResponse
 
 	params, _ := query.Values(filter)
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/customer-interaction/%s/cart/%s?%s", site, customer, params.Encode()))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/customer-interaction/%s/cart/%s?%s", site, customer, params.Encode()))
 	if err != nil {
 		return resp, status, err
 	}
@@ -2187,11 +2661,20 @@ func (c *Client) GetCart(site, customer string, filter GetCartFilter) (CartRespo
 //		log.Fatalf("http status: %d, error: %s", status, err)
 //	}
 func (c *Client) GetFavorites(site, customer string, filter FavoritesFilter) (FavoritesResponse, int, error) {
+	return c.GetFavoritesCtx(c.defaultContext(), site, customer, filter)
+}
+
+// GetFavoritesCtx is the same as GetFavorites, but uses the provided context.Context.
+func (c *Client) GetFavoritesCtx(
+	ctx context.Context, site, customer string, filter FavoritesFilter,
+) (FavoritesResponse, int, error) {
+	ctx = withAPIMethod(ctx, "GetFavorites")
+
 	var resp FavoritesResponse
 
 	params, _ := query.Values(filter)
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/customer-interaction/%s/favorites/%s?%s", site, customer, params.Encode()))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/customer-interaction/%s/favorites/%s?%s", site, customer, params.Encode()))
 	if err != nil {
 		return resp, status, err
 	}
@@ -2232,6 +2715,17 @@ func (c *Client) GetFavorites(site, customer string, filter FavoritesFilter) (Fa
 func (c *Client) AddFavorite(site, customer string, filter FavoritesFilter, req ChangeFavoritesRequest) (
 	SuccessfulResponse, int, error,
 ) {
+	return c.AddFavoriteCtx(c.defaultContext(), site, customer, filter, req)
+}
+
+// AddFavoriteCtx is the same as AddFavorite, but uses the provided context.Context.
+func (c *Client) AddFavoriteCtx(
+	ctx context.Context, site, customer string, filter FavoritesFilter, req ChangeFavoritesRequest,
+) (
+	SuccessfulResponse, int, error,
+) {
+	ctx = withAPIMethod(ctx, "AddFavorite")
+
 	var resp SuccessfulResponse
 
 	updateJSON, err := json.Marshal(&req)
@@ -2245,7 +2739,7 @@ func (c *Client) AddFavorite(site, customer string, filter FavoritesFilter, req
 
 	params, _ := query.Values(filter)
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/customer-interaction/%s/favorites/%s/add?%s", site, customer, params.Encode()), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/customer-interaction/%s/favorites/%s/add?%s", site, customer, params.Encode()), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -2286,6 +2780,17 @@ func (c *Client) AddFavorite(site, customer string, filter FavoritesFilter, req
 func (c *Client) RemoveFavorite(site, customer string, filter FavoritesFilter, req ChangeFavoritesRequest) (
 	SuccessfulResponse, int, error,
 ) {
+	return c.RemoveFavoriteCtx(c.defaultContext(), site, customer, filter, req)
+}
+
+// RemoveFavoriteCtx is the same as RemoveFavorite, but uses the provided context.Context.
+func (c *Client) RemoveFavoriteCtx(
+	ctx context.Context, site, customer string, filter FavoritesFilter, req ChangeFavoritesRequest,
+) (
+	SuccessfulResponse, int, error,
+) {
+	ctx = withAPIMethod(ctx, "RemoveFavorite")
+
 	var resp SuccessfulResponse
 
 	updateJSON, err := json.Marshal(&req)
@@ -2299,7 +2804,7 @@ func (c *Client) RemoveFavorite(site, customer string, filter FavoritesFilter, r
 
 	params, _ := query.Values(filter)
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/customer-interaction/%s/favorites/%s/remove?%s", site, customer, params.Encode()), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/customer-interaction/%s/favorites/%s/remove?%s", site, customer, params.Encode()), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -2345,6 +2850,15 @@ func (c *Client) RemoveFavorite(site, customer string, filter FavoritesFilter, r
 func (c *Client) DeliveryTracking(parameters []DeliveryTrackingRequest, subcode string) (
 	SuccessfulResponse, int, error,
 ) {
+	return c.DeliveryTrackingCtx(c.defaultContext(), parameters, subcode)
+}
+
+// DeliveryTrackingCtx is the same as DeliveryTracking, but uses the provided context.Context.
+func (c *Client) DeliveryTrackingCtx(ctx context.Context, parameters []DeliveryTrackingRequest, subcode string) (
+	SuccessfulResponse, int, error,
+) {
+	ctx = withAPIMethod(ctx, "DeliveryTracking")
+
 	var resp SuccessfulResponse
 
 	updateJSON, _ := json.Marshal(&parameters)
@@ -2353,7 +2867,7 @@ func (c *Client) DeliveryTracking(parameters []DeliveryTrackingRequest, subcode
 		"statusUpdate": {string(updateJSON)},
 	}
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/delivery/generic/%s/tracking", subcode), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/delivery/generic/%s/tracking", subcode), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -2393,11 +2907,20 @@ func (c *Client) DeliveryTracking(parameters []DeliveryTrackingRequest, subcode
 //		log.Printf("%v\n", value)
 //	}
 func (c *Client) DeliveryShipments(parameters DeliveryShipmentsRequest) (DeliveryShipmentsResponse, int, error) {
+	return c.DeliveryShipmentsCtx(c.defaultContext(), parameters)
+}
+
+// DeliveryShipmentsCtx is the same as DeliveryShipments, but uses the provided context.Context.
+func (c *Client) DeliveryShipmentsCtx(
+	ctx context.Context, parameters DeliveryShipmentsRequest,
+) (DeliveryShipmentsResponse, int, error) {
+	ctx = withAPIMethod(ctx, "DeliveryShipments")
+
 	var resp DeliveryShipmentsResponse
 
 	params, _ := query.Values(parameters)
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/delivery/shipments?%s", params.Encode()))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/delivery/shipments?%s", params.Encode()))
 	if err != nil {
 		return resp, status, err
 	}
@@ -2444,6 +2967,16 @@ func (c *Client) DeliveryShipments(parameters DeliveryShipmentsRequest) (Deliver
 func (c *Client) DeliveryShipmentCreate(
 	shipment DeliveryShipment, deliveryType string, site ...string,
 ) (DeliveryShipmentUpdateResponse, int, error) {
+	return c.DeliveryShipmentCreateCtx(c.defaultContext(), shipment, deliveryType, site...)
+}
+
+// DeliveryShipmentCreateCtx is the same as DeliveryShipmentCreate, but uses the provided context.Context.
+func (c *Client) DeliveryShipmentCreateCtx(
+	ctx context.Context,
+	shipment DeliveryShipment, deliveryType string, site ...string,
+) (DeliveryShipmentUpdateResponse, int, error) {
+	ctx = withAPIMethod(ctx, "DeliveryShipmentCreate")
+
 	var resp DeliveryShipmentUpdateResponse
 	updateJSON, _ := json.Marshal(&shipment)
 
@@ -2454,7 +2987,7 @@ func (c *Client) DeliveryShipmentCreate(
 
 	fillSite(&p, site)
 
-	data, status, err := c.PostRequest("/delivery/shipments/create", p)
+	data, status, err := c.PostRequestCtx(ctx, "/delivery/shipments/create", p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -2489,9 +3022,16 @@ func (c *Client) DeliveryShipmentCreate(
 //		log.Printf("%v\n", data.DeliveryShipment)
 //	}
 func (c *Client) DeliveryShipment(id int) (DeliveryShipmentResponse, int, error) {
+	return c.DeliveryShipmentCtx(c.defaultContext(), id)
+}
+
+// DeliveryShipmentCtx is the same as DeliveryShipment, but uses the provided context.Context.
+func (c *Client) DeliveryShipmentCtx(ctx context.Context, id int) (DeliveryShipmentResponse, int, error) {
+	ctx = withAPIMethod(ctx, "DeliveryShipment")
+
 	var resp DeliveryShipmentResponse
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/delivery/shipments/%d", id))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/delivery/shipments/%d", id))
 	if err != nil {
 		return resp, status, err
 	}
@@ -2530,6 +3070,15 @@ func (c *Client) DeliveryShipment(id int) (DeliveryShipmentResponse, int, error)
 func (c *Client) DeliveryShipmentEdit(shipment DeliveryShipment, site ...string) (
 	DeliveryShipmentUpdateResponse, int, error,
 ) {
+	return c.DeliveryShipmentEditCtx(c.defaultContext(), shipment, site...)
+}
+
+// DeliveryShipmentEditCtx is the same as DeliveryShipmentEdit, but uses the provided context.Context.
+func (c *Client) DeliveryShipmentEditCtx(ctx context.Context, shipment DeliveryShipment, site ...string) (
+	DeliveryShipmentUpdateResponse, int, error,
+) {
+	ctx = withAPIMethod(ctx, "DeliveryShipmentEdit")
+
 	var resp DeliveryShipmentUpdateResponse
 	updateJSON, _ := json.Marshal(&shipment)
 
@@ -2539,7 +3088,7 @@ func (c *Client) DeliveryShipmentEdit(shipment DeliveryShipment, site ...string)
 
 	fillSite(&p, site)
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/delivery/shipments/%s/edit", strconv.Itoa(shipment.ID)), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/delivery/shipments/%s/edit", strconv.Itoa(shipment.ID)), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -2574,9 +3123,16 @@ func (c *Client) DeliveryShipmentEdit(shipment DeliveryShipment, site ...string)
 //		log.Printf("%v\n", data.IntegrationModule)
 //	}
 func (c *Client) IntegrationModule(code string) (IntegrationModuleResponse, int, error) {
+	return c.IntegrationModuleCtx(c.defaultContext(), code)
+}
+
+// IntegrationModuleCtx is the same as IntegrationModule, but uses the provided context.Context.
+func (c *Client) IntegrationModuleCtx(ctx context.Context, code string) (IntegrationModuleResponse, int, error) {
+	ctx = withAPIMethod(ctx, "IntegrationModule")
+
 	var resp IntegrationModuleResponse
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/integration-modules/%s", code))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/integration-modules/%s", code))
 	if err != nil {
 		return resp, status, err
 	}
@@ -2614,6 +3170,15 @@ func (c *Client) IntegrationModule(code string) (IntegrationModuleResponse, int,
 //		log.Println("Creating a link")
 //	}
 func (c *Client) LinksCreate(link SerializedOrderLink, site ...string) (SuccessfulResponse, int, error) {
+	return c.LinksCreateCtx(c.defaultContext(), link, site...)
+}
+
+// LinksCreateCtx is the same as LinksCreate, but uses the provided context.Context.
+func (c *Client) LinksCreateCtx(
+	ctx context.Context, link SerializedOrderLink, site ...string,
+) (SuccessfulResponse, int, error) {
+	ctx = withAPIMethod(ctx, "LinksCreate")
+
 	var resp SuccessfulResponse
 
 	linkJSON, err := json.Marshal(link)
@@ -2628,7 +3193,7 @@ func (c *Client) LinksCreate(link SerializedOrderLink, site ...string) (Successf
 
 	fillSite(&p, site)
 
-	data, status, err := c.PostRequest("/orders/links/create", p)
+	data, status, err := c.PostRequestCtx(ctx, "/orders/links/create", p)
 
 	if err != nil {
 		return resp, status, err
@@ -2677,6 +3242,13 @@ func (c *Client) LinksCreate(link SerializedOrderLink, site ...string) (Successf
 //		log.Println("Upload is successful")
 //	}
 func (c *Client) ClientIdsUpload(clientIds []ClientID) (ClientIDResponse, int, error) {
+	return c.ClientIdsUploadCtx(c.defaultContext(), clientIds)
+}
+
+// ClientIdsUploadCtx is the same as ClientIdsUpload, but uses the provided context.Context.
+func (c *Client) ClientIdsUploadCtx(ctx context.Context, clientIds []ClientID) (ClientIDResponse, int, error) {
+	ctx = withAPIMethod(ctx, "ClientIdsUpload")
+
 	var resp ClientIDResponse
 	clientIdsJSON, err := json.Marshal(&clientIds)
 
@@ -2688,7 +3260,7 @@ func (c *Client) ClientIdsUpload(clientIds []ClientID) (ClientIDResponse, int, e
 		"clientIds": {string(clientIdsJSON)},
 	}
 
-	data, status, err := c.PostRequest("/web-analytics/client-ids/upload", p)
+	data, status, err := c.PostRequestCtx(ctx, "/web-analytics/client-ids/upload", p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -2735,6 +3307,13 @@ func (c *Client) ClientIdsUpload(clientIds []ClientID) (ClientIDResponse, int, e
 //		log.Println("Upload is successful!")
 //	}
 func (c *Client) SourcesUpload(sources []Source) (SourcesResponse, int, error) {
+	return c.SourcesUploadCtx(c.defaultContext(), sources)
+}
+
+// SourcesUploadCtx is the same as SourcesUpload, but uses the provided context.Context.
+func (c *Client) SourcesUploadCtx(ctx context.Context, sources []Source) (SourcesResponse, int, error) {
+	ctx = withAPIMethod(ctx, "SourcesUpload")
+
 	var resp SourcesResponse
 	sourcesJSON, err := json.Marshal(&sources)
 
@@ -2746,7 +3325,7 @@ func (c *Client) SourcesUpload(sources []Source) (SourcesResponse, int, error) {
 		"sources": {string(sourcesJSON)},
 	}
 
-	data, status, err := c.PostRequest("/web-analytics/sources/upload", p)
+	data, status, err := c.PostRequestCtx(ctx, "/web-analytics/sources/upload", p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -2781,9 +3360,16 @@ func (c *Client) SourcesUpload(sources []Source) (SourcesResponse, int, error) {
 //		log.Printf("%v\n", value)
 //	}
 func (c *Client) Currencies() (CurrencyResponse, int, error) {
+	return c.CurrenciesCtx(c.defaultContext())
+}
+
+// CurrenciesCtx is the same as Currencies, but uses the provided context.Context.
+func (c *Client) CurrenciesCtx(ctx context.Context) (CurrencyResponse, int, error) {
+	ctx = withAPIMethod(ctx, "Currencies")
+
 	var resp CurrencyResponse
 
-	data, status, err := c.GetRequest("/reference/currencies")
+	data, status, err := c.GetRequestCtx(ctx, "/reference/currencies")
 	if err != nil {
 		return resp, status, err
 	}
@@ -2825,6 +3411,13 @@ func (c *Client) Currencies() (CurrencyResponse, int, error) {
 //		log.Println("Create currency")
 //	}
 func (c *Client) CurrenciesCreate(currency Currency) (CurrencyCreateResponse, int, error) {
+	return c.CurrenciesCreateCtx(c.defaultContext(), currency)
+}
+
+// CurrenciesCreateCtx is the same as CurrenciesCreate, but uses the provided context.Context.
+func (c *Client) CurrenciesCreateCtx(ctx context.Context, currency Currency) (CurrencyCreateResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CurrenciesCreate")
+
 	var resp CurrencyCreateResponse
 	currencyJSON, err := json.Marshal(&currency)
 
@@ -2836,7 +3429,7 @@ func (c *Client) CurrenciesCreate(currency Currency) (CurrencyCreateResponse, in
 		"currency": {string(currencyJSON)},
 	}
 
-	data, status, err := c.PostRequest("/reference/currencies/create", p)
+	data, status, err := c.PostRequestCtx(ctx, "/reference/currencies/create", p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -2880,6 +3473,13 @@ func (c *Client) CurrenciesCreate(currency Currency) (CurrencyCreateResponse, in
 //		log.Println("Currency was edit")
 //	}
 func (c *Client) CurrenciesEdit(currency Currency) (SuccessfulResponse, int, error) {
+	return c.CurrenciesEditCtx(c.defaultContext(), currency)
+}
+
+// CurrenciesEditCtx is the same as CurrenciesEdit, but uses the provided context.Context.
+func (c *Client) CurrenciesEditCtx(ctx context.Context, currency Currency) (SuccessfulResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CurrenciesEdit")
+
 	var resp SuccessfulResponse
 	var uid = strconv.Itoa(currency.ID)
 
@@ -2893,7 +3493,7 @@ func (c *Client) CurrenciesEdit(currency Currency) (SuccessfulResponse, int, err
 		"currency": {string(currencyJSON)},
 	}
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/reference/currencies/%s/edit", uid), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/reference/currencies/%s/edit", uid), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -2942,12 +3542,21 @@ func (c *Client) CurrenciesEdit(currency Currency) (SuccessfulResponse, int, err
 func (c *Client) IntegrationModuleEdit(integrationModule IntegrationModule) (
 	IntegrationModuleEditResponse, int, error,
 ) {
+	return c.IntegrationModuleEditCtx(c.defaultContext(), integrationModule)
+}
+
+// IntegrationModuleEditCtx is the same as IntegrationModuleEdit, but uses the provided context.Context.
+func (c *Client) IntegrationModuleEditCtx(ctx context.Context, integrationModule IntegrationModule) (
+	IntegrationModuleEditResponse, int, error,
+) {
+	ctx = withAPIMethod(ctx, "IntegrationModuleEdit")
+
 	var resp IntegrationModuleEditResponse
 	updateJSON, _ := json.Marshal(&integrationModule)
 
 	p := url.Values{"integrationModule": {string(updateJSON)}}
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/integration-modules/%s/edit", integrationModule.Code), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/integration-modules/%s/edit", integrationModule.Code), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -2984,6 +3593,15 @@ func (c *Client) IntegrationModuleEdit(integrationModule IntegrationModule) (
 //		fmt.Printf("%v\n", data.APIKey)
 //	}
 func (c *Client) UpdateScopes(code string, request ScopesRequired) (UpdateScopesResponse, int, error) {
+	return c.UpdateScopesCtx(c.defaultContext(), code, request)
+}
+
+// UpdateScopesCtx is the same as UpdateScopes, but uses the provided context.Context.
+func (c *Client) UpdateScopesCtx(
+	ctx context.Context, code string, request ScopesRequired,
+) (UpdateScopesResponse, int, error) {
+	ctx = withAPIMethod(ctx, "UpdateScopes")
+
 	var resp UpdateScopesResponse
 	updateJSON, _ := json.Marshal(&request)
 
@@ -2991,7 +3609,7 @@ func (c *Client) UpdateScopes(code string, request ScopesRequired) (UpdateScopes
 		"requires": {string(updateJSON)},
 	}
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/integration-modules/%s/update-scopes", code), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/integration-modules/%s/update-scopes", code), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -3026,11 +3644,18 @@ func (c *Client) UpdateScopes(code string, request ScopesRequired) (UpdateScopes
 //		log.Printf("%v\n", value)
 //	}
 func (c *Client) Orders(parameters OrdersRequest) (OrdersResponse, int, error) {
+	return c.OrdersCtx(c.defaultContext(), parameters)
+}
+
+// OrdersCtx is the same as Orders, but uses the provided context.Context.
+func (c *Client) OrdersCtx(ctx context.Context, parameters OrdersRequest) (OrdersResponse, int, error) {
+	ctx = withAPIMethod(ctx, "Orders")
+
 	var resp OrdersResponse
 
 	params, _ := query.Values(parameters)
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/orders?%s", params.Encode()))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/orders?%s", params.Encode()))
 	if err != nil {
 		return resp, status, err
 	}
@@ -3061,6 +3686,15 @@ func (c *Client) Orders(parameters OrdersRequest) (OrdersResponse, int, error) {
 //		log.Fatalf("http status: %d, error: %s", status, err)
 //	}
 func (c *Client) OrdersCombine(technique string, order, resultOrder Order) (OperationResponse, int, error) {
+	return c.OrdersCombineCtx(c.defaultContext(), technique, order, resultOrder)
+}
+
+// OrdersCombineCtx is the same as OrdersCombine, but uses the provided context.Context.
+func (c *Client) OrdersCombineCtx(
+	ctx context.Context, technique string, order, resultOrder Order,
+) (OperationResponse, int, error) {
+	ctx = withAPIMethod(ctx, "OrdersCombine")
+
 	var resp OperationResponse
 
 	combineJSONIn, _ := json.Marshal(&order)
@@ -3072,7 +3706,7 @@ func (c *Client) OrdersCombine(technique string, order, resultOrder Order) (Oper
 		"resultOrder": {string(combineJSONOut)},
 	}
 
-	data, status, err := c.PostRequest("/orders/combine", p)
+	data, status, err := c.PostRequestCtx(ctx, "/orders/combine", p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -3113,7 +3747,21 @@ func (c *Client) OrdersCombine(technique string, order, resultOrder Order) (Oper
 //		log.Printf("%v\n", data.ID)
 //	}
 func (c *Client) OrderCreate(order Order, site ...string) (OrderCreateResponse, int, error) {
+	return c.OrderCreateCtx(c.defaultContext(), order, site...)
+}
+
+// OrderCreateCtx is the same as OrderCreate, but uses the provided context.Context.
+func (c *Client) OrderCreateCtx(ctx context.Context, order Order, site ...string) (OrderCreateResponse, int, error) {
+	ctx = withAPIMethod(ctx, "OrderCreate")
+
 	var resp OrderCreateResponse
+
+	if v := c.currentValidator(); v != nil {
+		if err := v.ValidateOrderCreate(order); err != nil {
+			return resp, 0, err
+		}
+	}
+
 	orderJSON, _ := json.Marshal(&order)
 
 	p := url.Values{
@@ -3122,7 +3770,7 @@ func (c *Client) OrderCreate(order Order, site ...string) (OrderCreateResponse,
 
 	fillSite(&p, site)
 
-	data, status, err := c.PostRequest("/orders/create", p)
+	data, status, err := c.PostRequestCtx(ctx, "/orders/create", p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -3160,6 +3808,15 @@ func (c *Client) OrderCreate(order Order, site ...string) (OrderCreateResponse,
 //		fmt.Printf("%v\n", data.ID)
 //	}
 func (c *Client) OrdersFixExternalIds(orders []IdentifiersPair) (SuccessfulResponse, int, error) {
+	return c.OrdersFixExternalIdsCtx(c.defaultContext(), orders)
+}
+
+// OrdersFixExternalIdsCtx is the same as OrdersFixExternalIds, but uses the provided context.Context.
+func (c *Client) OrdersFixExternalIdsCtx(
+	ctx context.Context, orders []IdentifiersPair,
+) (SuccessfulResponse, int, error) {
+	ctx = withAPIMethod(ctx, "OrdersFixExternalIds")
+
 	var resp SuccessfulResponse
 
 	ordersJSON, _ := json.Marshal(&orders)
@@ -3168,7 +3825,7 @@ func (c *Client) OrdersFixExternalIds(orders []IdentifiersPair) (SuccessfulRespo
 		"orders": {string(ordersJSON)},
 	}
 
-	data, status, err := c.PostRequest("/orders/fix-external-ids", p)
+	data, status, err := c.PostRequestCtx(ctx, "/orders/fix-external-ids", p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -3203,11 +3860,20 @@ func (c *Client) OrdersFixExternalIds(orders []IdentifiersPair) (SuccessfulRespo
 //		log.Printf("%v\n", value)
 //	}
 func (c *Client) OrdersHistory(parameters OrdersHistoryRequest) (OrdersHistoryResponse, int, error) {
+	return c.OrdersHistoryCtx(c.defaultContext(), parameters)
+}
+
+// OrdersHistoryCtx is the same as OrdersHistory, but uses the provided context.Context.
+func (c *Client) OrdersHistoryCtx(
+	ctx context.Context, parameters OrdersHistoryRequest,
+) (OrdersHistoryResponse, int, error) {
+	ctx = withAPIMethod(ctx, "OrdersHistory")
+
 	var resp OrdersHistoryResponse
 
 	params, _ := query.Values(parameters)
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/orders/history?%s", params.Encode()))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/orders/history?%s", params.Encode()))
 	if err != nil {
 		return resp, status, err
 	}
@@ -3248,6 +3914,15 @@ func (c *Client) OrdersHistory(parameters OrdersHistoryRequest) (OrdersHistoryRe
 //		log.Printf("%v\n", data.ID)
 //	}
 func (c *Client) OrderPaymentCreate(payment Payment, site ...string) (CreateResponse, int, error) {
+	return c.OrderPaymentCreateCtx(c.defaultContext(), payment, site...)
+}
+
+// OrderPaymentCreateCtx is the same as OrderPaymentCreate, but uses the provided context.Context.
+func (c *Client) OrderPaymentCreateCtx(
+	ctx context.Context, payment Payment, site ...string,
+) (CreateResponse, int, error) {
+	ctx = withAPIMethod(ctx, "OrderPaymentCreate")
+
 	var resp CreateResponse
 
 	paymentJSON, _ := json.Marshal(&payment)
@@ -3258,7 +3933,7 @@ func (c *Client) OrderPaymentCreate(payment Payment, site ...string) (CreateResp
 
 	fillSite(&p, site)
 
-	data, status, err := c.PostRequest("/orders/payments/create", p)
+	data, status, err := c.PostRequestCtx(ctx, "/orders/payments/create", p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -3289,13 +3964,20 @@ func (c *Client) OrderPaymentCreate(payment Payment, site ...string) (CreateResp
 //		log.Fatalf("http status: %d, error: %s", status, err)
 //	}
 func (c *Client) OrderPaymentDelete(id int) (SuccessfulResponse, int, error) {
+	return c.OrderPaymentDeleteCtx(c.defaultContext(), id)
+}
+
+// OrderPaymentDeleteCtx is the same as OrderPaymentDelete, but uses the provided context.Context.
+func (c *Client) OrderPaymentDeleteCtx(ctx context.Context, id int) (SuccessfulResponse, int, error) {
+	ctx = withAPIMethod(ctx, "OrderPaymentDelete")
+
 	var resp SuccessfulResponse
 
 	p := url.Values{
 		"id": {strconv.Itoa(id)},
 	}
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/orders/payments/%d/delete", id), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/orders/payments/%d/delete", id), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -3332,6 +4014,15 @@ func (c *Client) OrderPaymentDelete(id int) (SuccessfulResponse, int, error) {
 //		log.Fatalf("http status: %d, error: %s", status, err)
 //	}
 func (c *Client) OrderPaymentEdit(payment Payment, by string, site ...string) (SuccessfulResponse, int, error) {
+	return c.OrderPaymentEditCtx(c.defaultContext(), payment, by, site...)
+}
+
+// OrderPaymentEditCtx is the same as OrderPaymentEdit, but uses the provided context.Context.
+func (c *Client) OrderPaymentEditCtx(
+	ctx context.Context, payment Payment, by string, site ...string,
+) (SuccessfulResponse, int, error) {
+	ctx = withAPIMethod(ctx, "OrderPaymentEdit")
+
 	var resp SuccessfulResponse
 	var uid = strconv.Itoa(payment.ID)
 	var context = checkBy(by)
@@ -3349,7 +4040,7 @@ func (c *Client) OrderPaymentEdit(payment Payment, by string, site ...string) (S
 
 	fillSite(&p, site)
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/orders/payments/%s/edit", uid), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/orders/payments/%s/edit", uid), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -3383,11 +4074,20 @@ func (c *Client) OrderPaymentEdit(payment Payment, by string, site ...string) (S
 //		log.Fatalf("http status: %d, error: %s", status, err)
 //	}
 func (c *Client) OrdersStatuses(request OrdersStatusesRequest) (OrdersStatusesResponse, int, error) {
+	return c.OrdersStatusesCtx(c.defaultContext(), request)
+}
+
+// OrdersStatusesCtx is the same as OrdersStatuses, but uses the provided context.Context.
+func (c *Client) OrdersStatusesCtx(
+	ctx context.Context, request OrdersStatusesRequest,
+) (OrdersStatusesResponse, int, error) {
+	ctx = withAPIMethod(ctx, "OrdersStatuses")
+
 	var resp OrdersStatusesResponse
 
 	params, _ := query.Values(request)
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/orders/statuses?%s", params.Encode()))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/orders/statuses?%s", params.Encode()))
 	if err != nil {
 		return resp, status, err
 	}
@@ -3404,6 +4104,8 @@ func (c *Client) OrdersStatuses(request OrdersStatusesRequest) (OrdersStatusesRe
 //
 // # This method can return response together with error if http status is equal 460
 //
+// The error is *PartialUploadError in this case, it can be matched with errors.As.
+//
 // For more information see http://www.simla.com/docs/Developers/API/APIVersions/APIv5#post--api-v5-orders-upload
 //
 // Example:
@@ -3439,6 +4141,15 @@ func (c *Client) OrdersStatuses(request OrdersStatusesRequest) (OrdersStatusesRe
 //		log.Printf("%v\n", data.UploadedOrders)
 //	}
 func (c *Client) OrdersUpload(orders []Order, site ...string) (OrdersUploadResponse, int, error) {
+	return c.OrdersUploadCtx(c.defaultContext(), orders, site...)
+}
+
+// OrdersUploadCtx is the same as OrdersUpload, but uses the provided context.Context.
+func (c *Client) OrdersUploadCtx(
+	ctx context.Context, orders []Order, site ...string,
+) (OrdersUploadResponse, int, error) {
+	ctx = withAPIMethod(ctx, "OrdersUpload")
+
 	var resp OrdersUploadResponse
 
 	uploadJSON, _ := json.Marshal(&orders)
@@ -3449,7 +4160,7 @@ func (c *Client) OrdersUpload(orders []Order, site ...string) (OrdersUploadRespo
 
 	fillSite(&p, site)
 
-	data, status, err := c.PostRequest("/orders/upload", p)
+	data, status, err := c.PostRequestCtx(ctx, "/orders/upload", p)
 	if err != nil && status != HTTPStatusUnknown {
 		return resp, status, err
 	}
@@ -3460,7 +4171,12 @@ func (c *Client) OrdersUpload(orders []Order, site ...string) (OrdersUploadRespo
 	}
 
 	if status == HTTPStatusUnknown {
-		return resp, status, err
+		ids := make([]string, len(orders))
+		for i, order := range orders {
+			ids[i] = order.ExternalID
+		}
+
+		return resp, status, newPartialUploadError(err, ids, resp.UploadedOrders, resp.FailedOrders)
 	}
 
 	return resp, status, nil
@@ -3488,13 +4204,20 @@ func (c *Client) OrdersUpload(orders []Order, site ...string) (OrdersUploadRespo
 //		log.Printf("%v\n", data.Order)
 //	}
 func (c *Client) Order(id, by, site string) (OrderResponse, int, error) {
+	return c.OrderCtx(c.defaultContext(), id, by, site)
+}
+
+// OrderCtx is the same as Order, but uses the provided context.Context.
+func (c *Client) OrderCtx(ctx context.Context, id, by, site string) (OrderResponse, int, error) {
+	ctx = withAPIMethod(ctx, "Order")
+
 	var resp OrderResponse
 	var context = checkBy(by)
 
 	fw := OrderRequest{context, site}
 	params, _ := query.Values(fw)
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/orders/%s?%s", id, params.Encode()))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/orders/%s?%s", id, params.Encode()))
 	if err != nil {
 		return resp, status, err
 	}
@@ -3531,6 +4254,15 @@ func (c *Client) Order(id, by, site string) (OrderResponse, int, error) {
 //		log.Fatalf("http status: %d, error: %s", status, err)
 //	}
 func (c *Client) OrderEdit(order Order, by string, site ...string) (CreateResponse, int, error) {
+	return c.OrderEditCtx(c.defaultContext(), order, by, site...)
+}
+
+// OrderEditCtx is the same as OrderEdit, but uses the provided context.Context.
+func (c *Client) OrderEditCtx(
+	ctx context.Context, order Order, by string, site ...string,
+) (CreateResponse, int, error) {
+	ctx = withAPIMethod(ctx, "OrderEdit")
+
 	var resp CreateResponse
 	var uid = strconv.Itoa(order.ID)
 	var context = checkBy(by)
@@ -3539,6 +4271,12 @@ func (c *Client) OrderEdit(order Order, by string, site ...string) (CreateRespon
 		uid = order.ExternalID
 	}
 
+	if v := c.currentValidator(); v != nil {
+		if err := v.ValidateOrderEdit(order, by); err != nil {
+			return resp, 0, err
+		}
+	}
+
 	orderJSON, _ := json.Marshal(&order)
 
 	p := url.Values{
@@ -3548,7 +4286,7 @@ func (c *Client) OrderEdit(order Order, by string, site ...string) (CreateRespon
 
 	fillSite(&p, site)
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/orders/%s/edit", uid), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/orders/%s/edit", uid), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -3583,11 +4321,18 @@ func (c *Client) OrderEdit(order Order, by string, site ...string) (CreateRespon
 //		log.Printf("%v\n", value)
 //	}
 func (c *Client) Packs(parameters PacksRequest) (PacksResponse, int, error) {
+	return c.PacksCtx(c.defaultContext(), parameters)
+}
+
+// PacksCtx is the same as Packs, but uses the provided context.Context.
+func (c *Client) PacksCtx(ctx context.Context, parameters PacksRequest) (PacksResponse, int, error) {
+	ctx = withAPIMethod(ctx, "Packs")
+
 	var resp PacksResponse
 
 	params, _ := query.Values(parameters)
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/orders/packs?%s", params.Encode()))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/orders/packs?%s", params.Encode()))
 	if err != nil {
 		return resp, status, err
 	}
@@ -3626,6 +4371,13 @@ func (c *Client) Packs(parameters PacksRequest) (PacksResponse, int, error) {
 //		log.Printf("%v\n", data.ID)
 //	}
 func (c *Client) PackCreate(pack Pack) (CreateResponse, int, error) {
+	return c.PackCreateCtx(c.defaultContext(), pack)
+}
+
+// PackCreateCtx is the same as PackCreate, but uses the provided context.Context.
+func (c *Client) PackCreateCtx(ctx context.Context, pack Pack) (CreateResponse, int, error) {
+	ctx = withAPIMethod(ctx, "PackCreate")
+
 	var resp CreateResponse
 	packJSON, _ := json.Marshal(&pack)
 
@@ -3633,7 +4385,7 @@ func (c *Client) PackCreate(pack Pack) (CreateResponse, int, error) {
 		"pack": {string(packJSON)},
 	}
 
-	data, status, err := c.PostRequest("/orders/packs/create", p)
+	data, status, err := c.PostRequestCtx(ctx, "/orders/packs/create", p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -3668,11 +4420,20 @@ func (c *Client) PackCreate(pack Pack) (CreateResponse, int, error) {
 //		log.Printf("%v\n", value)
 //	}
 func (c *Client) PacksHistory(parameters PacksHistoryRequest) (PacksHistoryResponse, int, error) {
+	return c.PacksHistoryCtx(c.defaultContext(), parameters)
+}
+
+// PacksHistoryCtx is the same as PacksHistory, but uses the provided context.Context.
+func (c *Client) PacksHistoryCtx(
+	ctx context.Context, parameters PacksHistoryRequest,
+) (PacksHistoryResponse, int, error) {
+	ctx = withAPIMethod(ctx, "PacksHistory")
+
 	var resp PacksHistoryResponse
 
 	params, _ := query.Values(parameters)
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/orders/packs/history?%s", params.Encode()))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/orders/packs/history?%s", params.Encode()))
 	if err != nil {
 		return resp, status, err
 	}
@@ -3707,9 +4468,16 @@ func (c *Client) PacksHistory(parameters PacksHistoryRequest) (PacksHistoryRespo
 //		log.Printf("%v\n", data.Pack)
 //	}
 func (c *Client) Pack(id int) (PackResponse, int, error) {
+	return c.PackCtx(c.defaultContext(), id)
+}
+
+// PackCtx is the same as Pack, but uses the provided context.Context.
+func (c *Client) PackCtx(ctx context.Context, id int) (PackResponse, int, error) {
+	ctx = withAPIMethod(ctx, "Pack")
+
 	var resp PackResponse
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/orders/packs/%d", id))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/orders/packs/%d", id))
 	if err != nil {
 		return resp, status, err
 	}
@@ -3740,9 +4508,16 @@ func (c *Client) Pack(id int) (PackResponse, int, error) {
 //		log.Fatalf("http status: %d, error: %s", status, err)
 //	}
 func (c *Client) PackDelete(id int) (SuccessfulResponse, int, error) {
+	return c.PackDeleteCtx(c.defaultContext(), id)
+}
+
+// PackDeleteCtx is the same as PackDelete, but uses the provided context.Context.
+func (c *Client) PackDeleteCtx(ctx context.Context, id int) (SuccessfulResponse, int, error) {
+	ctx = withAPIMethod(ctx, "PackDelete")
+
 	var resp SuccessfulResponse
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/orders/packs/%d/delete", id), url.Values{})
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/orders/packs/%d/delete", id), url.Values{})
 	if err != nil {
 		return resp, status, err
 	}
@@ -3773,6 +4548,13 @@ func (c *Client) PackDelete(id int) (SuccessfulResponse, int, error) {
 //		log.Fatalf("http status: %d, error: %s", status, err)
 //	}
 func (c *Client) PackEdit(pack Pack) (CreateResponse, int, error) {
+	return c.PackEditCtx(c.defaultContext(), pack)
+}
+
+// PackEditCtx is the same as PackEdit, but uses the provided context.Context.
+func (c *Client) PackEditCtx(ctx context.Context, pack Pack) (CreateResponse, int, error) {
+	ctx = withAPIMethod(ctx, "PackEdit")
+
 	var resp CreateResponse
 
 	packJSON, _ := json.Marshal(&pack)
@@ -3781,7 +4563,7 @@ func (c *Client) PackEdit(pack Pack) (CreateResponse, int, error) {
 		"pack": {string(packJSON)},
 	}
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/orders/packs/%d/edit", pack.ID), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/orders/packs/%d/edit", pack.ID), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -3798,9 +4580,16 @@ func (c *Client) PackEdit(pack Pack) (CreateResponse, int, error) {
 //
 // For more information see http://www.simla.com/docs/Developers/API/APIVersions/APIv5#get--api-v5-reference-countries
 func (c *Client) Countries() (CountriesResponse, int, error) {
+	return c.CountriesCtx(c.defaultContext())
+}
+
+// CountriesCtx is the same as Countries, but uses the provided context.Context.
+func (c *Client) CountriesCtx(ctx context.Context) (CountriesResponse, int, error) {
+	ctx = withAPIMethod(ctx, "Countries")
+
 	var resp CountriesResponse
 
-	data, status, err := c.GetRequest("/reference/countries")
+	data, status, err := c.GetRequestCtx(ctx, "/reference/countries")
 	if err != nil {
 		return resp, status, err
 	}
@@ -3817,9 +4606,16 @@ func (c *Client) Countries() (CountriesResponse, int, error) {
 //
 // For more information see http://www.simla.com/docs/Developers/API/APIVersions/APIv5#get--api-v5-reference-cost-groups
 func (c *Client) CostGroups() (CostGroupsResponse, int, error) {
+	return c.CostGroupsCtx(c.defaultContext())
+}
+
+// CostGroupsCtx is the same as CostGroups, but uses the provided context.Context.
+func (c *Client) CostGroupsCtx(ctx context.Context) (CostGroupsResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CostGroups")
+
 	var resp CostGroupsResponse
 
-	data, status, err := c.GetRequest("/reference/cost-groups")
+	data, status, err := c.GetRequestCtx(ctx, "/reference/cost-groups")
 	if err != nil {
 		return resp, status, err
 	}
@@ -3853,6 +4649,13 @@ func (c *Client) CostGroups() (CostGroupsResponse, int, error) {
 //		log.Fatalf("http status: %d, error: %s", status, err)
 //	}
 func (c *Client) CostGroupEdit(costGroup CostGroup) (SuccessfulResponse, int, error) {
+	return c.CostGroupEditCtx(c.defaultContext(), costGroup)
+}
+
+// CostGroupEditCtx is the same as CostGroupEdit, but uses the provided context.Context.
+func (c *Client) CostGroupEditCtx(ctx context.Context, costGroup CostGroup) (SuccessfulResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CostGroupEdit")
+
 	var resp SuccessfulResponse
 
 	objJSON, _ := json.Marshal(&costGroup)
@@ -3861,7 +4664,7 @@ func (c *Client) CostGroupEdit(costGroup CostGroup) (SuccessfulResponse, int, er
 		"costGroup": {string(objJSON)},
 	}
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/reference/cost-groups/%s/edit", costGroup.Code), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/reference/cost-groups/%s/edit", costGroup.Code), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -3878,9 +4681,16 @@ func (c *Client) CostGroupEdit(costGroup CostGroup) (SuccessfulResponse, int, er
 //
 // For more information see http://www.simla.com/docs/Developers/API/APIVersions/APIv5#get--api-v5-reference-cost-items
 func (c *Client) CostItems() (CostItemsResponse, int, error) {
+	return c.CostItemsCtx(c.defaultContext())
+}
+
+// CostItemsCtx is the same as CostItems, but uses the provided context.Context.
+func (c *Client) CostItemsCtx(ctx context.Context) (CostItemsResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CostItems")
+
 	var resp CostItemsResponse
 
-	data, status, err := c.GetRequest("/reference/cost-items")
+	data, status, err := c.GetRequestCtx(ctx, "/reference/cost-items")
 	if err != nil {
 		return resp, status, err
 	}
@@ -3914,6 +4724,13 @@ func (c *Client) CostItems() (CostItemsResponse, int, error) {
 //		log.Fatalf("http status: %d, error: %s", status, err)
 //	}
 func (c *Client) CostItemEdit(costItem CostItem) (SuccessfulResponse, int, error) {
+	return c.CostItemEditCtx(c.defaultContext(), costItem)
+}
+
+// CostItemEditCtx is the same as CostItemEdit, but uses the provided context.Context.
+func (c *Client) CostItemEditCtx(ctx context.Context, costItem CostItem) (SuccessfulResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CostItemEdit")
+
 	var resp SuccessfulResponse
 
 	objJSON, _ := json.Marshal(&costItem)
@@ -3922,7 +4739,7 @@ func (c *Client) CostItemEdit(costItem CostItem) (SuccessfulResponse, int, error
 		"costItem": {string(objJSON)},
 	}
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/reference/cost-items/%s/edit", costItem.Code), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/reference/cost-items/%s/edit", costItem.Code), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -3939,9 +4756,16 @@ func (c *Client) CostItemEdit(costItem CostItem) (SuccessfulResponse, int, error
 //
 // For more information see http://www.simla.com/docs/Developers/API/APIVersions/APIv5#get--api-v5-reference-couriers
 func (c *Client) Couriers() (CouriersResponse, int, error) {
+	return c.CouriersCtx(c.defaultContext())
+}
+
+// CouriersCtx is the same as Couriers, but uses the provided context.Context.
+func (c *Client) CouriersCtx(ctx context.Context) (CouriersResponse, int, error) {
+	ctx = withAPIMethod(ctx, "Couriers")
+
 	var resp CouriersResponse
 
-	data, status, err := c.GetRequest("/reference/couriers")
+	data, status, err := c.GetRequestCtx(ctx, "/reference/couriers")
 	if err != nil {
 		return resp, status, err
 	}
@@ -3981,6 +4805,13 @@ func (c *Client) Couriers() (CouriersResponse, int, error) {
 //		log.Printf("%v", data.ID)
 //	}
 func (c *Client) CourierCreate(courier Courier) (CreateResponse, int, error) {
+	return c.CourierCreateCtx(c.defaultContext(), courier)
+}
+
+// CourierCreateCtx is the same as CourierCreate, but uses the provided context.Context.
+func (c *Client) CourierCreateCtx(ctx context.Context, courier Courier) (CreateResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CourierCreate")
+
 	var resp CreateResponse
 
 	objJSON, _ := json.Marshal(&courier)
@@ -3989,7 +4820,7 @@ func (c *Client) CourierCreate(courier Courier) (CreateResponse, int, error) {
 		"courier": {string(objJSON)},
 	}
 
-	data, status, err := c.PostRequest("/reference/couriers/create", p)
+	data, status, err := c.PostRequestCtx(ctx, "/reference/couriers/create", p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -4023,6 +4854,13 @@ func (c *Client) CourierCreate(courier Courier) (CreateResponse, int, error) {
 //		log.Fatalf("http status: %d, error: %s", status, err)
 //	}
 func (c *Client) CourierEdit(courier Courier) (SuccessfulResponse, int, error) {
+	return c.CourierEditCtx(c.defaultContext(), courier)
+}
+
+// CourierEditCtx is the same as CourierEdit, but uses the provided context.Context.
+func (c *Client) CourierEditCtx(ctx context.Context, courier Courier) (SuccessfulResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CourierEdit")
+
 	var resp SuccessfulResponse
 
 	objJSON, _ := json.Marshal(&courier)
@@ -4031,7 +4869,7 @@ func (c *Client) CourierEdit(courier Courier) (SuccessfulResponse, int, error) {
 		"courier": {string(objJSON)},
 	}
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/reference/couriers/%d/edit", courier.ID), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/reference/couriers/%d/edit", courier.ID), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -4048,9 +4886,16 @@ func (c *Client) CourierEdit(courier Courier) (SuccessfulResponse, int, error) {
 //
 // For more information see http://www.simla.com/docs/Developers/API/APIVersions/APIv5#get--api-v5-reference-delivery-services
 func (c *Client) DeliveryServices() (DeliveryServiceResponse, int, error) {
+	return c.DeliveryServicesCtx(c.defaultContext())
+}
+
+// DeliveryServicesCtx is the same as DeliveryServices, but uses the provided context.Context.
+func (c *Client) DeliveryServicesCtx(ctx context.Context) (DeliveryServiceResponse, int, error) {
+	ctx = withAPIMethod(ctx, "DeliveryServices")
+
 	var resp DeliveryServiceResponse
 
-	data, status, err := c.GetRequest("/reference/delivery-services")
+	data, status, err := c.GetRequestCtx(ctx, "/reference/delivery-services")
 	if err != nil {
 		return resp, status, err
 	}
@@ -4084,6 +4929,15 @@ func (c *Client) DeliveryServices() (DeliveryServiceResponse, int, error) {
 //		log.Fatalf("http status: %d, error: %s", status, err)
 //	}
 func (c *Client) DeliveryServiceEdit(deliveryService DeliveryService) (SuccessfulResponse, int, error) {
+	return c.DeliveryServiceEditCtx(c.defaultContext(), deliveryService)
+}
+
+// DeliveryServiceEditCtx is the same as DeliveryServiceEdit, but uses the provided context.Context.
+func (c *Client) DeliveryServiceEditCtx(
+	ctx context.Context, deliveryService DeliveryService,
+) (SuccessfulResponse, int, error) {
+	ctx = withAPIMethod(ctx, "DeliveryServiceEdit")
+
 	var resp SuccessfulResponse
 
 	objJSON, _ := json.Marshal(&deliveryService)
@@ -4092,7 +4946,7 @@ func (c *Client) DeliveryServiceEdit(deliveryService DeliveryService) (Successfu
 		"deliveryService": {string(objJSON)},
 	}
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/reference/delivery-services/%s/edit", deliveryService.Code), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/reference/delivery-services/%s/edit", deliveryService.Code), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -4109,9 +4963,16 @@ func (c *Client) DeliveryServiceEdit(deliveryService DeliveryService) (Successfu
 //
 // For more information see http://www.simla.com/docs/Developers/API/APIVersions/APIv5#get--api-v5-reference-delivery-types
 func (c *Client) DeliveryTypes() (DeliveryTypesResponse, int, error) {
+	return c.DeliveryTypesCtx(c.defaultContext())
+}
+
+// DeliveryTypesCtx is the same as DeliveryTypes, but uses the provided context.Context.
+func (c *Client) DeliveryTypesCtx(ctx context.Context) (DeliveryTypesResponse, int, error) {
+	ctx = withAPIMethod(ctx, "DeliveryTypes")
+
 	var resp DeliveryTypesResponse
 
-	data, status, err := c.GetRequest("/reference/delivery-types")
+	data, status, err := c.GetRequestCtx(ctx, "/reference/delivery-types")
 	if err != nil {
 		return resp, status, err
 	}
@@ -4147,6 +5008,13 @@ func (c *Client) DeliveryTypes() (DeliveryTypesResponse, int, error) {
 //		log.Fatalf("http status: %d, error: %s", status, err)
 //	}
 func (c *Client) DeliveryTypeEdit(deliveryType DeliveryType) (SuccessfulResponse, int, error) {
+	return c.DeliveryTypeEditCtx(c.defaultContext(), deliveryType)
+}
+
+// DeliveryTypeEditCtx is the same as DeliveryTypeEdit, but uses the provided context.Context.
+func (c *Client) DeliveryTypeEditCtx(ctx context.Context, deliveryType DeliveryType) (SuccessfulResponse, int, error) {
+	ctx = withAPIMethod(ctx, "DeliveryTypeEdit")
+
 	var resp SuccessfulResponse
 
 	objJSON, _ := json.Marshal(&deliveryType)
@@ -4155,7 +5023,7 @@ func (c *Client) DeliveryTypeEdit(deliveryType DeliveryType) (SuccessfulResponse
 		"deliveryType": {string(objJSON)},
 	}
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/reference/delivery-types/%s/edit", deliveryType.Code), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/reference/delivery-types/%s/edit", deliveryType.Code), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -4172,9 +5040,16 @@ func (c *Client) DeliveryTypeEdit(deliveryType DeliveryType) (SuccessfulResponse
 //
 // For more information see http://www.simla.com/docs/Developers/API/APIVersions/APIv5#get--api-v5-reference-legal-entities
 func (c *Client) LegalEntities() (LegalEntitiesResponse, int, error) {
+	return c.LegalEntitiesCtx(c.defaultContext())
+}
+
+// LegalEntitiesCtx is the same as LegalEntities, but uses the provided context.Context.
+func (c *Client) LegalEntitiesCtx(ctx context.Context) (LegalEntitiesResponse, int, error) {
+	ctx = withAPIMethod(ctx, "LegalEntities")
+
 	var resp LegalEntitiesResponse
 
-	data, status, err := c.GetRequest("/reference/legal-entities")
+	data, status, err := c.GetRequestCtx(ctx, "/reference/legal-entities")
 	if err != nil {
 		return resp, status, err
 	}
@@ -4208,6 +5083,13 @@ func (c *Client) LegalEntities() (LegalEntitiesResponse, int, error) {
 //		log.Fatalf("http status: %d, error: %s", status, err)
 //	}
 func (c *Client) LegalEntityEdit(legalEntity LegalEntity) (SuccessfulResponse, int, error) {
+	return c.LegalEntityEditCtx(c.defaultContext(), legalEntity)
+}
+
+// LegalEntityEditCtx is the same as LegalEntityEdit, but uses the provided context.Context.
+func (c *Client) LegalEntityEditCtx(ctx context.Context, legalEntity LegalEntity) (SuccessfulResponse, int, error) {
+	ctx = withAPIMethod(ctx, "LegalEntityEdit")
+
 	var resp SuccessfulResponse
 
 	objJSON, _ := json.Marshal(&legalEntity)
@@ -4216,7 +5098,7 @@ func (c *Client) LegalEntityEdit(legalEntity LegalEntity) (SuccessfulResponse, i
 		"legalEntity": {string(objJSON)},
 	}
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/reference/legal-entities/%s/edit", legalEntity.Code), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/reference/legal-entities/%s/edit", legalEntity.Code), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -4233,9 +5115,16 @@ func (c *Client) LegalEntityEdit(legalEntity LegalEntity) (SuccessfulResponse, i
 //
 // For more information see http://www.simla.com/docs/Developers/API/APIVersions/APIv5#get--api-v5-reference-order-methods
 func (c *Client) OrderMethods() (OrderMethodsResponse, int, error) {
+	return c.OrderMethodsCtx(c.defaultContext())
+}
+
+// OrderMethodsCtx is the same as OrderMethods, but uses the provided context.Context.
+func (c *Client) OrderMethodsCtx(ctx context.Context) (OrderMethodsResponse, int, error) {
+	ctx = withAPIMethod(ctx, "OrderMethods")
+
 	var resp OrderMethodsResponse
 
-	data, status, err := c.GetRequest("/reference/order-methods")
+	data, status, err := c.GetRequestCtx(ctx, "/reference/order-methods")
 	if err != nil {
 		return resp, status, err
 	}
@@ -4270,6 +5159,13 @@ func (c *Client) OrderMethods() (OrderMethodsResponse, int, error) {
 //		log.Fatalf("http status: %d, error: %s", status, err)
 //	}
 func (c *Client) OrderMethodEdit(orderMethod OrderMethod) (SuccessfulResponse, int, error) {
+	return c.OrderMethodEditCtx(c.defaultContext(), orderMethod)
+}
+
+// OrderMethodEditCtx is the same as OrderMethodEdit, but uses the provided context.Context.
+func (c *Client) OrderMethodEditCtx(ctx context.Context, orderMethod OrderMethod) (SuccessfulResponse, int, error) {
+	ctx = withAPIMethod(ctx, "OrderMethodEdit")
+
 	var resp SuccessfulResponse
 
 	objJSON, _ := json.Marshal(&orderMethod)
@@ -4278,7 +5174,7 @@ func (c *Client) OrderMethodEdit(orderMethod OrderMethod) (SuccessfulResponse, i
 		"orderMethod": {string(objJSON)},
 	}
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/reference/order-methods/%s/edit", orderMethod.Code), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/reference/order-methods/%s/edit", orderMethod.Code), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -4295,9 +5191,16 @@ func (c *Client) OrderMethodEdit(orderMethod OrderMethod) (SuccessfulResponse, i
 //
 // For more information see http://www.simla.com/docs/Developers/API/APIVersions/APIv5#get--api-v5-reference-order-types
 func (c *Client) OrderTypes() (OrderTypesResponse, int, error) {
+	return c.OrderTypesCtx(c.defaultContext())
+}
+
+// OrderTypesCtx is the same as OrderTypes, but uses the provided context.Context.
+func (c *Client) OrderTypesCtx(ctx context.Context) (OrderTypesResponse, int, error) {
+	ctx = withAPIMethod(ctx, "OrderTypes")
+
 	var resp OrderTypesResponse
 
-	data, status, err := c.GetRequest("/reference/order-types")
+	data, status, err := c.GetRequestCtx(ctx, "/reference/order-types")
 	if err != nil {
 		return resp, status, err
 	}
@@ -4332,6 +5235,13 @@ func (c *Client) OrderTypes() (OrderTypesResponse, int, error) {
 //		log.Fatalf("http status: %d, error: %s", status, err)
 //	}
 func (c *Client) OrderTypeEdit(orderType OrderType) (SuccessfulResponse, int, error) {
+	return c.OrderTypeEditCtx(c.defaultContext(), orderType)
+}
+
+// OrderTypeEditCtx is the same as OrderTypeEdit, but uses the provided context.Context.
+func (c *Client) OrderTypeEditCtx(ctx context.Context, orderType OrderType) (SuccessfulResponse, int, error) {
+	ctx = withAPIMethod(ctx, "OrderTypeEdit")
+
 	var resp SuccessfulResponse
 
 	objJSON, _ := json.Marshal(&orderType)
@@ -4340,7 +5250,7 @@ func (c *Client) OrderTypeEdit(orderType OrderType) (SuccessfulResponse, int, er
 		"orderType": {string(objJSON)},
 	}
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/reference/order-types/%s/edit", orderType.Code), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/reference/order-types/%s/edit", orderType.Code), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -4357,9 +5267,16 @@ func (c *Client) OrderTypeEdit(orderType OrderType) (SuccessfulResponse, int, er
 //
 // For more information see http://www.simla.com/docs/Developers/API/APIVersions/APIv5#get--api-v5-reference-payment-statuses
 func (c *Client) PaymentStatuses() (PaymentStatusesResponse, int, error) {
+	return c.PaymentStatusesCtx(c.defaultContext())
+}
+
+// PaymentStatusesCtx is the same as PaymentStatuses, but uses the provided context.Context.
+func (c *Client) PaymentStatusesCtx(ctx context.Context) (PaymentStatusesResponse, int, error) {
+	ctx = withAPIMethod(ctx, "PaymentStatuses")
+
 	var resp PaymentStatusesResponse
 
-	data, status, err := c.GetRequest("/reference/payment-statuses")
+	data, status, err := c.GetRequestCtx(ctx, "/reference/payment-statuses")
 	if err != nil {
 		return resp, status, err
 	}
@@ -4376,6 +5293,15 @@ func (c *Client) PaymentStatuses() (PaymentStatusesResponse, int, error) {
 //
 // For more information see http://www.simla.com/docs/Developers/API/APIVersions/APIv5#post--api-v5-reference-payment-statuses-code-edit
 func (c *Client) PaymentStatusEdit(paymentStatus PaymentStatus) (SuccessfulResponse, int, error) {
+	return c.PaymentStatusEditCtx(c.defaultContext(), paymentStatus)
+}
+
+// PaymentStatusEditCtx is the same as PaymentStatusEdit, but uses the provided context.Context.
+func (c *Client) PaymentStatusEditCtx(
+	ctx context.Context, paymentStatus PaymentStatus,
+) (SuccessfulResponse, int, error) {
+	ctx = withAPIMethod(ctx, "PaymentStatusEdit")
+
 	var resp SuccessfulResponse
 
 	objJSON, _ := json.Marshal(&paymentStatus)
@@ -4384,7 +5310,7 @@ func (c *Client) PaymentStatusEdit(paymentStatus PaymentStatus) (SuccessfulRespo
 		"paymentStatus": {string(objJSON)},
 	}
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/reference/payment-statuses/%s/edit", paymentStatus.Code), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/reference/payment-statuses/%s/edit", paymentStatus.Code), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -4401,9 +5327,16 @@ func (c *Client) PaymentStatusEdit(paymentStatus PaymentStatus) (SuccessfulRespo
 //
 // For more information see http://www.simla.com/docs/Developers/API/APIVersions/APIv5#get--api-v5-reference-payment-types
 func (c *Client) PaymentTypes() (PaymentTypesResponse, int, error) {
+	return c.PaymentTypesCtx(c.defaultContext())
+}
+
+// PaymentTypesCtx is the same as PaymentTypes, but uses the provided context.Context.
+func (c *Client) PaymentTypesCtx(ctx context.Context) (PaymentTypesResponse, int, error) {
+	ctx = withAPIMethod(ctx, "PaymentTypes")
+
 	var resp PaymentTypesResponse
 
-	data, status, err := c.GetRequest("/reference/payment-types")
+	data, status, err := c.GetRequestCtx(ctx, "/reference/payment-types")
 	if err != nil {
 		return resp, status, err
 	}
@@ -4420,6 +5353,13 @@ func (c *Client) PaymentTypes() (PaymentTypesResponse, int, error) {
 //
 // For more information see http://www.simla.com/docs/Developers/API/APIVersions/APIv5#post--api-v5-reference-payment-types-code-edit
 func (c *Client) PaymentTypeEdit(paymentType PaymentType) (SuccessfulResponse, int, error) {
+	return c.PaymentTypeEditCtx(c.defaultContext(), paymentType)
+}
+
+// PaymentTypeEditCtx is the same as PaymentTypeEdit, but uses the provided context.Context.
+func (c *Client) PaymentTypeEditCtx(ctx context.Context, paymentType PaymentType) (SuccessfulResponse, int, error) {
+	ctx = withAPIMethod(ctx, "PaymentTypeEdit")
+
 	var resp SuccessfulResponse
 
 	objJSON, _ := json.Marshal(&paymentType)
@@ -4428,7 +5368,7 @@ func (c *Client) PaymentTypeEdit(paymentType PaymentType) (SuccessfulResponse, i
 		"paymentType": {string(objJSON)},
 	}
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/reference/payment-types/%s/edit", paymentType.Code), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/reference/payment-types/%s/edit", paymentType.Code), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -4445,9 +5385,16 @@ func (c *Client) PaymentTypeEdit(paymentType PaymentType) (SuccessfulResponse, i
 //
 // For more information see http://www.simla.com/docs/Developers/API/APIVersions/APIv5#get--api-v5-reference-price-types
 func (c *Client) PriceTypes() (PriceTypesResponse, int, error) {
+	return c.PriceTypesCtx(c.defaultContext())
+}
+
+// PriceTypesCtx is the same as PriceTypes, but uses the provided context.Context.
+func (c *Client) PriceTypesCtx(ctx context.Context) (PriceTypesResponse, int, error) {
+	ctx = withAPIMethod(ctx, "PriceTypes")
+
 	var resp PriceTypesResponse
 
-	data, status, err := c.GetRequest("/reference/price-types")
+	data, status, err := c.GetRequestCtx(ctx, "/reference/price-types")
 	if err != nil {
 		return resp, status, err
 	}
@@ -4464,6 +5411,13 @@ func (c *Client) PriceTypes() (PriceTypesResponse, int, error) {
 //
 // For more information see http://www.simla.com/docs/Developers/API/APIVersions/APIv5#post--api-v5-reference-price-types-code-edit
 func (c *Client) PriceTypeEdit(priceType PriceType) (SuccessfulResponse, int, error) {
+	return c.PriceTypeEditCtx(c.defaultContext(), priceType)
+}
+
+// PriceTypeEditCtx is the same as PriceTypeEdit, but uses the provided context.Context.
+func (c *Client) PriceTypeEditCtx(ctx context.Context, priceType PriceType) (SuccessfulResponse, int, error) {
+	ctx = withAPIMethod(ctx, "PriceTypeEdit")
+
 	var resp SuccessfulResponse
 
 	objJSON, _ := json.Marshal(&priceType)
@@ -4472,7 +5426,7 @@ func (c *Client) PriceTypeEdit(priceType PriceType) (SuccessfulResponse, int, er
 		"priceType": {string(objJSON)},
 	}
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/reference/price-types/%s/edit", priceType.Code), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/reference/price-types/%s/edit", priceType.Code), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -4489,9 +5443,16 @@ func (c *Client) PriceTypeEdit(priceType PriceType) (SuccessfulResponse, int, er
 //
 // For more information see http://www.simla.com/docs/Developers/API/APIVersions/APIv5#get--api-v5-reference-product-statuses
 func (c *Client) ProductStatuses() (ProductStatusesResponse, int, error) {
+	return c.ProductStatusesCtx(c.defaultContext())
+}
+
+// ProductStatusesCtx is the same as ProductStatuses, but uses the provided context.Context.
+func (c *Client) ProductStatusesCtx(ctx context.Context) (ProductStatusesResponse, int, error) {
+	ctx = withAPIMethod(ctx, "ProductStatuses")
+
 	var resp ProductStatusesResponse
 
-	data, status, err := c.GetRequest("/reference/product-statuses")
+	data, status, err := c.GetRequestCtx(ctx, "/reference/product-statuses")
 	if err != nil {
 		return resp, status, err
 	}
@@ -4508,6 +5469,15 @@ func (c *Client) ProductStatuses() (ProductStatusesResponse, int, error) {
 //
 // For more information see http://www.simla.com/docs/Developers/API/APIVersions/APIv5#post--api-v5-reference-product-statuses-code-edit
 func (c *Client) ProductStatusEdit(productStatus ProductStatus) (SuccessfulResponse, int, error) {
+	return c.ProductStatusEditCtx(c.defaultContext(), productStatus)
+}
+
+// ProductStatusEditCtx is the same as ProductStatusEdit, but uses the provided context.Context.
+func (c *Client) ProductStatusEditCtx(
+	ctx context.Context, productStatus ProductStatus,
+) (SuccessfulResponse, int, error) {
+	ctx = withAPIMethod(ctx, "ProductStatusEdit")
+
 	var resp SuccessfulResponse
 
 	objJSON, _ := json.Marshal(&productStatus)
@@ -4516,7 +5486,7 @@ func (c *Client) ProductStatusEdit(productStatus ProductStatus) (SuccessfulRespo
 		"productStatus": {string(objJSON)},
 	}
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/reference/product-statuses/%s/edit", productStatus.Code), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/reference/product-statuses/%s/edit", productStatus.Code), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -4533,9 +5503,16 @@ func (c *Client) ProductStatusEdit(productStatus ProductStatus) (SuccessfulRespo
 //
 // For more information see http://www.simla.com/docs/Developers/API/APIVersions/APIv5#get--api-v5-reference-sites
 func (c *Client) Sites() (SitesResponse, int, error) {
+	return c.SitesCtx(c.defaultContext())
+}
+
+// SitesCtx is the same as Sites, but uses the provided context.Context.
+func (c *Client) SitesCtx(ctx context.Context) (SitesResponse, int, error) {
+	ctx = withAPIMethod(ctx, "Sites")
+
 	var resp SitesResponse
 
-	data, status, err := c.GetRequest("/reference/sites")
+	data, status, err := c.GetRequestCtx(ctx, "/reference/sites")
 	if err != nil {
 		return resp, status, err
 	}
@@ -4552,6 +5529,13 @@ func (c *Client) Sites() (SitesResponse, int, error) {
 //
 // For more information see http://www.simla.com/docs/Developers/API/APIVersions/APIv5#post--api-v5-reference-sites-code-edit
 func (c *Client) SiteEdit(site Site) (SuccessfulResponse, int, error) {
+	return c.SiteEditCtx(c.defaultContext(), site)
+}
+
+// SiteEditCtx is the same as SiteEdit, but uses the provided context.Context.
+func (c *Client) SiteEditCtx(ctx context.Context, site Site) (SuccessfulResponse, int, error) {
+	ctx = withAPIMethod(ctx, "SiteEdit")
+
 	var resp SuccessfulResponse
 
 	objJSON, _ := json.Marshal(&site)
@@ -4560,7 +5544,7 @@ func (c *Client) SiteEdit(site Site) (SuccessfulResponse, int, error) {
 		"site": {string(objJSON)},
 	}
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/reference/sites/%s/edit", site.Code), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/reference/sites/%s/edit", site.Code), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -4577,9 +5561,16 @@ func (c *Client) SiteEdit(site Site) (SuccessfulResponse, int, error) {
 //
 // For more information see http://www.simla.com/docs/Developers/API/APIVersions/APIv5#get--api-v5-reference-status-groups
 func (c *Client) StatusGroups() (StatusGroupsResponse, int, error) {
+	return c.StatusGroupsCtx(c.defaultContext())
+}
+
+// StatusGroupsCtx is the same as StatusGroups, but uses the provided context.Context.
+func (c *Client) StatusGroupsCtx(ctx context.Context) (StatusGroupsResponse, int, error) {
+	ctx = withAPIMethod(ctx, "StatusGroups")
+
 	var resp StatusGroupsResponse
 
-	data, status, err := c.GetRequest("/reference/status-groups")
+	data, status, err := c.GetRequestCtx(ctx, "/reference/status-groups")
 	if err != nil {
 		return resp, status, err
 	}
@@ -4596,9 +5587,16 @@ func (c *Client) StatusGroups() (StatusGroupsResponse, int, error) {
 //
 // For more information see http://www.simla.com/docs/Developers/API/APIVersions/APIv5#get--api-v5-reference-statuses
 func (c *Client) Statuses() (StatusesResponse, int, error) {
+	return c.StatusesCtx(c.defaultContext())
+}
+
+// StatusesCtx is the same as Statuses, but uses the provided context.Context.
+func (c *Client) StatusesCtx(ctx context.Context) (StatusesResponse, int, error) {
+	ctx = withAPIMethod(ctx, "Statuses")
+
 	var resp StatusesResponse
 
-	data, status, err := c.GetRequest("/reference/statuses")
+	data, status, err := c.GetRequestCtx(ctx, "/reference/statuses")
 	if err != nil {
 		return resp, status, err
 	}
@@ -4615,6 +5613,13 @@ func (c *Client) Statuses() (StatusesResponse, int, error) {
 //
 // For more information see www.retailcrm.pro/docs/Developers/ApiVersion5#post--api-v5-reference-sites-code-edit.
 func (c *Client) StatusEdit(st Status) (SuccessfulResponse, int, error) {
+	return c.StatusEditCtx(c.defaultContext(), st)
+}
+
+// StatusEditCtx is the same as StatusEdit, but uses the provided context.Context.
+func (c *Client) StatusEditCtx(ctx context.Context, st Status) (SuccessfulResponse, int, error) {
+	ctx = withAPIMethod(ctx, "StatusEdit")
+
 	var resp SuccessfulResponse
 
 	objJSON, _ := json.Marshal(&st)
@@ -4623,7 +5628,7 @@ func (c *Client) StatusEdit(st Status) (SuccessfulResponse, int, error) {
 		"status": {string(objJSON)},
 	}
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/reference/statuses/%s/edit", st.Code), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/reference/statuses/%s/edit", st.Code), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -4640,9 +5645,16 @@ func (c *Client) StatusEdit(st Status) (SuccessfulResponse, int, error) {
 //
 // For more information see http://www.simla.com/docs/Developers/API/APIVersions/APIv5#get--api-v5-reference-stores
 func (c *Client) Stores() (StoresResponse, int, error) {
+	return c.StoresCtx(c.defaultContext())
+}
+
+// StoresCtx is the same as Stores, but uses the provided context.Context.
+func (c *Client) StoresCtx(ctx context.Context) (StoresResponse, int, error) {
+	ctx = withAPIMethod(ctx, "Stores")
+
 	var resp StoresResponse
 
-	data, status, err := c.GetRequest("/reference/stores")
+	data, status, err := c.GetRequestCtx(ctx, "/reference/stores")
 	if err != nil {
 		return resp, status, err
 	}
@@ -4659,6 +5671,13 @@ func (c *Client) Stores() (StoresResponse, int, error) {
 //
 // For more information see http://www.simla.com/docs/Developers/API/APIVersions/APIv5#post--api-v5-reference-stores-code-edit
 func (c *Client) StoreEdit(store Store) (SuccessfulResponse, int, error) {
+	return c.StoreEditCtx(c.defaultContext(), store)
+}
+
+// StoreEditCtx is the same as StoreEdit, but uses the provided context.Context.
+func (c *Client) StoreEditCtx(ctx context.Context, store Store) (SuccessfulResponse, int, error) {
+	ctx = withAPIMethod(ctx, "StoreEdit")
+
 	var resp SuccessfulResponse
 
 	objJSON, _ := json.Marshal(&store)
@@ -4667,7 +5686,7 @@ func (c *Client) StoreEdit(store Store) (SuccessfulResponse, int, error) {
 		"store": {string(objJSON)},
 	}
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/reference/stores/%s/edit", store.Code), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/reference/stores/%s/edit", store.Code), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -4684,9 +5703,16 @@ func (c *Client) StoreEdit(store Store) (SuccessfulResponse, int, error) {
 //
 // For more information see http://www.simla.com/docs/Developers/API/APIVersions/APIv5#get--api-v5-reference-units
 func (c *Client) Units() (UnitsResponse, int, error) {
+	return c.UnitsCtx(c.defaultContext())
+}
+
+// UnitsCtx is the same as Units, but uses the provided context.Context.
+func (c *Client) UnitsCtx(ctx context.Context) (UnitsResponse, int, error) {
+	ctx = withAPIMethod(ctx, "Units")
+
 	var resp UnitsResponse
 
-	data, status, err := c.GetRequest("/reference/units")
+	data, status, err := c.GetRequestCtx(ctx, "/reference/units")
 	if err != nil {
 		return resp, status, err
 	}
@@ -4703,6 +5729,13 @@ func (c *Client) Units() (UnitsResponse, int, error) {
 //
 // For more information see http://www.simla.com/docs/Developers/API/APIVersions/APIv5#post--api-v5-reference-units-code-edit
 func (c *Client) UnitEdit(unit Unit) (SuccessfulResponse, int, error) {
+	return c.UnitEditCtx(c.defaultContext(), unit)
+}
+
+// UnitEditCtx is the same as UnitEdit, but uses the provided context.Context.
+func (c *Client) UnitEditCtx(ctx context.Context, unit Unit) (SuccessfulResponse, int, error) {
+	ctx = withAPIMethod(ctx, "UnitEdit")
+
 	var resp SuccessfulResponse
 
 	objJSON, _ := json.Marshal(&unit)
@@ -4711,7 +5744,7 @@ func (c *Client) UnitEdit(unit Unit) (SuccessfulResponse, int, error) {
 		"unit": {string(objJSON)},
 	}
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/reference/units/%s/edit", unit.Code), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/reference/units/%s/edit", unit.Code), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -4750,11 +5783,18 @@ func (c *Client) UnitEdit(unit Unit) (SuccessfulResponse, int, error) {
 //		fmt.Printf("%v\n", value)
 //	}
 func (c *Client) Segments(parameters SegmentsRequest) (SegmentsResponse, int, error) {
+	return c.SegmentsCtx(c.defaultContext(), parameters)
+}
+
+// SegmentsCtx is the same as Segments, but uses the provided context.Context.
+func (c *Client) SegmentsCtx(ctx context.Context, parameters SegmentsRequest) (SegmentsResponse, int, error) {
+	ctx = withAPIMethod(ctx, "Segments")
+
 	var resp SegmentsResponse
 
 	params, _ := query.Values(parameters)
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/segments?%s", params.Encode()))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/segments?%s", params.Encode()))
 	if err != nil {
 		return resp, status, err
 	}
@@ -4787,9 +5827,16 @@ func (c *Client) Segments(parameters SegmentsRequest) (SegmentsResponse, int, er
 //
 //	fmt.Printf("%#v\n", data)
 func (c *Client) Settings() (SettingsResponse, int, error) {
+	return c.SettingsCtx(c.defaultContext())
+}
+
+// SettingsCtx is the same as Settings, but uses the provided context.Context.
+func (c *Client) SettingsCtx(ctx context.Context) (SettingsResponse, int, error) {
+	ctx = withAPIMethod(ctx, "Settings")
+
 	var resp SettingsResponse
 
-	data, status, err := c.GetRequest("/settings")
+	data, status, err := c.GetRequestCtx(ctx, "/settings")
 	if err != nil {
 		return resp, status, err
 	}
@@ -4824,11 +5871,18 @@ func (c *Client) Settings() (SettingsResponse, int, error) {
 //		log.Printf("%v\n", value)
 //	}
 func (c *Client) Inventories(parameters InventoriesRequest) (InventoriesResponse, int, error) {
+	return c.InventoriesCtx(c.defaultContext(), parameters)
+}
+
+// InventoriesCtx is the same as Inventories, but uses the provided context.Context.
+func (c *Client) InventoriesCtx(ctx context.Context, parameters InventoriesRequest) (InventoriesResponse, int, error) {
+	ctx = withAPIMethod(ctx, "Inventories")
+
 	var resp InventoriesResponse
 
 	params, _ := query.Values(parameters)
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/store/inventories?%s", params.Encode()))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/store/inventories?%s", params.Encode()))
 	if err != nil {
 		return resp, status, err
 	}
@@ -4880,6 +5934,15 @@ func (c *Client) Inventories(parameters InventoriesRequest) (InventoriesResponse
 //
 //	fmt.Printf("%v\n", data.NotFoundOffers)
 func (c *Client) InventoriesUpload(inventories []InventoryUpload, site ...string) (StoreUploadResponse, int, error) {
+	return c.InventoriesUploadCtx(c.defaultContext(), inventories, site...)
+}
+
+// InventoriesUploadCtx is the same as InventoriesUpload, but uses the provided context.Context.
+func (c *Client) InventoriesUploadCtx(
+	ctx context.Context, inventories []InventoryUpload, site ...string,
+) (StoreUploadResponse, int, error) {
+	ctx = withAPIMethod(ctx, "InventoriesUpload")
+
 	var resp StoreUploadResponse
 
 	uploadJSON, _ := json.Marshal(&inventories)
@@ -4890,7 +5953,7 @@ func (c *Client) InventoriesUpload(inventories []InventoryUpload, site ...string
 
 	fillSite(&p, site)
 
-	data, status, err := c.PostRequest("/store/inventories/upload", p)
+	data, status, err := c.PostRequestCtx(ctx, "/store/inventories/upload", p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -4934,6 +5997,13 @@ func (c *Client) InventoriesUpload(inventories []InventoryUpload, site ...string
 //
 //	fmt.Printf("%v\n", data.NotFoundOffers)
 func (c *Client) PricesUpload(prices []OfferPriceUpload) (StoreUploadResponse, int, error) {
+	return c.PricesUploadCtx(c.defaultContext(), prices)
+}
+
+// PricesUploadCtx is the same as PricesUpload, but uses the provided context.Context.
+func (c *Client) PricesUploadCtx(ctx context.Context, prices []OfferPriceUpload) (StoreUploadResponse, int, error) {
+	ctx = withAPIMethod(ctx, "PricesUpload")
+
 	var resp StoreUploadResponse
 
 	uploadJSON, _ := json.Marshal(&prices)
@@ -4942,7 +6012,7 @@ func (c *Client) PricesUpload(prices []OfferPriceUpload) (StoreUploadResponse, i
 		"prices": {string(uploadJSON)},
 	}
 
-	data, status, err := c.PostRequest("/store/prices/upload", p)
+	data, status, err := c.PostRequestCtx(ctx, "/store/prices/upload", p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -4981,11 +6051,20 @@ func (c *Client) PricesUpload(prices []OfferPriceUpload) (StoreUploadResponse, i
 //		log.Printf("%v\n", value)
 //	}
 func (c *Client) ProductsGroup(parameters ProductsGroupsRequest) (ProductsGroupsResponse, int, error) {
+	return c.ProductsGroupCtx(c.defaultContext(), parameters)
+}
+
+// ProductsGroupCtx is the same as ProductsGroup, but uses the provided context.Context.
+func (c *Client) ProductsGroupCtx(
+	ctx context.Context, parameters ProductsGroupsRequest,
+) (ProductsGroupsResponse, int, error) {
+	ctx = withAPIMethod(ctx, "ProductsGroup")
+
 	var resp ProductsGroupsResponse
 
 	params, _ := query.Values(parameters)
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/store/product-groups?%s", params.Encode()))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/store/product-groups?%s", params.Encode()))
 	if err != nil {
 		return resp, status, err
 	}
@@ -5025,11 +6104,18 @@ func (c *Client) ProductsGroup(parameters ProductsGroupsRequest) (ProductsGroups
 //		log.Printf("%v\n", value)
 //	}
 func (c *Client) Products(parameters ProductsRequest) (ProductsResponse, int, error) {
+	return c.ProductsCtx(c.defaultContext(), parameters)
+}
+
+// ProductsCtx is the same as Products, but uses the provided context.Context.
+func (c *Client) ProductsCtx(ctx context.Context, parameters ProductsRequest) (ProductsResponse, int, error) {
+	ctx = withAPIMethod(ctx, "Products")
+
 	var resp ProductsResponse
 
 	params, _ := query.Values(parameters)
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/store/products?%s", params.Encode()))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/store/products?%s", params.Encode()))
 	if err != nil {
 		return resp, status, err
 	}
@@ -5068,11 +6154,20 @@ func (c *Client) Products(parameters ProductsRequest) (ProductsResponse, int, er
 //		log.Printf("%v\n", value)
 //	}
 func (c *Client) ProductsProperties(parameters ProductsPropertiesRequest) (ProductsPropertiesResponse, int, error) {
+	return c.ProductsPropertiesCtx(c.defaultContext(), parameters)
+}
+
+// ProductsPropertiesCtx is the same as ProductsProperties, but uses the provided context.Context.
+func (c *Client) ProductsPropertiesCtx(
+	ctx context.Context, parameters ProductsPropertiesRequest,
+) (ProductsPropertiesResponse, int, error) {
+	ctx = withAPIMethod(ctx, "ProductsProperties")
+
 	var resp ProductsPropertiesResponse
 
 	params, _ := query.Values(parameters)
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/store/products/properties?%s", params.Encode()))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/store/products/properties?%s", params.Encode()))
 	if err != nil {
 		return resp, status, err
 	}
@@ -5111,11 +6206,18 @@ func (c *Client) ProductsProperties(parameters ProductsPropertiesRequest) (Produ
 //		log.Printf("%v\n", value)
 //	}
 func (c *Client) Tasks(parameters TasksRequest) (TasksResponse, int, error) {
+	return c.TasksCtx(c.defaultContext(), parameters)
+}
+
+// TasksCtx is the same as Tasks, but uses the provided context.Context.
+func (c *Client) TasksCtx(ctx context.Context, parameters TasksRequest) (TasksResponse, int, error) {
+	ctx = withAPIMethod(ctx, "Tasks")
+
 	var resp TasksResponse
 
 	params, _ := query.Values(parameters)
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/tasks?%s", params.Encode()))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/tasks?%s", params.Encode()))
 	if err != nil {
 		return resp, status, err
 	}
@@ -5153,6 +6255,13 @@ func (c *Client) Tasks(parameters TasksRequest) (TasksResponse, int, error) {
 //		log.Printf("%v\n", data.ID)
 //	}
 func (c *Client) TaskCreate(task Task, site ...string) (CreateResponse, int, error) {
+	return c.TaskCreateCtx(c.defaultContext(), task, site...)
+}
+
+// TaskCreateCtx is the same as TaskCreate, but uses the provided context.Context.
+func (c *Client) TaskCreateCtx(ctx context.Context, task Task, site ...string) (CreateResponse, int, error) {
+	ctx = withAPIMethod(ctx, "TaskCreate")
+
 	var resp CreateResponse
 	taskJSON, _ := json.Marshal(&task)
 
@@ -5162,7 +6271,7 @@ func (c *Client) TaskCreate(task Task, site ...string) (CreateResponse, int, err
 
 	fillSite(&p, site)
 
-	data, status, err := c.PostRequest("/tasks/create", p)
+	data, status, err := c.PostRequestCtx(ctx, "/tasks/create", p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -5197,9 +6306,16 @@ func (c *Client) TaskCreate(task Task, site ...string) (CreateResponse, int, err
 //		log.Printf("%v\n", data.Task)
 //	}
 func (c *Client) Task(id int) (TaskResponse, int, error) {
+	return c.TaskCtx(c.defaultContext(), id)
+}
+
+// TaskCtx is the same as Task, but uses the provided context.Context.
+func (c *Client) TaskCtx(ctx context.Context, id int) (TaskResponse, int, error) {
+	ctx = withAPIMethod(ctx, "Task")
+
 	var resp TaskResponse
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/tasks/%d", id))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/tasks/%d", id))
 	if err != nil {
 		return resp, status, err
 	}
@@ -5233,6 +6349,13 @@ func (c *Client) Task(id int) (TaskResponse, int, error) {
 //		log.Fatalf("http status: %d, error: %s", status, err)
 //	}
 func (c *Client) TaskEdit(task Task, site ...string) (SuccessfulResponse, int, error) {
+	return c.TaskEditCtx(c.defaultContext(), task, site...)
+}
+
+// TaskEditCtx is the same as TaskEdit, but uses the provided context.Context.
+func (c *Client) TaskEditCtx(ctx context.Context, task Task, site ...string) (SuccessfulResponse, int, error) {
+	ctx = withAPIMethod(ctx, "TaskEdit")
+
 	var resp SuccessfulResponse
 	var uid = strconv.Itoa(task.ID)
 
@@ -5244,7 +6367,7 @@ func (c *Client) TaskEdit(task Task, site ...string) (SuccessfulResponse, int, e
 
 	fillSite(&p, site)
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/tasks/%s/edit", uid), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/tasks/%s/edit", uid), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -5284,11 +6407,18 @@ func (c *Client) TaskEdit(task Task, site ...string) (SuccessfulResponse, int, e
 //		log.Printf("%v\n", value)
 //	}
 func (c *Client) UserGroups(parameters UserGroupsRequest) (UserGroupsResponse, int, error) {
+	return c.UserGroupsCtx(c.defaultContext(), parameters)
+}
+
+// UserGroupsCtx is the same as UserGroups, but uses the provided context.Context.
+func (c *Client) UserGroupsCtx(ctx context.Context, parameters UserGroupsRequest) (UserGroupsResponse, int, error) {
+	ctx = withAPIMethod(ctx, "UserGroups")
+
 	var resp UserGroupsResponse
 
 	params, _ := query.Values(parameters)
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/user-groups?%s", params.Encode()))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/user-groups?%s", params.Encode()))
 	if err != nil {
 		return resp, status, err
 	}
@@ -5323,11 +6453,18 @@ func (c *Client) UserGroups(parameters UserGroupsRequest) (UserGroupsResponse, i
 //		log.Printf("%v\n", value)
 //	}
 func (c *Client) Users(parameters UsersRequest) (UsersResponse, int, error) {
+	return c.UsersCtx(c.defaultContext(), parameters)
+}
+
+// UsersCtx is the same as Users, but uses the provided context.Context.
+func (c *Client) UsersCtx(ctx context.Context, parameters UsersRequest) (UsersResponse, int, error) {
+	ctx = withAPIMethod(ctx, "Users")
+
 	var resp UsersResponse
 
 	params, _ := query.Values(parameters)
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/users?%s", params.Encode()))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/users?%s", params.Encode()))
 	if err != nil {
 		return resp, status, err
 	}
@@ -5362,9 +6499,16 @@ func (c *Client) Users(parameters UsersRequest) (UsersResponse, int, error) {
 //		log.Printf("%v\n", data.User)
 //	}
 func (c *Client) User(id int) (UserResponse, int, error) {
+	return c.UserCtx(c.defaultContext(), id)
+}
+
+// UserCtx is the same as User, but uses the provided context.Context.
+func (c *Client) UserCtx(ctx context.Context, id int) (UserResponse, int, error) {
+	ctx = withAPIMethod(ctx, "User")
+
 	var resp UserResponse
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/users/%d", id))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/users/%d", id))
 	if err != nil {
 		return resp, status, err
 	}
@@ -5395,13 +6539,20 @@ func (c *Client) User(id int) (UserResponse, int, error) {
 //		log.Fatalf("http status: %d, error: %s", status, err)
 //	}
 func (c *Client) UserStatus(id int, status string) (SuccessfulResponse, int, error) {
+	return c.UserStatusCtx(c.defaultContext(), id, status)
+}
+
+// UserStatusCtx is the same as UserStatus, but uses the provided context.Context.
+func (c *Client) UserStatusCtx(ctx context.Context, id int, status string) (SuccessfulResponse, int, error) {
+	ctx = withAPIMethod(ctx, "UserStatus")
+
 	var resp SuccessfulResponse
 
 	p := url.Values{
 		"status": {status},
 	}
 
-	data, st, err := c.PostRequest(fmt.Sprintf("/users/%d/status", id), p)
+	data, st, err := c.PostRequestCtx(ctx, fmt.Sprintf("/users/%d/status", id), p)
 	if err != nil {
 		return resp, st, err
 	}
@@ -5422,9 +6573,16 @@ func (c *Client) UserStatus(id int, status string) (SuccessfulResponse, int, err
 //
 // For more information see http://www.simla.com/docs/Developers/API/APIVersions/APIv5#get--api-v5-statistic-update
 func (c *Client) StaticticsUpdate() (SuccessfulResponse, int, error) {
+	return c.StaticticsUpdateCtx(c.defaultContext())
+}
+
+// StaticticsUpdateCtx is the same as StaticticsUpdate, but uses the provided context.Context.
+func (c *Client) StaticticsUpdateCtx(ctx context.Context) (SuccessfulResponse, int, error) {
+	ctx = withAPIMethod(ctx, "StaticticsUpdate")
+
 	var resp SuccessfulResponse
 
-	data, status, err := c.GetRequest("/statistic/update")
+	data, status, err := c.GetRequestCtx(ctx, "/statistic/update")
 	if err != nil {
 		return resp, status, err
 	}
@@ -5464,11 +6622,18 @@ func (c *Client) StaticticsUpdate() (SuccessfulResponse, int, error) {
 //		log.Printf("%v\n", value.Summ)
 //	}
 func (c *Client) Costs(costs CostsRequest) (CostsResponse, int, error) {
+	return c.CostsCtx(c.defaultContext(), costs)
+}
+
+// CostsCtx is the same as Costs, but uses the provided context.Context.
+func (c *Client) CostsCtx(ctx context.Context, costs CostsRequest) (CostsResponse, int, error) {
+	ctx = withAPIMethod(ctx, "Costs")
+
 	var resp CostsResponse
 
 	params, _ := query.Values(costs)
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/costs?%s", params.Encode()))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/costs?%s", params.Encode()))
 
 	if err != nil {
 		return resp, status, err
@@ -5516,6 +6681,13 @@ func (c *Client) Costs(costs CostsRequest) (CostsResponse, int, error) {
 //		log.Printf("%v", data.ID)
 //	}
 func (c *Client) CostCreate(cost CostRecord, site ...string) (CreateResponse, int, error) {
+	return c.CostCreateCtx(c.defaultContext(), cost, site...)
+}
+
+// CostCreateCtx is the same as CostCreate, but uses the provided context.Context.
+func (c *Client) CostCreateCtx(ctx context.Context, cost CostRecord, site ...string) (CreateResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CostCreate")
+
 	var resp CreateResponse
 
 	costJSON, _ := json.Marshal(&cost)
@@ -5526,7 +6698,7 @@ func (c *Client) CostCreate(cost CostRecord, site ...string) (CreateResponse, in
 
 	fillSite(&p, site)
 
-	data, status, err := c.PostRequest("/costs/create", p)
+	data, status, err := c.PostRequestCtx(ctx, "/costs/create", p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -5561,6 +6733,13 @@ func (c *Client) CostCreate(cost CostRecord, site ...string) (CreateResponse, in
 //		log.Printf("Not removed costs: %v", data.NotRemovedIds)
 //	}
 func (c *Client) CostsDelete(ids []int) (CostsDeleteResponse, int, error) {
+	return c.CostsDeleteCtx(c.defaultContext(), ids)
+}
+
+// CostsDeleteCtx is the same as CostsDelete, but uses the provided context.Context.
+func (c *Client) CostsDeleteCtx(ctx context.Context, ids []int) (CostsDeleteResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CostsDelete")
+
 	var resp CostsDeleteResponse
 
 	costJSON, _ := json.Marshal(&ids)
@@ -5569,7 +6748,7 @@ func (c *Client) CostsDelete(ids []int) (CostsDeleteResponse, int, error) {
 		"ids": {string(costJSON)},
 	}
 
-	data, status, err := c.PostRequest("/costs/delete", p)
+	data, status, err := c.PostRequestCtx(ctx, "/costs/delete", p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -5621,6 +6800,13 @@ func (c *Client) CostsDelete(ids []int) (CostsDeleteResponse, int, error) {
 //		log.Printf("Uploaded costs: %v", data.UploadedCosts)
 //	}
 func (c *Client) CostsUpload(cost []CostRecord) (CostsUploadResponse, int, error) {
+	return c.CostsUploadCtx(c.defaultContext(), cost)
+}
+
+// CostsUploadCtx is the same as CostsUpload, but uses the provided context.Context.
+func (c *Client) CostsUploadCtx(ctx context.Context, cost []CostRecord) (CostsUploadResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CostsUpload")
+
 	var resp CostsUploadResponse
 
 	costJSON, _ := json.Marshal(&cost)
@@ -5629,7 +6815,7 @@ func (c *Client) CostsUpload(cost []CostRecord) (CostsUploadResponse, int, error
 		"costs": {string(costJSON)},
 	}
 
-	data, status, err := c.PostRequest("/costs/upload", p)
+	data, status, err := c.PostRequestCtx(ctx, "/costs/upload", p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -5664,9 +6850,16 @@ func (c *Client) CostsUpload(cost []CostRecord) (CostsUploadResponse, int, error
 //		log.Printf("%v", data.Cost)
 //	}
 func (c *Client) Cost(id int) (CostResponse, int, error) {
+	return c.CostCtx(c.defaultContext(), id)
+}
+
+// CostCtx is the same as Cost, but uses the provided context.Context.
+func (c *Client) CostCtx(ctx context.Context, id int) (CostResponse, int, error) {
+	ctx = withAPIMethod(ctx, "Cost")
+
 	var resp CostResponse
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/costs/%d", id))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/costs/%d", id))
 
 	if err != nil {
 		return resp, status, err
@@ -5698,6 +6891,13 @@ func (c *Client) Cost(id int) (CostResponse, int, error) {
 //		log.Fatalf("http status: %d, error: %s", status, err)
 //	}
 func (c *Client) CostDelete(id int) (SuccessfulResponse, int, error) {
+	return c.CostDeleteCtx(c.defaultContext(), id)
+}
+
+// CostDeleteCtx is the same as CostDelete, but uses the provided context.Context.
+func (c *Client) CostDeleteCtx(ctx context.Context, id int) (SuccessfulResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CostDelete")
+
 	var resp SuccessfulResponse
 
 	costJSON, _ := json.Marshal(&id)
@@ -5706,7 +6906,7 @@ func (c *Client) CostDelete(id int) (SuccessfulResponse, int, error) {
 		"costs": {string(costJSON)},
 	}
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/costs/%d/delete", id), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/costs/%d/delete", id), p)
 
 	if err != nil {
 		return resp, status, err
@@ -5747,6 +6947,15 @@ func (c *Client) CostDelete(id int) (SuccessfulResponse, int, error) {
 //		log.Printf("%v", data.ID)
 //	}
 func (c *Client) CostEdit(id int, cost CostRecord, site ...string) (CreateResponse, int, error) {
+	return c.CostEditCtx(c.defaultContext(), id, cost, site...)
+}
+
+// CostEditCtx is the same as CostEdit, but uses the provided context.Context.
+func (c *Client) CostEditCtx(
+	ctx context.Context, id int, cost CostRecord, site ...string,
+) (CreateResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CostEdit")
+
 	var resp CreateResponse
 
 	costJSON, _ := json.Marshal(&cost)
@@ -5757,7 +6966,7 @@ func (c *Client) CostEdit(id int, cost CostRecord, site ...string) (CreateRespon
 
 	fillSite(&p, site)
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/costs/%d/edit", id), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/costs/%d/edit", id), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -5792,11 +7001,18 @@ func (c *Client) CostEdit(id int, cost CostRecord, site ...string) (CreateRespon
 //		log.Fatalf("http status: %d, error: %s", status, err)
 //	}
 func (c *Client) Files(files FilesRequest) (FilesResponse, int, error) {
+	return c.FilesCtx(c.defaultContext(), files)
+}
+
+// FilesCtx is the same as Files, but uses the provided context.Context.
+func (c *Client) FilesCtx(ctx context.Context, files FilesRequest) (FilesResponse, int, error) {
+	ctx = withAPIMethod(ctx, "Files")
+
 	var resp FilesResponse
 
 	params, _ := query.Values(files)
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/files?%s", params.Encode()))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/files?%s", params.Encode()))
 
 	if err != nil && err.Error() != "" {
 		return resp, status, err
@@ -5833,9 +7049,16 @@ func (c *Client) Files(files FilesRequest) (FilesResponse, int, error) {
 //		    fmt.Printf("%v", err.Error())
 //	 }
 func (c *Client) FileUpload(reader io.Reader) (FileUploadResponse, int, error) {
+	return c.FileUploadCtx(c.defaultContext(), reader)
+}
+
+// FileUploadCtx is the same as FileUpload, but uses the provided context.Context.
+func (c *Client) FileUploadCtx(ctx context.Context, reader io.Reader) (FileUploadResponse, int, error) {
+	ctx = withAPIMethod(ctx, "FileUpload")
+
 	var resp FileUploadResponse
 
-	data, status, err := c.PostRequest("/files/upload", reader, "application/octet-stream")
+	data, status, err := c.PostRequestCtx(ctx, "/files/upload", reader, "application/octet-stream")
 
 	if err != nil && err.Error() != "" {
 		return resp, status, err
@@ -5871,9 +7094,16 @@ func (c *Client) FileUpload(reader io.Reader) (FileUploadResponse, int, error) {
 //		log.Printf("%v\n", data.File)
 //	}
 func (c *Client) File(id int) (FileResponse, int, error) {
+	return c.FileCtx(c.defaultContext(), id)
+}
+
+// FileCtx is the same as File, but uses the provided context.Context.
+func (c *Client) FileCtx(ctx context.Context, id int) (FileResponse, int, error) {
+	ctx = withAPIMethod(ctx, "File")
+
 	var resp FileResponse
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/files/%d", id))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/files/%d", id))
 	if err != nil {
 		return resp, status, err
 	}
@@ -5904,9 +7134,16 @@ func (c *Client) File(id int) (FileResponse, int, error) {
 //		    fmt.Printf("%v", err.Error())
 //	 }
 func (c *Client) FileDelete(id int) (SuccessfulResponse, int, error) {
+	return c.FileDeleteCtx(c.defaultContext(), id)
+}
+
+// FileDeleteCtx is the same as FileDelete, but uses the provided context.Context.
+func (c *Client) FileDeleteCtx(ctx context.Context, id int) (SuccessfulResponse, int, error) {
+	ctx = withAPIMethod(ctx, "FileDelete")
+
 	var resp SuccessfulResponse
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/files/%d/delete", id), strings.NewReader(""))
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/files/%d/delete", id), strings.NewReader(""))
 
 	if err != nil && err.Error() != "" {
 		return resp, status, err
@@ -5938,7 +7175,14 @@ func (c *Client) FileDelete(id int) (SuccessfulResponse, int, error) {
 //		    fmt.Printf("%v", err.Error())
 //	 }
 func (c *Client) FileDownload(id int) (io.ReadCloser, int, error) {
-	data, status, err := c.GetRequest(fmt.Sprintf("/files/%d/download", id))
+	return c.FileDownloadCtx(c.defaultContext(), id)
+}
+
+// FileDownloadCtx is the same as FileDownload, but uses the provided context.Context.
+func (c *Client) FileDownloadCtx(ctx context.Context, id int) (io.ReadCloser, int, error) {
+	ctx = withAPIMethod(ctx, "FileDownload")
+
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/files/%d/download", id))
 	if status != http.StatusOK {
 		return nil, status, err
 	}
@@ -5965,10 +7209,17 @@ func (c *Client) FileDownload(id int) (io.ReadCloser, int, error) {
 //		    fmt.Printf("%v", err.Error())
 //	 }
 func (c *Client) FileEdit(id int, file File) (FileResponse, int, error) {
+	return c.FileEditCtx(c.defaultContext(), id, file)
+}
+
+// FileEditCtx is the same as FileEdit, but uses the provided context.Context.
+func (c *Client) FileEditCtx(ctx context.Context, id int, file File) (FileResponse, int, error) {
+	ctx = withAPIMethod(ctx, "FileEdit")
+
 	var resp FileResponse
 
 	req, _ := json.Marshal(file)
-	data, status, err := c.PostRequest(
+	data, status, err := c.PostRequestCtx(ctx,
 		fmt.Sprintf("/files/%d/edit", id), url.Values{
 			"file": {string(req)},
 		},
@@ -6011,11 +7262,20 @@ func (c *Client) FileEdit(id int, file File) (FileResponse, int, error) {
 //		fmt.Printf("%v\n", value)
 //	}
 func (c *Client) CustomFields(customFields CustomFieldsRequest) (CustomFieldsResponse, int, error) {
+	return c.CustomFieldsCtx(c.defaultContext(), customFields)
+}
+
+// CustomFieldsCtx is the same as CustomFields, but uses the provided context.Context.
+func (c *Client) CustomFieldsCtx(
+	ctx context.Context, customFields CustomFieldsRequest,
+) (CustomFieldsResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CustomFields")
+
 	var resp CustomFieldsResponse
 
 	params, _ := query.Values(customFields)
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/custom-fields?%s", params.Encode()))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/custom-fields?%s", params.Encode()))
 
 	if err != nil {
 		return resp, status, err
@@ -6057,11 +7317,20 @@ func (c *Client) CustomFields(customFields CustomFieldsRequest) (CustomFieldsRes
 func (c *Client) CustomDictionaries(customDictionaries CustomDictionariesRequest) (
 	CustomDictionariesResponse, int, error,
 ) {
+	return c.CustomDictionariesCtx(c.defaultContext(), customDictionaries)
+}
+
+// CustomDictionariesCtx is the same as CustomDictionaries, but uses the provided context.Context.
+func (c *Client) CustomDictionariesCtx(ctx context.Context, customDictionaries CustomDictionariesRequest) (
+	CustomDictionariesResponse, int, error,
+) {
+	ctx = withAPIMethod(ctx, "CustomDictionaries")
+
 	var resp CustomDictionariesResponse
 
 	params, _ := query.Values(customDictionaries)
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/custom-fields/dictionaries?%s", params.Encode()))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/custom-fields/dictionaries?%s", params.Encode()))
 
 	if err != nil {
 		return resp, status, err
@@ -6110,6 +7379,15 @@ func (c *Client) CustomDictionaries(customDictionaries CustomDictionariesRequest
 //		fmt.Printf("%v", data.Code)
 //	}
 func (c *Client) CustomDictionariesCreate(customDictionary CustomDictionary) (CustomResponse, int, error) {
+	return c.CustomDictionariesCreateCtx(c.defaultContext(), customDictionary)
+}
+
+// CustomDictionariesCreateCtx is the same as CustomDictionariesCreate, but uses the provided context.Context.
+func (c *Client) CustomDictionariesCreateCtx(
+	ctx context.Context, customDictionary CustomDictionary,
+) (CustomResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CustomDictionariesCreate")
+
 	var resp CustomResponse
 
 	costJSON, _ := json.Marshal(&customDictionary)
@@ -6118,7 +7396,7 @@ func (c *Client) CustomDictionariesCreate(customDictionary CustomDictionary) (Cu
 		"customDictionary": {string(costJSON)},
 	}
 
-	data, status, err := c.PostRequest("/custom-fields/dictionaries/create", p)
+	data, status, err := c.PostRequestCtx(ctx, "/custom-fields/dictionaries/create", p)
 
 	if err != nil {
 		return resp, status, err
@@ -6154,9 +7432,16 @@ func (c *Client) CustomDictionariesCreate(customDictionary CustomDictionary) (Cu
 //		log.Printf("%v", data.CustomDictionary.Name)
 //	}
 func (c *Client) CustomDictionary(code string) (CustomDictionaryResponse, int, error) {
+	return c.CustomDictionaryCtx(c.defaultContext(), code)
+}
+
+// CustomDictionaryCtx is the same as CustomDictionary, but uses the provided context.Context.
+func (c *Client) CustomDictionaryCtx(ctx context.Context, code string) (CustomDictionaryResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CustomDictionary")
+
 	var resp CustomDictionaryResponse
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/custom-fields/dictionaries/%s", code))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/custom-fields/dictionaries/%s", code))
 
 	if err != nil {
 		return resp, status, err
@@ -6205,6 +7490,15 @@ func (c *Client) CustomDictionary(code string) (CustomDictionaryResponse, int, e
 //		fmt.Printf("%v", data.Code)
 //	}
 func (c *Client) CustomDictionaryEdit(customDictionary CustomDictionary) (CustomResponse, int, error) {
+	return c.CustomDictionaryEditCtx(c.defaultContext(), customDictionary)
+}
+
+// CustomDictionaryEditCtx is the same as CustomDictionaryEdit, but uses the provided context.Context.
+func (c *Client) CustomDictionaryEditCtx(
+	ctx context.Context, customDictionary CustomDictionary,
+) (CustomResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CustomDictionaryEdit")
+
 	var resp CustomResponse
 
 	costJSON, _ := json.Marshal(&customDictionary)
@@ -6213,7 +7507,7 @@ func (c *Client) CustomDictionaryEdit(customDictionary CustomDictionary) (Custom
 		"customDictionary": {string(costJSON)},
 	}
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/custom-fields/dictionaries/%s/edit", customDictionary.Code), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/custom-fields/dictionaries/%s/edit", customDictionary.Code), p)
 	if err != nil {
 		return resp, status, err
 	}
@@ -6254,6 +7548,13 @@ func (c *Client) CustomDictionaryEdit(customDictionary CustomDictionary) (Custom
 //		log.Printf("%v", data.Code)
 //	}
 func (c *Client) CustomFieldsCreate(customFields CustomFields) (CustomResponse, int, error) {
+	return c.CustomFieldsCreateCtx(c.defaultContext(), customFields)
+}
+
+// CustomFieldsCreateCtx is the same as CustomFieldsCreate, but uses the provided context.Context.
+func (c *Client) CustomFieldsCreateCtx(ctx context.Context, customFields CustomFields) (CustomResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CustomFieldsCreate")
+
 	var resp CustomResponse
 
 	costJSON, _ := json.Marshal(&customFields)
@@ -6262,7 +7563,7 @@ func (c *Client) CustomFieldsCreate(customFields CustomFields) (CustomResponse,
 		"customField": {string(costJSON)},
 	}
 
-	data, status, err := c.PostRequest(fmt.Sprintf("/custom-fields/%s/create", customFields.Entity), p)
+	data, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/custom-fields/%s/create", customFields.Entity), p)
 
 	if err != nil {
 		return resp, status, err
@@ -6298,9 +7599,16 @@ func (c *Client) CustomFieldsCreate(customFields CustomFields) (CustomResponse,
 //		log.Printf("%v", data.CustomField)
 //	}
 func (c *Client) CustomField(entity, code string) (CustomFieldResponse, int, error) {
+	return c.CustomFieldCtx(c.defaultContext(), entity, code)
+}
+
+// CustomFieldCtx is the same as CustomField, but uses the provided context.Context.
+func (c *Client) CustomFieldCtx(ctx context.Context, entity, code string) (CustomFieldResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CustomField")
+
 	var resp CustomFieldResponse
 
-	data, status, err := c.GetRequest(fmt.Sprintf("/custom-fields/%s/%s", entity, code))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/custom-fields/%s/%s", entity, code))
 
 	if err != nil {
 		return resp, status, err
@@ -6340,6 +7648,13 @@ func (c *Client) CustomField(entity, code string) (CustomFieldResponse, int, err
 //		log.Printf("%v", data.Code)
 //	}
 func (c *Client) CustomFieldEdit(customFields CustomFields) (CustomResponse, int, error) {
+	return c.CustomFieldEditCtx(c.defaultContext(), customFields)
+}
+
+// CustomFieldEditCtx is the same as CustomFieldEdit, but uses the provided context.Context.
+func (c *Client) CustomFieldEditCtx(ctx context.Context, customFields CustomFields) (CustomResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CustomFieldEdit")
+
 	var resp CustomResponse
 
 	costJSON, _ := json.Marshal(&customFields)
@@ -6348,7 +7663,7 @@ func (c *Client) CustomFieldEdit(customFields CustomFields) (CustomResponse, int
 		"customField": {string(costJSON)},
 	}
 
-	data, status, err := c.PostRequest(
+	data, status, err := c.PostRequestCtx(ctx,
 		fmt.Sprintf("/custom-fields/%s/%s/edit", customFields.Entity, customFields.Code), p,
 	)
 
@@ -6392,10 +7707,19 @@ func (c *Client) CustomFieldEdit(customFields CustomFields) (CustomResponse, int
 //		log.Printf("%v\n", value)
 //	}
 func (c *Client) BonusOperations(parameters BonusOperationsRequest) (BonusOperationsResponse, int, error) {
+	return c.BonusOperationsCtx(c.defaultContext(), parameters)
+}
+
+// BonusOperationsCtx is the same as BonusOperations, but uses the provided context.Context.
+func (c *Client) BonusOperationsCtx(
+	ctx context.Context, parameters BonusOperationsRequest,
+) (BonusOperationsResponse, int, error) {
+	ctx = withAPIMethod(ctx, "BonusOperations")
+
 	var resp BonusOperationsResponse
 
 	params, _ := query.Values(parameters)
-	data, status, err := c.GetRequest(fmt.Sprintf("/loyalty/bonus/operations?%s", params.Encode()))
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/loyalty/bonus/operations?%s", params.Encode()))
 
 	if err != nil {
 		return resp, status, err
@@ -6438,6 +7762,15 @@ func (c *Client) BonusOperations(parameters BonusOperationsRequest) (BonusOperat
 //		log.Printf("%v\n", value)
 //	}
 func (c *Client) AccountBonusOperations(id int, parameters AccountBonusOperationsRequest) (BonusOperationsResponse, int, error) {
+	return c.AccountBonusOperationsCtx(c.defaultContext(), id, parameters)
+}
+
+// AccountBonusOperationsCtx is the same as AccountBonusOperations, but uses the provided context.Context.
+func (c *Client) AccountBonusOperationsCtx(
+	ctx context.Context, id int, parameters AccountBonusOperationsRequest,
+) (BonusOperationsResponse, int, error) {
+	ctx = withAPIMethod(ctx, "AccountBonusOperations")
+
 	var resp BonusOperationsResponse
 
 	if id == 0 {
@@ -6445,7 +7778,7 @@ func (c *Client) AccountBonusOperations(id int, parameters AccountBonusOperation
 	}
 
 	params, _ := query.Values(parameters)
-	data, status, err := c.GetRequest(fmt.Sprintf(
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf(
 		"/loyalty/account/%d/bonus/operations?%s",
 		id, params.Encode(),
 	))
@@ -6497,6 +7830,15 @@ func (c *Client) AccountBonusOperations(id int, parameters AccountBonusOperation
 //			log.Printf("%v", data.ProcessedProductsCount)
 //		}
 func (c *Client) ProductsBatchEdit(products []ProductEdit) (ProductsBatchEditResponse, int, error) {
+	return c.ProductsBatchEditCtx(c.defaultContext(), products)
+}
+
+// ProductsBatchEditCtx is the same as ProductsBatchEdit, but uses the provided context.Context.
+func (c *Client) ProductsBatchEditCtx(
+	ctx context.Context, products []ProductEdit,
+) (ProductsBatchEditResponse, int, error) {
+	ctx = withAPIMethod(ctx, "ProductsBatchEdit")
+
 	var resp ProductsBatchEditResponse
 
 	productsEditJSON, _ := json.Marshal(products)
@@ -6504,7 +7846,7 @@ func (c *Client) ProductsBatchEdit(products []ProductEdit) (ProductsBatchEditRes
 		"products": {string(productsEditJSON)},
 	}
 
-	data, status, err := c.PostRequest("/store/products/batch/edit", p)
+	data, status, err := c.PostRequestCtx(ctx, "/store/products/batch/edit", p)
 
 	if err != nil {
 		return resp, status, err
@@ -6551,6 +7893,15 @@ func (c *Client) ProductsBatchEdit(products []ProductEdit) (ProductsBatchEditRes
 //			log.Printf("%v", data.AddedProducts)
 //		}
 func (c *Client) ProductsBatchCreate(products []ProductCreate) (ProductsBatchEditResponse, int, error) {
+	return c.ProductsBatchCreateCtx(c.defaultContext(), products)
+}
+
+// ProductsBatchCreateCtx is the same as ProductsBatchCreate, but uses the provided context.Context.
+func (c *Client) ProductsBatchCreateCtx(
+	ctx context.Context, products []ProductCreate,
+) (ProductsBatchEditResponse, int, error) {
+	ctx = withAPIMethod(ctx, "ProductsBatchCreate")
+
 	var resp ProductsBatchEditResponse
 
 	productsEditJSON, _ := json.Marshal(products)
@@ -6558,7 +7909,7 @@ func (c *Client) ProductsBatchCreate(products []ProductCreate) (ProductsBatchEdi
 		"products": {string(productsEditJSON)},
 	}
 
-	data, status, err := c.PostRequest("/store/products/batch/create", p)
+	data, status, err := c.PostRequestCtx(ctx, "/store/products/batch/create", p)
 
 	if err != nil {
 		return resp, status, err
@@ -6603,6 +7954,15 @@ func (c *Client) ProductsBatchCreate(products []ProductCreate) (ProductsBatchEdi
 //			log.Printf("%v", data.LoyaltyAccount.ID)
 //		}
 func (c *Client) LoyaltyAccountCreate(site string, loyaltyAccount SerializedCreateLoyaltyAccount) (CreateLoyaltyAccountResponse, int, error) {
+	return c.LoyaltyAccountCreateCtx(c.defaultContext(), site, loyaltyAccount)
+}
+
+// LoyaltyAccountCreateCtx is the same as LoyaltyAccountCreate, but uses the provided context.Context.
+func (c *Client) LoyaltyAccountCreateCtx(
+	ctx context.Context, site string, loyaltyAccount SerializedCreateLoyaltyAccount,
+) (CreateLoyaltyAccountResponse, int, error) {
+	ctx = withAPIMethod(ctx, "LoyaltyAccountCreate")
+
 	var result CreateLoyaltyAccountResponse
 
 	loyaltyAccountJSON, _ := json.Marshal(loyaltyAccount)
@@ -6611,7 +7971,7 @@ func (c *Client) LoyaltyAccountCreate(site string, loyaltyAccount SerializedCrea
 		"loyaltyAccount": {string(loyaltyAccountJSON)},
 	}
 
-	resp, status, err := c.PostRequest("/loyalty/account/create", p)
+	resp, status, err := c.PostRequestCtx(ctx, "/loyalty/account/create", p)
 
 	if err != nil {
 		return result, status, err
@@ -6654,6 +8014,15 @@ func (c *Client) LoyaltyAccountCreate(site string, loyaltyAccount SerializedCrea
 //			log.Printf("%v", data.LoyaltyAccount.PhoneNumber)
 //		}
 func (c *Client) LoyaltyAccountEdit(id int, loyaltyAccount SerializedEditLoyaltyAccount) (EditLoyaltyAccountResponse, int, error) {
+	return c.LoyaltyAccountEditCtx(c.defaultContext(), id, loyaltyAccount)
+}
+
+// LoyaltyAccountEditCtx is the same as LoyaltyAccountEdit, but uses the provided context.Context.
+func (c *Client) LoyaltyAccountEditCtx(
+	ctx context.Context, id int, loyaltyAccount SerializedEditLoyaltyAccount,
+) (EditLoyaltyAccountResponse, int, error) {
+	ctx = withAPIMethod(ctx, "LoyaltyAccountEdit")
+
 	var result EditLoyaltyAccountResponse
 
 	loyaltyAccountJSON, _ := json.Marshal(loyaltyAccount)
@@ -6661,7 +8030,7 @@ func (c *Client) LoyaltyAccountEdit(id int, loyaltyAccount SerializedEditLoyalty
 		"loyaltyAccount": {string(loyaltyAccountJSON)},
 	}
 
-	resp, status, err := c.PostRequest(fmt.Sprintf("/loyalty/account/%d/edit", id), p)
+	resp, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/loyalty/account/%d/edit", id), p)
 
 	if err != nil {
 		return result, status, err
@@ -6698,9 +8067,16 @@ func (c *Client) LoyaltyAccountEdit(id int, loyaltyAccount SerializedEditLoyalty
 //		log.Printf("%v", data.LoyaltyAccount.PhoneNumber)
 //	}
 func (c *Client) LoyaltyAccount(id int) (LoyaltyAccountResponse, int, error) {
+	return c.LoyaltyAccountCtx(c.defaultContext(), id)
+}
+
+// LoyaltyAccountCtx is the same as LoyaltyAccount, but uses the provided context.Context.
+func (c *Client) LoyaltyAccountCtx(ctx context.Context, id int) (LoyaltyAccountResponse, int, error) {
+	ctx = withAPIMethod(ctx, "LoyaltyAccount")
+
 	var result LoyaltyAccountResponse
 
-	resp, status, err := c.GetRequest(fmt.Sprintf("/loyalty/account/%d", id))
+	resp, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/loyalty/account/%d", id))
 
 	if err != nil {
 		return result, status, err
@@ -6737,9 +8113,16 @@ func (c *Client) LoyaltyAccount(id int) (LoyaltyAccountResponse, int, error) {
 //		log.Printf("%v", data.LoyaltyAccount.Active)
 //	}
 func (c *Client) LoyaltyAccountActivate(id int) (LoyaltyAccountActivateResponse, int, error) {
+	return c.LoyaltyAccountActivateCtx(c.defaultContext(), id)
+}
+
+// LoyaltyAccountActivateCtx is the same as LoyaltyAccountActivate, but uses the provided context.Context.
+func (c *Client) LoyaltyAccountActivateCtx(ctx context.Context, id int) (LoyaltyAccountActivateResponse, int, error) {
+	ctx = withAPIMethod(ctx, "LoyaltyAccountActivate")
+
 	var result LoyaltyAccountActivateResponse
 
-	resp, status, err := c.PostRequest(fmt.Sprintf("/loyalty/account/%d/activate", id), strings.NewReader(""))
+	resp, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/loyalty/account/%d/activate", id), strings.NewReader(""))
 
 	if err != nil {
 		return result, status, err
@@ -6782,10 +8165,19 @@ func (c *Client) LoyaltyAccountActivate(id int) (LoyaltyAccountActivateResponse,
 //		log.Printf("%v", data.LoyaltyBonus.ActivationDate)
 //	}
 func (c *Client) LoyaltyBonusCredit(id int, req LoyaltyBonusCreditRequest) (LoyaltyBonusCreditResponse, int, error) {
+	return c.LoyaltyBonusCreditCtx(c.defaultContext(), id, req)
+}
+
+// LoyaltyBonusCreditCtx is the same as LoyaltyBonusCredit, but uses the provided context.Context.
+func (c *Client) LoyaltyBonusCreditCtx(
+	ctx context.Context, id int, req LoyaltyBonusCreditRequest,
+) (LoyaltyBonusCreditResponse, int, error) {
+	ctx = withAPIMethod(ctx, "LoyaltyBonusCredit")
+
 	var result LoyaltyBonusCreditResponse
 	p, _ := query.Values(req)
 
-	resp, status, err := c.PostRequest(fmt.Sprintf("/loyalty/account/%d/bonus/credit", id), p)
+	resp, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/loyalty/account/%d/bonus/credit", id), p)
 
 	if err != nil {
 		return result, status, err
@@ -6831,11 +8223,21 @@ func (c *Client) LoyaltyBonusCredit(id int, req LoyaltyBonusCreditRequest) (Loya
 func (c *Client) LoyaltyBonusStatusDetails(
 	id int, statusType string, request LoyaltyBonusStatusDetailsRequest,
 ) (LoyaltyBonusDetailsResponse, int, error) {
+	return c.LoyaltyBonusStatusDetailsCtx(c.defaultContext(), id, statusType, request)
+}
+
+// LoyaltyBonusStatusDetailsCtx is the same as LoyaltyBonusStatusDetails, but uses the provided context.Context.
+func (c *Client) LoyaltyBonusStatusDetailsCtx(
+	ctx context.Context,
+	id int, statusType string, request LoyaltyBonusStatusDetailsRequest,
+) (LoyaltyBonusDetailsResponse, int, error) {
+	ctx = withAPIMethod(ctx, "LoyaltyBonusStatusDetails")
+
 	var result LoyaltyBonusDetailsResponse
 
 	p, _ := query.Values(request)
 
-	resp, status, err := c.GetRequest(fmt.Sprintf("/loyalty/account/%d/bonus/%s/details?%s", id, statusType, p.Encode()))
+	resp, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/loyalty/account/%d/bonus/%s/details?%s", id, statusType, p.Encode()))
 
 	if err != nil {
 		return result, status, err
@@ -6885,11 +8287,20 @@ func (c *Client) LoyaltyBonusStatusDetails(
 //		}
 //	}
 func (c *Client) LoyaltyAccounts(req LoyaltyAccountsRequest) (LoyaltyAccountsResponse, int, error) {
+	return c.LoyaltyAccountsCtx(c.defaultContext(), req)
+}
+
+// LoyaltyAccountsCtx is the same as LoyaltyAccounts, but uses the provided context.Context.
+func (c *Client) LoyaltyAccountsCtx(
+	ctx context.Context, req LoyaltyAccountsRequest,
+) (LoyaltyAccountsResponse, int, error) {
+	ctx = withAPIMethod(ctx, "LoyaltyAccounts")
+
 	var result LoyaltyAccountsResponse
 
 	p, _ := query.Values(req)
 
-	resp, status, err := c.GetRequest(fmt.Sprintf("/loyalty/accounts?%s", p.Encode()))
+	resp, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/loyalty/accounts?%s", p.Encode()))
 
 	if err != nil {
 		return result, status, err
@@ -6946,6 +8357,15 @@ func (c *Client) LoyaltyAccounts(req LoyaltyAccountsRequest) (LoyaltyAccountsRes
 //		log.Printf("%v", data.Order.BonusesCreditTotal)
 //	}
 func (c *Client) LoyaltyCalculate(req LoyaltyCalculateRequest) (LoyaltyCalculateResponse, int, error) {
+	return c.LoyaltyCalculateCtx(c.defaultContext(), req)
+}
+
+// LoyaltyCalculateCtx is the same as LoyaltyCalculate, but uses the provided context.Context.
+func (c *Client) LoyaltyCalculateCtx(
+	ctx context.Context, req LoyaltyCalculateRequest,
+) (LoyaltyCalculateResponse, int, error) {
+	ctx = withAPIMethod(ctx, "LoyaltyCalculate")
+
 	var result LoyaltyCalculateResponse
 
 	orderJSON, _ := json.Marshal(req.Order)
@@ -6956,7 +8376,7 @@ func (c *Client) LoyaltyCalculate(req LoyaltyCalculateRequest) (LoyaltyCalculate
 		"bonuses": {fmt.Sprintf("%f", req.Bonuses)},
 	}
 
-	resp, status, err := c.PostRequest("/loyalty/calculate", p)
+	resp, status, err := c.PostRequestCtx(ctx, "/loyalty/calculate", p)
 
 	if err != nil {
 		return result, status, err
@@ -7004,11 +8424,18 @@ func (c *Client) LoyaltyCalculate(req LoyaltyCalculateRequest) (LoyaltyCalculate
 //		}
 //	}
 func (c *Client) GetLoyalties(req LoyaltiesRequest) (LoyaltiesResponse, int, error) {
+	return c.GetLoyaltiesCtx(c.defaultContext(), req)
+}
+
+// GetLoyaltiesCtx is the same as GetLoyalties, but uses the provided context.Context.
+func (c *Client) GetLoyaltiesCtx(ctx context.Context, req LoyaltiesRequest) (LoyaltiesResponse, int, error) {
+	ctx = withAPIMethod(ctx, "GetLoyalties")
+
 	var result LoyaltiesResponse
 
 	p, _ := query.Values(req)
 
-	resp, status, err := c.GetRequest(fmt.Sprintf("/loyalty/loyalties?%s", p.Encode()))
+	resp, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/loyalty/loyalties?%s", p.Encode()))
 
 	if err != nil {
 		return result, status, err
@@ -7046,9 +8473,16 @@ func (c *Client) GetLoyalties(req LoyaltiesRequest) (LoyaltiesResponse, int, err
 //		log.Printf("%v", res.Loyalty.Active)
 //	}
 func (c *Client) GetLoyaltyByID(id int) (LoyaltyResponse, int, error) {
+	return c.GetLoyaltyByIDCtx(c.defaultContext(), id)
+}
+
+// GetLoyaltyByIDCtx is the same as GetLoyaltyByID, but uses the provided context.Context.
+func (c *Client) GetLoyaltyByIDCtx(ctx context.Context, id int) (LoyaltyResponse, int, error) {
+	ctx = withAPIMethod(ctx, "GetLoyaltyByID")
+
 	var result LoyaltyResponse
 
-	resp, status, err := c.GetRequest(fmt.Sprintf("/loyalty/loyalties/%d", id))
+	resp, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/loyalty/loyalties/%d", id))
 
 	if err != nil {
 		return result, status, err
@@ -7085,6 +8519,15 @@ func (c *Client) GetLoyaltyByID(id int) (LoyaltyResponse, int, error) {
 //		log.Printf("%v", res.Success)
 //	}
 func (c *Client) OrderIntegrationDeliveryCancel(by string, force bool, id string) (SuccessfulResponse, int, error) {
+	return c.OrderIntegrationDeliveryCancelCtx(c.defaultContext(), by, force, id)
+}
+
+// OrderIntegrationDeliveryCancelCtx is the same as OrderIntegrationDeliveryCancel, but uses the provided context.Context.
+func (c *Client) OrderIntegrationDeliveryCancelCtx(
+	ctx context.Context, by string, force bool, id string,
+) (SuccessfulResponse, int, error) {
+	ctx = withAPIMethod(ctx, "OrderIntegrationDeliveryCancel")
+
 	var result SuccessfulResponse
 
 	p := url.Values{
@@ -7092,7 +8535,7 @@ func (c *Client) OrderIntegrationDeliveryCancel(by string, force bool, id string
 		"force": {fmt.Sprintf("%t", force)},
 	}
 
-	resp, status, err := c.PostRequest(fmt.Sprintf("/orders/%s/delivery/cancel?%s", id, p.Encode()), strings.NewReader(""))
+	resp, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/orders/%s/delivery/cancel?%s", id, p.Encode()), strings.NewReader(""))
 
 	if err != nil {
 		return result, status, err
@@ -7137,6 +8580,15 @@ func (c *Client) OrderIntegrationDeliveryCancel(by string, force bool, id string
 //		log.Printf("%v", res.ID)
 //	}
 func (c *Client) CreateProductsGroup(group ProductGroup) (ActionProductsGroupResponse, int, error) {
+	return c.CreateProductsGroupCtx(c.defaultContext(), group)
+}
+
+// CreateProductsGroupCtx is the same as CreateProductsGroup, but uses the provided context.Context.
+func (c *Client) CreateProductsGroupCtx(
+	ctx context.Context, group ProductGroup,
+) (ActionProductsGroupResponse, int, error) {
+	ctx = withAPIMethod(ctx, "CreateProductsGroup")
+
 	var result ActionProductsGroupResponse
 
 	groupJSON, _ := json.Marshal(group)
@@ -7145,7 +8597,7 @@ func (c *Client) CreateProductsGroup(group ProductGroup) (ActionProductsGroupRes
 		"productGroup": {string(groupJSON)},
 	}
 
-	resp, status, err := c.PostRequest("/store/product-groups/create", p)
+	resp, status, err := c.PostRequestCtx(ctx, "/store/product-groups/create", p)
 
 	if err != nil {
 		return result, status, err
@@ -7188,6 +8640,15 @@ func (c *Client) CreateProductsGroup(group ProductGroup) (ActionProductsGroupRes
 //		log.Printf("%v", res.ID)
 //	}
 func (c *Client) EditProductsGroup(by, id, site string, group ProductGroup) (ActionProductsGroupResponse, int, error) {
+	return c.EditProductsGroupCtx(c.defaultContext(), by, id, site, group)
+}
+
+// EditProductsGroupCtx is the same as EditProductsGroup, but uses the provided context.Context.
+func (c *Client) EditProductsGroupCtx(
+	ctx context.Context, by, id, site string, group ProductGroup,
+) (ActionProductsGroupResponse, int, error) {
+	ctx = withAPIMethod(ctx, "EditProductsGroup")
+
 	var result ActionProductsGroupResponse
 
 	groupJSON, _ := json.Marshal(group)
@@ -7198,7 +8659,7 @@ func (c *Client) EditProductsGroup(by, id, site string, group ProductGroup) (Act
 		"productGroup": {string(groupJSON)},
 	}
 
-	resp, status, err := c.PostRequest(fmt.Sprintf("/store/product-groups/%s/edit", id), p)
+	resp, status, err := c.PostRequestCtx(ctx, fmt.Sprintf("/store/product-groups/%s/edit", id), p)
 
 	if err != nil {
 		return result, status, err
@@ -7242,24 +8703,27 @@ func (c *Client) EditProductsGroup(by, id, site string, group ProductGroup) (Act
 //		log.Printf("%s", fileData)
 //	}
 func (c *Client) GetOrderPlate(by, orderID, site string, plateID int) (io.ReadCloser, int, error) {
+	return c.GetOrderPlateCtx(c.defaultContext(), by, orderID, site, plateID)
+}
+
+// GetOrderPlateCtx is the same as GetOrderPlate, but uses the provided context.Context.
+func (c *Client) GetOrderPlateCtx(
+	ctx context.Context, by, orderID, site string, plateID int,
+) (io.ReadCloser, int, error) {
+	ctx = withAPIMethod(ctx, "GetOrderPlate")
+
 	requestURL := fmt.Sprintf("%s/api/v5/orders/%s/plates/%d/print?%s", c.URL, orderID, plateID, url.Values{
 		"by":   {checkBy(by)},
 		"site": {site},
 	}.Encode())
 
-	return c.executeWithRetryReadCloser(requestURL, func() (interface{}, *http.Response, int, error) {
-		req, err := http.NewRequest("GET", requestURL, nil)
+	return c.executeWithRetryReadCloser(ctx, http.MethodGet, requestURL, func(ctx context.Context) (interface{}, *http.Response, int, error) {
+		req, err := http.NewRequestWithContext(ctx, "GET", requestURL, nil)
 		if err != nil {
 			return nil, nil, 0, err
 		}
 
-		req.Header.Set("X-API-KEY", c.Key)
-
-		if c.Debug {
-			c.writeLog("API Request: %s %s", requestURL, c.Key)
-		}
-
-		resp, err := c.httpClient.Do(req)
+		resp, err := c.do(req)
 
 		if err != nil {
 			return nil, resp, 0, err
@@ -7314,12 +8778,19 @@ func (c *Client) GetOrderPlate(by, orderID, site string, plateID int) (io.ReadCl
 //		log.Fatalf("http status: %d, error: %s", status, err)
 //	}
 func (c *Client) NotificationsSend(req NotificationsSendRequest) (int, error) {
+	return c.NotificationsSendCtx(c.defaultContext(), req)
+}
+
+// NotificationsSendCtx is the same as NotificationsSend, but uses the provided context.Context.
+func (c *Client) NotificationsSendCtx(ctx context.Context, req NotificationsSendRequest) (int, error) {
+	ctx = withAPIMethod(ctx, "NotificationsSend")
+
 	marshaled, err := json.Marshal(req)
 	if err != nil {
 		return 0, err
 	}
 
-	_, status, err := c.PostRequest("/notifications/send",
+	_, status, err := c.PostRequestCtx(ctx, "/notifications/send",
 		url.Values{"notification": {string(marshaled)}})
 	if err != nil {
 		return status, err
@@ -7329,6 +8800,15 @@ func (c *Client) NotificationsSend(req NotificationsSendRequest) (int, error) {
 }
 
 func (c *Client) ListMGChannelTemplates(channelID, page, limit int) (MGChannelTemplatesResponse, int, error) {
+	return c.ListMGChannelTemplatesCtx(c.defaultContext(), channelID, page, limit)
+}
+
+// ListMGChannelTemplatesCtx is the same as ListMGChannelTemplates, but uses the provided context.Context.
+func (c *Client) ListMGChannelTemplatesCtx(
+	ctx context.Context, channelID, page, limit int,
+) (MGChannelTemplatesResponse, int, error) {
+	ctx = withAPIMethod(ctx, "ListMGChannelTemplates")
+
 	var resp MGChannelTemplatesResponse
 
 	values := url.Values{
@@ -7337,7 +8817,7 @@ func (c *Client) ListMGChannelTemplates(channelID, page, limit int) (MGChannelTe
 		"channel_id": {fmt.Sprintf("%d", channelID)},
 	}
 
-	data, code, err := c.GetRequest(fmt.Sprintf("/reference/mg-channels/templates?%s", values.Encode()))
+	data, code, err := c.GetRequestCtx(ctx, fmt.Sprintf("/reference/mg-channels/templates?%s", values.Encode()))
 
 	if err != nil {
 		return resp, code, err
@@ -7353,6 +8833,13 @@ func (c *Client) ListMGChannelTemplates(channelID, page, limit int) (MGChannelTe
 }
 
 func (c *Client) EditMGChannelTemplate(req EditMGChannelTemplateRequest) (int, error) {
+	return c.EditMGChannelTemplateCtx(c.defaultContext(), req)
+}
+
+// EditMGChannelTemplateCtx is the same as EditMGChannelTemplate, but uses the provided context.Context.
+func (c *Client) EditMGChannelTemplateCtx(ctx context.Context, req EditMGChannelTemplateRequest) (int, error) {
+	ctx = withAPIMethod(ctx, "EditMGChannelTemplate")
+
 	templates, err := json.Marshal(req.Templates)
 
 	if err != nil {
@@ -7378,7 +8865,7 @@ func (c *Client) EditMGChannelTemplate(req EditMGChannelTemplateRequest) (int, e
 		"removed":   {string(removed)},
 	}
 
-	_, code, err := c.PostRequest("/reference/mg-channels/templates/edit", values)
+	_, code, err := c.PostRequestCtx(ctx, "/reference/mg-channels/templates/edit", values)
 
 	if err != nil {
 		return code, err
@@ -7388,6 +8875,13 @@ func (c *Client) EditMGChannelTemplate(req EditMGChannelTemplateRequest) (int, e
 }
 
 func (c *Client) StoreOffers(req OffersRequest) (StoreOffersResponse, int, error) {
+	return c.StoreOffersCtx(c.defaultContext(), req)
+}
+
+// StoreOffersCtx is the same as StoreOffers, but uses the provided context.Context.
+func (c *Client) StoreOffersCtx(ctx context.Context, req OffersRequest) (StoreOffersResponse, int, error) {
+	ctx = withAPIMethod(ctx, "StoreOffers")
+
 	var result StoreOffersResponse
 
 	filter, err := query.Values(req)
@@ -7396,7 +8890,7 @@ func (c *Client) StoreOffers(req OffersRequest) (StoreOffersResponse, int, error
 		return StoreOffersResponse{}, 0, err
 	}
 
-	resp, status, err := c.GetRequest(fmt.Sprintf("/store/offers?%s", filter.Encode()))
+	resp, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/store/offers?%s", filter.Encode()))
 
 	if err != nil {
 		return StoreOffersResponse{}, status, err
@@ -7409,3 +8903,217 @@ func (c *Client) StoreOffers(req OffersRequest) (StoreOffersResponse, int, error
 
 	return result, status, nil
 }
+
+// TelephonyCallEvent sends the call event to the system, e.g. to show the incoming call popup to the managers
+//
+// For more information see http://www.simla.com/docs/Developers/API/APIVersions/APIv5#post--api-v5-telephony-call-event
+//
+// Example:
+//
+//	var client = retailcrm.New("https://demo.url", "09jIJ")
+//
+//	data, status, err := client.TelephonyCallEvent(retailcrm.CallEvent{
+//		Phone:          "+79991234567",
+//		Type:           retailcrm.CallTypeIn,
+//		Codes:          []string{"101"},
+//		CallExternalID: "call-1",
+//	})
+//
+//	if err != nil {
+//		if apiErr, ok := retailcrm.AsAPIError(err); ok {
+//			log.Fatalf("http status: %d, %s", status, apiErr.String())
+//		}
+//
+//		log.Fatalf("http status: %d, error: %s", status, err)
+//	}
+func (c *Client) TelephonyCallEvent(event CallEvent) (SuccessfulResponse, int, error) {
+	return c.TelephonyCallEventCtx(c.defaultContext(), event)
+}
+
+// TelephonyCallEventCtx is the same as TelephonyCallEvent, but uses the provided context.Context.
+func (c *Client) TelephonyCallEventCtx(ctx context.Context, event CallEvent) (SuccessfulResponse, int, error) {
+	ctx = withAPIMethod(ctx, "TelephonyCallEvent")
+
+	var resp SuccessfulResponse
+
+	eventJSON, err := json.Marshal(&event)
+	if err != nil {
+		return resp, 0, err
+	}
+
+	p := url.Values{
+		"event": {string(eventJSON)},
+	}
+
+	data, status, err := c.PostRequestCtx(ctx, "/telephony/call/event", p)
+	if err != nil {
+		return resp, status, err
+	}
+
+	err = json.Unmarshal(data, &resp)
+	if err != nil {
+		return resp, status, err
+	}
+
+	return resp, status, nil
+}
+
+// TelephonyCallsUpload uploads the calls history
+//
+// For more information see http://www.simla.com/docs/Developers/API/APIVersions/APIv5#post--api-v5-telephony-calls-upload
+//
+// Example:
+//
+//	var client = retailcrm.New("https://demo.url", "09jIJ")
+//
+//	data, status, err := client.TelephonyCallsUpload([]retailcrm.TelephonyCall{
+//		{
+//			Date:       "2024-01-10 12:30:00",
+//			Type:       retailcrm.CallTypeIn,
+//			Phone:      "+79991234567",
+//			Code:       "101",
+//			Result:     "answered",
+//			Duration:   65,
+//			ExternalID: "call-1",
+//		},
+//	})
+//
+//	if err != nil {
+//		if apiErr, ok := retailcrm.AsAPIError(err); ok {
+//			log.Fatalf("http status: %d, %s", status, apiErr.String())
+//		}
+//
+//		log.Fatalf("http status: %d, error: %s", status, err)
+//	}
+//
+//	if data.Success == true {
+//		log.Printf("processed: %d, duplicates: %v\n", data.ProcessedCallsCount, data.DuplicateCalls)
+//	}
+func (c *Client) TelephonyCallsUpload(calls []TelephonyCall) (TelephonyCallsUploadResponse, int, error) {
+	return c.TelephonyCallsUploadCtx(c.defaultContext(), calls)
+}
+
+// TelephonyCallsUploadCtx is the same as TelephonyCallsUpload, but uses the provided context.Context.
+func (c *Client) TelephonyCallsUploadCtx(
+	ctx context.Context, calls []TelephonyCall,
+) (TelephonyCallsUploadResponse, int, error) {
+	ctx = withAPIMethod(ctx, "TelephonyCallsUpload")
+
+	var resp TelephonyCallsUploadResponse
+
+	callsJSON, err := json.Marshal(&calls)
+	if err != nil {
+		return resp, 0, err
+	}
+
+	p := url.Values{
+		"calls": {string(callsJSON)},
+	}
+
+	data, status, err := c.PostRequestCtx(ctx, "/telephony/calls/upload", p)
+	if err != nil {
+		return resp, status, err
+	}
+
+	err = json.Unmarshal(data, &resp)
+	if err != nil {
+		return resp, status, err
+	}
+
+	return resp, status, nil
+}
+
+// TelephonyManager returns the manager who is responsible for the phone number
+//
+// For more information see http://www.simla.com/docs/Developers/API/APIVersions/APIv5#get--api-v5-telephony-manager
+//
+// Example:
+//
+//	var client = retailcrm.New("https://demo.url", "09jIJ")
+//
+//	data, status, err := client.TelephonyManager(retailcrm.TelephonyManagerRequest{
+//		Phone:   "+79991234567",
+//		Details: true,
+//	})
+//
+//	if err != nil {
+//		if apiErr, ok := retailcrm.AsAPIError(err); ok {
+//			log.Fatalf("http status: %d, %s", status, apiErr.String())
+//		}
+//
+//		log.Fatalf("http status: %d, error: %s", status, err)
+//	}
+//
+//	if data.Manager != nil {
+//		log.Printf("%v\n", data.Manager.Code)
+//	}
+func (c *Client) TelephonyManager(parameters TelephonyManagerRequest) (TelephonyManagerResponse, int, error) {
+	return c.TelephonyManagerCtx(c.defaultContext(), parameters)
+}
+
+// TelephonyManagerCtx is the same as TelephonyManager, but uses the provided context.Context.
+func (c *Client) TelephonyManagerCtx(
+	ctx context.Context, parameters TelephonyManagerRequest,
+) (TelephonyManagerResponse, int, error) {
+	ctx = withAPIMethod(ctx, "TelephonyManager")
+
+	var resp TelephonyManagerResponse
+
+	params, _ := query.Values(parameters)
+
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/telephony/manager?%s", params.Encode()))
+	if err != nil {
+		return resp, status, err
+	}
+
+	err = json.Unmarshal(data, &resp)
+	if err != nil {
+		return resp, status, err
+	}
+
+	return resp, status, nil
+}
+
+// TelephonySetting returns the telephony integration settings
+//
+// For more information see http://www.simla.com/docs/Developers/API/APIVersions/APIv5#get--api-v5-telephony-setting-code
+//
+// Example:
+//
+//	var client = retailcrm.New("https://demo.url", "09jIJ")
+//
+//	data, status, err := client.TelephonySetting("pbx")
+//
+//	if err != nil {
+//		if apiErr, ok := retailcrm.AsAPIError(err); ok {
+//			log.Fatalf("http status: %d, %s", status, apiErr.String())
+//		}
+//
+//		log.Fatalf("http status: %d, error: %s", status, err)
+//	}
+//
+//	if data.Configuration != nil {
+//		log.Printf("%v\n", data.Configuration.AdditionalCodes)
+//	}
+func (c *Client) TelephonySetting(code string) (TelephonySettingResponse, int, error) {
+	return c.TelephonySettingCtx(c.defaultContext(), code)
+}
+
+// TelephonySettingCtx is the same as TelephonySetting, but uses the provided context.Context.
+func (c *Client) TelephonySettingCtx(ctx context.Context, code string) (TelephonySettingResponse, int, error) {
+	ctx = withAPIMethod(ctx, "TelephonySetting")
+
+	var resp TelephonySettingResponse
+
+	data, status, err := c.GetRequestCtx(ctx, fmt.Sprintf("/telephony/setting/%s", code))
+	if err != nil {
+		return resp, status, err
+	}
+
+	err = json.Unmarshal(data, &resp)
+	if err != nil {
+		return resp, status, err
+	}
+
+	return resp, status, nil
+}
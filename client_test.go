@@ -2,6 +2,7 @@ package retailcrm
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -248,6 +249,84 @@ func TestPostRequest(t *testing.T) {
 	}
 }
 
+func TestClient_OrdersCtx(t *testing.T) {
+	t.Run("Request is sent with the provided context", func(t *testing.T) {
+		c := client()
+
+		defer gock.OffAll()
+
+		gock.New(crmURL).
+			Get("/api/v5/orders").
+			Reply(200).
+			BodyString(`{"success": true, "orders": [{"id": 1}]}`)
+
+		data, status, err := c.OrdersCtx(context.Background(), OrdersRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.True(t, data.Success)
+		assert.Len(t, data.Orders, 1)
+	})
+
+	t.Run("Canceled context stops the rate limiter", func(t *testing.T) {
+		c := client()
+		c.EnableRateLimiter(3)
+
+		defer gock.OffAll()
+
+		gock.New(crmURL).
+			Get("/api/v5/orders").
+			Reply(200).
+			BodyString(`{"success": true}`)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, _, err := c.OrdersCtx(ctx, OrdersRequest{})
+		require.ErrorIs(t, err, context.Canceled)
+		assert.False(t, gock.IsDone(), "Request must not be sent")
+	})
+
+	t.Run("Deadline interrupts the delay between attempts", func(t *testing.T) {
+		c := client()
+		c.EnableRateLimiter(0)
+
+		defer gock.OffAll()
+
+		gock.New(crmURL).
+			Post("/api/v5/orders/create").
+			Persist().
+			Reply(503).
+			BodyString(`{"success": false, "errorMsg": "Rate limit exceeded"}`)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		_, status, err := c.OrderCreateCtx(ctx, Order{ExternalID: "ext-1"})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, http.StatusServiceUnavailable, status)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+}
+
+func TestClient_WithContextIsUsedByDefault(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	c := client().WithContext(ctx)
+	c.EnableRateLimiter(1)
+
+	defer gock.OffAll()
+
+	gock.New(crmURL).
+		Get("/api/v5/orders").
+		Reply(200).
+		BodyString(`{"success": true}`)
+
+	_, _, err := c.Orders(OrdersRequest{})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
 func TestClient_ApiVersionsVersions(t *testing.T) {
 	c := client()
 
@@ -3258,6 +3337,11 @@ func TestClient_OrdersUpload_Fail(t *testing.T) {
 	assert.Equal(t, fmt.Sprintf("%d", iCodeFail), data.FailedOrders[0].ExternalID)
 	assert.Equal(t, "Orders are loaded with errors", err.Error())
 	assert.Equal(t, "items[0].offer.id: Offer with id 123123 not found.", err.(APIError).Errors()["0"]) //nolint:errorlint
+
+	var partial *PartialUploadError
+	require.ErrorAs(t, err, &partial)
+	assert.Equal(t, []string{fmt.Sprintf("%d", iCodeFail)}, partial.FailedExternalIDs())
+	assert.Equal(t, "items[0].offer.id: Offer with id 123123 not found.", partial.Failed[0].Reason)
 }
 
 func TestClient_OrdersCombine(t *testing.T) {
@@ -9017,3 +9101,129 @@ func TestClient_StoreOffers(t *testing.T) {
 	assert.Equal(t, float32(10000), resp.Offers[0].Prices[0].Price)
 	assert.Equal(t, "RUB", resp.Offers[0].Prices[0].Currency)
 }
+
+func TestClient_TelephonyCallEvent(t *testing.T) {
+	c := client()
+
+	defer gock.Off()
+
+	event := CallEvent{
+		Phone:          "+79991234567",
+		Type:           CallTypeIn,
+		Codes:          []string{"101"},
+		CallExternalID: "call-1",
+	}
+
+	jr, _ := json.Marshal(&event)
+	p := url.Values{
+		"event": {string(jr)},
+	}
+
+	gock.New(crmURL).
+		Post(prefix + "/telephony/call/event").
+		MatchType("url").
+		BodyString(p.Encode()).
+		Reply(http.StatusOK).
+		BodyString(`{"success": true}`)
+
+	data, status, err := c.TelephonyCallEvent(event)
+	require.NoError(t, err)
+	assert.True(t, statuses[status])
+	assert.True(t, data.Success)
+}
+
+func TestClient_TelephonyCallsUpload(t *testing.T) {
+	c := client()
+
+	defer gock.Off()
+
+	calls := []TelephonyCall{
+		{Date: "2024-01-10 12:30:00", Type: CallTypeIn, Phone: "+79991234567", Result: "answered", ExternalID: "call-1"},
+		{Date: "2024-01-10 12:35:00", Type: CallTypeOut, Phone: "+79997654321", UserID: 1, ExternalID: "call-2"},
+	}
+
+	jr, _ := json.Marshal(&calls)
+	p := url.Values{
+		"calls": {string(jr)},
+	}
+
+	gock.New(crmURL).
+		Post(prefix + "/telephony/calls/upload").
+		MatchType("url").
+		BodyString(p.Encode()).
+		Reply(http.StatusOK).
+		BodyString(`{"success": true, "processedCallsCount": 1, "duplicateCalls": ["call-2"]}`)
+
+	data, status, err := c.TelephonyCallsUpload(calls)
+	require.NoError(t, err)
+	assert.True(t, statuses[status])
+	assert.Equal(t, 1, data.ProcessedCallsCount)
+	assert.Equal(t, []string{"call-2"}, data.DuplicateCalls)
+}
+
+func TestClient_TelephonyManager(t *testing.T) {
+	c := client()
+
+	defer gock.Off()
+
+	gock.New(crmURL).
+		Get(prefix+"/telephony/manager").
+		MatchParam("phone", `^\+79991234567$`).
+		MatchParam("details", "1").
+		Reply(http.StatusOK).
+		BodyString(`{
+			"success": true,
+			"manager": {"id": 5, "firstName": "John", "lastName": "Doe", "code": "101"},
+			"customer": {"id": 10, "firstName": "Jane"},
+			"links": {"newOrderLink": "https://demo.url/orders/add", "customerLink": "https://demo.url/customers/10"}
+		}`)
+
+	data, status, err := c.TelephonyManager(TelephonyManagerRequest{Phone: "+79991234567", Details: true})
+	require.NoError(t, err)
+	assert.True(t, statuses[status])
+	require.NotNil(t, data.Manager)
+	assert.Equal(t, 5, data.Manager.ID)
+	assert.Equal(t, "101", data.Manager.Code)
+	require.NotNil(t, data.Customer)
+	assert.Equal(t, 10, data.Customer.ID)
+	require.NotNil(t, data.Links)
+	assert.Equal(t, "https://demo.url/customers/10", data.Links.CustomerLink)
+}
+
+func TestClient_TelephonySetting(t *testing.T) {
+	c := client()
+
+	defer gock.Off()
+
+	gock.New(crmURL).
+		Get(prefix + "/telephony/setting/pbx").
+		Reply(http.StatusOK).
+		BodyString(`{
+			"success": true,
+			"configuration": {
+				"clientId": "client",
+				"code": "pbx",
+				"active": true,
+				"makeCallUrl": "https://pbx.example.com/call",
+				"additionalCodes": [{"code": "101", "userId": "1"}],
+				"externalPhones": [{"siteCode": "site", "externalPhone": "+79990000000"}]
+			}
+		}`)
+
+	gock.New(crmURL).
+		Get(prefix + "/telephony/setting/unknown").
+		Reply(http.StatusNotFound).
+		BodyString(`{"success": false, "errorMsg": "Not found"}`)
+
+	data, status, err := c.TelephonySetting("pbx")
+	require.NoError(t, err)
+	assert.True(t, statuses[status])
+	require.NotNil(t, data.Configuration)
+	assert.True(t, data.Configuration.Active)
+	assert.Equal(t, "https://pbx.example.com/call", data.Configuration.MakeCallURL)
+	assert.Equal(t, []AdditionalCode{{Code: "101", UserID: "1"}}, data.Configuration.AdditionalCodes)
+
+	_, status, err = c.TelephonySetting("unknown")
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, status)
+}
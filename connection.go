@@ -0,0 +1,277 @@
+package retailcrm
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sync"
+)
+
+// ErrInvalidClientID will be returned if the clientId of the integration callback is unknown.
+var ErrInvalidClientID = errors.New("invalid clientId")
+
+// ClientIDChecker reports whether the clientId of the integration callback belongs to the connected account.
+type ClientIDChecker func(ctx context.Context, clientID string) (bool, error)
+
+// ConnectionAccount contains credentials of the account which was connected to the module.
+type ConnectionAccount struct {
+	URL      string
+	APIKey   string
+	ClientID string
+}
+
+// ConnectionStore persists credentials of the connected accounts.
+type ConnectionStore interface {
+	// Save stores the account credentials. The account with the same URL must be replaced.
+	Save(ctx context.Context, account ConnectionAccount) error
+	// AccountByClientID returns the account which was connected with the provided clientId.
+	AccountByClientID(ctx context.Context, clientID string) (ConnectionAccount, bool, error)
+}
+
+// MemoryConnectionStore keeps the connected accounts in memory.
+type MemoryConnectionStore struct {
+	accounts  map[string]ConnectionAccount
+	clientIDs map[string]string
+	mutex     sync.RWMutex
+}
+
+// NewMemoryConnectionStore instantiates new MemoryConnectionStore.
+func NewMemoryConnectionStore() *MemoryConnectionStore {
+	return &MemoryConnectionStore{accounts: map[string]ConnectionAccount{}, clientIDs: map[string]string{}}
+}
+
+// Save stores the account credentials.
+func (s *MemoryConnectionStore) Save(_ context.Context, account ConnectionAccount) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if previous, ok := s.accounts[account.URL]; ok && s.clientIDs[previous.ClientID] == account.URL {
+		delete(s.clientIDs, previous.ClientID)
+	}
+
+	s.accounts[account.URL] = account
+	if account.ClientID != "" {
+		s.clientIDs[account.ClientID] = account.URL
+	}
+
+	return nil
+}
+
+// Account returns credentials of the account with the provided URL.
+func (s *MemoryConnectionStore) Account(url string) (ConnectionAccount, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	account, ok := s.accounts[url]
+	return account, ok
+}
+
+// AccountByClientID returns credentials of the account with the provided clientId.
+func (s *MemoryConnectionStore) AccountByClientID(_ context.Context, clientID string) (ConnectionAccount, bool, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	accountURL, ok := s.clientIDs[clientID]
+	if !ok {
+		return ConnectionAccount{}, false, nil
+	}
+
+	return s.accounts[accountURL], true, nil
+}
+
+// StoreClientIDChecker returns ClientIDChecker which accepts the clientId of every account in the ConnectionStore.
+// It is used to check the integration callbacks when every account has its own clientId, e.g. when ConnectionHandler
+// generates them.
+//
+// Example:
+//
+//	store := retailcrm.NewMemoryConnectionStore()
+//	checker := retailcrm.StoreClientIDChecker(store)
+//
+//	http.Handle("/connect", retailcrm.NewConnectionHandler(config, store))
+//	http.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
+//		if ok, err := checker(r.Context(), r.FormValue("clientId")); err != nil || !ok {
+//			http.Error(w, "invalid clientId", http.StatusForbidden)
+//			return
+//		}
+//	})
+func StoreClientIDChecker(store ConnectionStore) ClientIDChecker {
+	return func(ctx context.Context, clientID string) (bool, error) {
+		if clientID == "" {
+			return false, nil
+		}
+
+		_, ok, err := store.AccountByClientID(ctx, clientID)
+		return ok, err
+	}
+}
+
+// ConnectionConfig contains configuration of the module for the one-step connection.
+type ConnectionConfig struct {
+	// Secret is used to verify ConnectRequest.
+	Secret string
+	// Scopes which are requested for the API key.
+	Scopes []string
+	// RegisterURL is the URL of the ConnectionHandler which accepts POST requests.
+	RegisterURL string
+	// Module is sent to IntegrationModuleEdit after the connection. It is activated automatically and random ClientID
+	// is generated for every account if it is empty. Module.AccountURL is returned in ConnectResponse.
+	Module IntegrationModule
+}
+
+// ConnectionHandler is the http.Handler for the one-step connection of the module. It responds to GET requests with
+// ConnectionConfigResponse. POST requests must contain ConnectRequest in the "register" form field or in the JSON
+// body. The request is verified, the module is activated in the account using IntegrationModuleEdit and
+// the credentials are saved to the ConnectionStore.
+//
+// Example:
+//
+//	handler := retailcrm.NewConnectionHandler(retailcrm.ConnectionConfig{
+//		Secret:      "secret",
+//		Scopes:      []string{"order_read", "order_write"},
+//		RegisterURL: "https://module.example.com/connect",
+//		Module: retailcrm.IntegrationModule{
+//			Code:            "module-code",
+//			IntegrationCode: "module-code",
+//			Name:            "Module",
+//			BaseURL:         "https://module.example.com",
+//			AccountURL:      "https://module.example.com/settings",
+//		},
+//	}, store)
+//
+//	http.Handle("/connect", handler)
+type ConnectionHandler struct {
+	config    ConnectionConfig
+	store     ConnectionStore
+	newClient func(url, apiKey string) *Client
+}
+
+// NewConnectionHandler instantiates new ConnectionHandler.
+func NewConnectionHandler(config ConnectionConfig, store ConnectionStore) *ConnectionHandler {
+	return &ConnectionHandler{config: config, store: store, newClient: New}
+}
+
+// WithClientFactory sets the function which creates the Client for the connected account, e.g. to configure
+// the rate limiter or the logger.
+func (h *ConnectionHandler) WithClientFactory(factory func(url, apiKey string) *Client) *ConnectionHandler {
+	h.newClient = factory
+	return h
+}
+
+// ServeHTTP serves the connection configuration and the connection requests.
+func (h *ConnectionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		writeJSONResponse(w, http.StatusOK, ConnectionConfigResponse{
+			SuccessfulResponse: SuccessfulResponse{Success: true},
+			Scopes:             h.config.Scopes,
+			RegisterURL:        h.config.RegisterURL,
+		})
+	case http.MethodPost:
+		h.connect(w, r)
+	default:
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (h *ConnectionHandler) connect(w http.ResponseWriter, r *http.Request) {
+	req, err := parseConnectRequest(r)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !req.Verify(h.config.Secret) {
+		writeErrorResponse(w, http.StatusForbidden, "invalid token")
+		return
+	}
+
+	account := ConnectionAccount{URL: req.SystemURL(), APIKey: req.APIKey, ClientID: h.config.Module.ClientID}
+	if account.ClientID == "" {
+		if account.ClientID, err = randomClientID(); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
+	active := true
+	module := h.config.Module
+	module.Active = &active
+	module.ClientID = account.ClientID
+
+	if _, _, err := h.newClient(account.URL, account.APIKey).IntegrationModuleEditCtx(r.Context(), module); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("cannot activate module: %s", err))
+		return
+	}
+
+	if err := h.store.Save(r.Context(), account); err != nil {
+		writeErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("cannot save account: %s", err))
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, NewConnectResponse(module.AccountURL))
+}
+
+// parseConnectRequest reads ConnectRequest from the "register" form field or from the JSON body.
+func parseConnectRequest(r *http.Request) (ConnectRequest, error) {
+	var req ConnectRequest
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "application/json" {
+		body, err := io.ReadAll(io.LimitReader(r.Body, DefaultWebhookMaxBodySize))
+		if err != nil {
+			return req, err
+		}
+
+		if err := json.Unmarshal(body, &req); err != nil {
+			return req, fmt.Errorf("invalid connection request: %w", err)
+		}
+	} else if err := json.Unmarshal([]byte(r.PostFormValue("register")), &req); err != nil {
+		return req, fmt.Errorf("invalid connection request: %w", err)
+	}
+
+	if req.APIKey == "" || req.SystemURL() == "" {
+		return req, errors.New("apiKey and systemUrl are required")
+	}
+
+	return req, nil
+}
+
+func randomClientID() (string, error) {
+	data := make([]byte, 16) // nolint:gomnd
+	if _, err := rand.Read(data); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(data), nil
+}
+
+// staticClientID returns ClientIDChecker which accepts only the provided non-empty clientId.
+func staticClientID(clientID string) ClientIDChecker {
+	return func(_ context.Context, actual string) (bool, error) {
+		return clientID != "" && subtle.ConstantTimeCompare([]byte(clientID), []byte(actual)) == 1, nil
+	}
+}
+
+// checkClientID responds with an error and returns false if the clientId is not accepted by the checker.
+func checkClientID(w http.ResponseWriter, r *http.Request, checker ClientIDChecker, clientID string) bool {
+	ok, err := checker(r.Context(), clientID)
+	if err != nil {
+		writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return false
+	}
+
+	if !ok {
+		writeErrorResponse(w, http.StatusForbidden, ErrInvalidClientID.Error())
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,205 @@
+package retailcrm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gock "gopkg.in/h2non/gock.v1"
+)
+
+const connectionAccountURL = "https://connect.retailcrm.pro"
+
+type failingConnectionStore struct{}
+
+func (failingConnectionStore) Save(context.Context, ConnectionAccount) error {
+	return errors.New("storage is unavailable")
+}
+
+func (failingConnectionStore) AccountByClientID(context.Context, string) (ConnectionAccount, bool, error) {
+	return ConnectionAccount{}, false, errors.New("storage is unavailable")
+}
+
+func connectionConfig() ConnectionConfig {
+	return ConnectionConfig{
+		Secret:      "secret",
+		Scopes:      []string{"order_read", "order_write"},
+		RegisterURL: "https://module.example.com/connect",
+		Module: IntegrationModule{
+			Code:            "module",
+			IntegrationCode: "module",
+			Name:            "Module",
+			AccountURL:      "https://module.example.com/settings",
+		},
+	}
+}
+
+func connectRequest(apiKey, secret string) *http.Request {
+	register, _ := json.Marshal(ConnectRequest{
+		Token:  createConnectToken(apiKey, secret),
+		APIKey: apiKey,
+		URL:    connectionAccountURL + "/",
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/connect", strings.NewReader(url.Values{
+		"register": {string(register)},
+	}.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return req
+}
+
+func TestConnectionHandler_Config(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewConnectionHandler(connectionConfig(), NewMemoryConnectionStore()).
+		ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/connect", nil))
+
+	var resp ConnectionConfigResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, resp.Success)
+	assert.Equal(t, []string{"order_read", "order_write"}, resp.Scopes)
+	assert.Equal(t, "https://module.example.com/connect", resp.RegisterURL)
+}
+
+func TestConnectionHandler_Connect(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(connectionAccountURL).
+		Post("/integration-modules/module/edit").
+		MatchHeader("X-API-KEY", "key").
+		AddMatcher(func(req *http.Request, _ *gock.Request) (bool, error) {
+			var module IntegrationModule
+			if err := json.Unmarshal([]byte(req.FormValue("integrationModule")), &module); err != nil {
+				return false, err
+			}
+
+			return module.Active != nil && *module.Active && module.ClientID != "", nil
+		}).
+		Reply(http.StatusOK).
+		BodyString(`{"success": true}`)
+
+	store := NewMemoryConnectionStore()
+	rec := httptest.NewRecorder()
+	NewConnectionHandler(connectionConfig(), store).ServeHTTP(rec, connectRequest("key", "secret"))
+
+	var resp ConnectResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, NewConnectResponse("https://module.example.com/settings"), resp)
+	assert.True(t, gock.IsDone())
+
+	account, ok := store.Account(connectionAccountURL)
+	require.True(t, ok)
+	assert.Equal(t, "key", account.APIKey)
+	assert.Len(t, account.ClientID, 32)
+}
+
+func TestConnectionHandler_InvalidToken(t *testing.T) {
+	store := NewMemoryConnectionStore()
+	rec := httptest.NewRecorder()
+	NewConnectionHandler(connectionConfig(), store).ServeHTTP(rec, connectRequest("key", "wrong"))
+
+	var resp ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.False(t, resp.Success)
+	assert.Equal(t, "invalid token", resp.ErrorMessage)
+
+	_, ok := store.Account(connectionAccountURL)
+	assert.False(t, ok)
+}
+
+func TestConnectionHandler_Errors(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(connectionAccountURL).
+		Post("/integration-modules/module/edit").
+		Reply(http.StatusForbidden).
+		BodyString(`{"success": false, "errorMsg": "Access denied."}`)
+	gock.New(connectionAccountURL).
+		Post("/integration-modules/module/edit").
+		Reply(http.StatusOK).
+		BodyString(`{"success": true}`)
+
+	store := NewMemoryConnectionStore()
+	rec := httptest.NewRecorder()
+	NewConnectionHandler(connectionConfig(), store).ServeHTTP(rec, connectRequest("key", "secret"))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "cannot activate module")
+	_, ok := store.Account(connectionAccountURL)
+	assert.False(t, ok)
+
+	rec = httptest.NewRecorder()
+	NewConnectionHandler(connectionConfig(), failingConnectionStore{}).ServeHTTP(rec, connectRequest("key", "secret"))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), "storage is unavailable")
+
+	req := httptest.NewRequest(http.MethodPost, "/connect", strings.NewReader(`{"token": "t"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	NewConnectionHandler(connectionConfig(), store).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestMemoryConnectionStore_AccountByClientID(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryConnectionStore()
+	checker := StoreClientIDChecker(store)
+
+	require.NoError(t, store.Save(ctx, ConnectionAccount{URL: connectionAccountURL, APIKey: "key", ClientID: "first"}))
+
+	account, ok, err := store.AccountByClientID(ctx, "first")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "key", account.APIKey)
+
+	require.NoError(t, store.Save(ctx, ConnectionAccount{URL: connectionAccountURL, APIKey: "new", ClientID: "second"}))
+
+	_, ok, _ = store.AccountByClientID(ctx, "first")
+	assert.False(t, ok, "replaced clientId must not be accepted")
+
+	ok, err = checker(ctx, "second")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, _ = checker(ctx, "")
+	assert.False(t, ok)
+
+	_, err = StoreClientIDChecker(failingConnectionStore{})(ctx, "second")
+	assert.Error(t, err)
+}
+
+func TestConnectionHandler_ConnectedClientID(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(connectionAccountURL).
+		Post("/integration-modules/module/edit").
+		Reply(http.StatusOK).
+		BodyString(`{"success": true}`)
+
+	store := NewMemoryConnectionStore()
+	rec := httptest.NewRecorder()
+	NewConnectionHandler(connectionConfig(), store).ServeHTTP(rec, connectRequest("key", "secret"))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	account, ok := store.Account(connectionAccountURL)
+	require.True(t, ok)
+
+	ok, err := StoreClientIDChecker(store)(context.Background(), account.ClientID)
+	require.NoError(t, err)
+	assert.True(t, ok, "generated clientId must be accepted by the integration handlers")
+}
@@ -0,0 +1,384 @@
+package retailcrm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Delivery actions which are used as keys of Delivery.Actions.
+const (
+	DeliveryActionCalculate      = "calculate"
+	DeliveryActionSave           = "save"
+	DeliveryActionGet            = "get"
+	DeliveryActionDelete         = "delete"
+	DeliveryActionPrint          = "print"
+	DeliveryActionShipmentSave   = "shipmentSave"
+	DeliveryActionShipmentDelete = "shipmentDelete"
+)
+
+// ErrDeliveryActionNotSupported will be returned if the DeliveryModule doesn't implement the action.
+var ErrDeliveryActionNotSupported = errors.New("delivery action is not supported")
+
+// DeliveryPackageItem type.
+type DeliveryPackageItem struct {
+	OfferID       int        `json:"offerId,omitempty"`
+	Name          string     `json:"name,omitempty"`
+	DeclaredValue float64    `json:"declaredValue,omitempty"`
+	Cod           float64    `json:"cod,omitempty"`
+	VatRate       string     `json:"vatRate,omitempty"`
+	Quantity      float64    `json:"quantity,omitempty"`
+	Unit          string     `json:"unit,omitempty"`
+	Properties    Properties `json:"properties,omitempty"`
+}
+
+// DeliveryPackage type.
+type DeliveryPackage struct {
+	PackageID string                `json:"packageId,omitempty"`
+	Weight    float64               `json:"weight,omitempty"`
+	Width     int                   `json:"width,omitempty"`
+	Length    int                   `json:"length,omitempty"`
+	Height    int                   `json:"height,omitempty"`
+	Items     []DeliveryPackageItem `json:"items,omitempty"`
+}
+
+// DeliveryCalculateRequest is sent to the "calculate" action to get the available tariffs.
+type DeliveryCalculateRequest struct {
+	ShipmentAddress *Address          `json:"shipmentAddress,omitempty"`
+	DeliveryAddress *Address          `json:"deliveryAddress,omitempty"`
+	Packages        []DeliveryPackage `json:"packages,omitempty"`
+	DeclaredValue   float64           `json:"declaredValue,omitempty"`
+	Cod             float64           `json:"cod,omitempty"`
+	PayerType       string            `json:"payerType,omitempty"`
+	ShippingDate    string            `json:"shippingDate,omitempty"`
+	DeliveryDate    string            `json:"deliveryDate,omitempty"`
+	DeliveryTime    *DeliveryTime     `json:"deliveryTime,omitempty"`
+	Currency        string            `json:"currency,omitempty"`
+	ExtraData       StringMap         `json:"extraData,omitempty"`
+}
+
+// DeliveryCalculation is the tariff which is returned by the "calculate" action.
+type DeliveryCalculation struct {
+	Code               string        `json:"code"`
+	Group              string        `json:"group,omitempty"`
+	Name               string        `json:"name"`
+	Type               string        `json:"type,omitempty"`
+	Description        string        `json:"description,omitempty"`
+	Cost               float64       `json:"cost"`
+	MinTerm            int           `json:"minTerm,omitempty"`
+	MaxTerm            int           `json:"maxTerm,omitempty"`
+	DeliveryDate       string        `json:"deliveryDate,omitempty"`
+	DeliveryTime       *DeliveryTime `json:"deliveryTime,omitempty"`
+	PickuppointAddress string        `json:"pickuppointAddress,omitempty"`
+	ExtraData          StringMap     `json:"extraData,omitempty"`
+}
+
+// DeliveryContact contains the customer or the manager data of DeliverySaveRequest.
+type DeliveryContact struct {
+	ID         int      `json:"id,omitempty"`
+	FirstName  string   `json:"firstName,omitempty"`
+	LastName   string   `json:"lastName,omitempty"`
+	Patronymic string   `json:"patronymic,omitempty"`
+	Phones     []string `json:"phones,omitempty"`
+	Email      string   `json:"email,omitempty"`
+}
+
+// DeliverySaveDetails contains the delivery data of DeliverySaveRequest.
+type DeliverySaveDetails struct {
+	ShipmentAddress *Address      `json:"shipmentAddress,omitempty"`
+	DeliveryAddress *Address      `json:"deliveryAddress,omitempty"`
+	WithCod         bool          `json:"withCod,omitempty"`
+	Cod             float64       `json:"cod,omitempty"`
+	Cost            float64       `json:"cost,omitempty"`
+	Tariff          string        `json:"tariff,omitempty"`
+	ShipmentDate    string        `json:"shipmentDate,omitempty"`
+	DeliveryDate    string        `json:"deliveryDate,omitempty"`
+	DeliveryTime    *DeliveryTime `json:"deliveryTime,omitempty"`
+	ExtraData       StringMap     `json:"extraData,omitempty"`
+}
+
+// DeliverySaveRequest is sent to the "save" action to create the delivery or to edit it if DeliveryID is not empty.
+type DeliverySaveRequest struct {
+	DeliveryID  string              `json:"deliveryId,omitempty"`
+	Order       string              `json:"order,omitempty"`
+	OrderNumber string              `json:"orderNumber,omitempty"`
+	Site        string              `json:"site,omitempty"`
+	SiteName    string              `json:"siteName,omitempty"`
+	PayerType   string              `json:"payerType,omitempty"`
+	Customer    *DeliveryContact    `json:"customer,omitempty"`
+	Manager     *DeliveryContact    `json:"manager,omitempty"`
+	Packages    []DeliveryPackage   `json:"packages,omitempty"`
+	Delivery    DeliverySaveDetails `json:"delivery"`
+	Currency    string              `json:"currency,omitempty"`
+}
+
+// DeliverySaveResult is returned by the "save" action.
+type DeliverySaveResult struct {
+	DeliveryID  string    `json:"deliveryId"`
+	TrackNumber string    `json:"trackNumber,omitempty"`
+	Cost        float64   `json:"cost,omitempty"`
+	Status      string    `json:"status,omitempty"`
+	ExtraData   StringMap `json:"extraData,omitempty"`
+}
+
+// DeliveryInfo is returned by the "get" action.
+type DeliveryInfo struct {
+	DeliveryID   string                  `json:"deliveryId"`
+	TrackNumber  string                  `json:"trackNumber,omitempty"`
+	Cost         float64                 `json:"cost,omitempty"`
+	Status       string                  `json:"status,omitempty"`
+	ShipmentDate string                  `json:"shipmentDate,omitempty"`
+	History      []DeliveryHistoryRecord `json:"history,omitempty"`
+	ExtraData    StringMap               `json:"extraData,omitempty"`
+}
+
+// DeliveryDeleteRequest is sent to the "delete" action.
+type DeliveryDeleteRequest struct {
+	DeliveryID string    `json:"deliveryId"`
+	ExtraData  StringMap `json:"extraData,omitempty"`
+}
+
+// DeliveryPrintRequest is sent to the "print" action. Type is the code of the Plate.
+type DeliveryPrintRequest struct {
+	Type        string   `json:"type"`
+	DeliveryIDs []string `json:"deliveryIds"`
+}
+
+// DeliveryPrintFile is returned by the "print" action. PDF is assumed if ContentType is empty.
+type DeliveryPrintFile struct {
+	ContentType string
+	Data        []byte
+}
+
+// DeliveryShipmentOrder type.
+type DeliveryShipmentOrder struct {
+	DeliveryID string `json:"deliveryId"`
+}
+
+// DeliveryShipmentSaveRequest is sent to the "shipmentSave" action to create or edit the shipment.
+type DeliveryShipmentSaveRequest struct {
+	ShipmentID string                  `json:"shipmentId,omitempty"`
+	ManagerID  int                     `json:"managerId,omitempty"`
+	Date       string                  `json:"date,omitempty"`
+	Time       *DeliveryTime           `json:"time,omitempty"`
+	Address    *Address                `json:"address,omitempty"`
+	Orders     []DeliveryShipmentOrder `json:"orders,omitempty"`
+	Comment    string                  `json:"comment,omitempty"`
+	ExtraData  StringMap               `json:"extraData,omitempty"`
+}
+
+// DeliveryShipmentSaveResult is returned by the "shipmentSave" action.
+type DeliveryShipmentSaveResult struct {
+	ShipmentID string    `json:"shipmentId"`
+	ExtraData  StringMap `json:"extraData,omitempty"`
+}
+
+// DeliveryShipmentDeleteRequest is sent to the "shipmentDelete" action.
+type DeliveryShipmentDeleteRequest struct {
+	ShipmentID string    `json:"shipmentId"`
+	ExtraData  StringMap `json:"extraData,omitempty"`
+}
+
+// DeliveryModule is implemented by the delivery integration module.
+type DeliveryModule interface {
+	// Calculate returns the tariffs which are available for the delivery.
+	Calculate(ctx context.Context, req DeliveryCalculateRequest) ([]DeliveryCalculation, error)
+	// Save creates the delivery in the delivery service or edits it.
+	Save(ctx context.Context, req DeliverySaveRequest) (DeliverySaveResult, error)
+	// Get returns the current state of the delivery.
+	Get(ctx context.Context, deliveryID string) (DeliveryInfo, error)
+	// Delete cancels the delivery.
+	Delete(ctx context.Context, req DeliveryDeleteRequest) error
+	// Print returns the printed form of the deliveries, e.g. the labels.
+	Print(ctx context.Context, req DeliveryPrintRequest) (DeliveryPrintFile, error)
+}
+
+// DeliveryShipmentModule can be implemented by the DeliveryModule which supports shipments.
+type DeliveryShipmentModule interface {
+	// ShipmentSave creates the shipment of the deliveries or edits it.
+	ShipmentSave(ctx context.Context, req DeliveryShipmentSaveRequest) (DeliveryShipmentSaveResult, error)
+	// ShipmentDelete cancels the shipment.
+	ShipmentDelete(ctx context.Context, req DeliveryShipmentDeleteRequest) error
+}
+
+// DeliveryRouter is the http.Handler which serves the callbacks of the delivery integration module. Request paths
+// are matched with Delivery.Actions, so the same Delivery must be sent in IntegrationModuleEdit. Use http.StripPrefix
+// if the router isn't mounted at the root of the module BaseURL.
+//
+// Payloads are decoded from the form field with the action name, e.g. "calculate", and the result is responded
+// as {"success": true, "result": ...}. The "print" action responds with the file. Errors of the DeliveryModule are
+// responded with 500 status code and the error message in "errorMsg".
+//
+// Example:
+//
+//	delivery := retailcrm.Delivery{
+//		Actions: retailcrm.StringMap{
+//			retailcrm.DeliveryActionCalculate: "/calculate",
+//			retailcrm.DeliveryActionSave:      "/save",
+//			retailcrm.DeliveryActionGet:       "/get",
+//			retailcrm.DeliveryActionDelete:    "/delete",
+//			retailcrm.DeliveryActionPrint:     "/print",
+//		},
+//		PlateList: []retailcrm.Plate{{Code: "label", Label: "Label"}},
+//	}
+//
+//	http.Handle("/delivery/", http.StripPrefix("/delivery", retailcrm.NewDeliveryRouter("client-id", delivery, module)))
+type DeliveryRouter struct {
+	actions map[string]string
+	module  DeliveryModule
+	checker ClientIDChecker
+}
+
+// NewDeliveryRouter instantiates new DeliveryRouter which accepts callbacks with the provided clientId.
+func NewDeliveryRouter(clientID string, delivery Delivery, module DeliveryModule) *DeliveryRouter {
+	actions := make(map[string]string, len(delivery.Actions))
+	for action, path := range delivery.Actions {
+		actions[normalizeActionPath(path)] = action
+	}
+
+	return &DeliveryRouter{
+		actions: actions,
+		module:  module,
+		checker: staticClientID(clientID),
+	}
+}
+
+// WithClientIDChecker replaces the clientId check, e.g. with StoreClientIDChecker to accept callbacks from all
+// the accounts in the ConnectionStore.
+func (d *DeliveryRouter) WithClientIDChecker(checker ClientIDChecker) *DeliveryRouter {
+	d.checker = checker
+	return d
+}
+
+// ServeHTTP routes the request to the DeliveryModule.
+func (d *DeliveryRouter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	action, ok := d.actions[normalizeActionPath(r.URL.Path)]
+	if !ok {
+		writeErrorResponse(w, http.StatusNotFound, "unknown action")
+		return
+	}
+
+	if !checkClientID(w, r, d.checker, r.FormValue("clientId")) {
+		return
+	}
+
+	result, err := d.dispatch(r, action)
+
+	var invalid *deliveryPayloadError
+
+	switch {
+	case errors.As(err, &invalid):
+		writeErrorResponse(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, ErrDeliveryActionNotSupported):
+		writeErrorResponse(w, http.StatusNotFound, err.Error())
+	case err != nil:
+		writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+	default:
+		writeDeliveryResult(w, result)
+	}
+}
+
+func (d *DeliveryRouter) dispatch(r *http.Request, action string) (interface{}, error) {
+	switch action {
+	case DeliveryActionCalculate:
+		return callDeliveryAction(r, action, d.module.Calculate)
+	case DeliveryActionSave:
+		return callDeliveryAction(r, action, d.module.Save)
+	case DeliveryActionGet:
+		deliveryID := r.FormValue("deliveryId")
+		if deliveryID == "" {
+			return nil, &deliveryPayloadError{action: action, err: errors.New("deliveryId is required")}
+		}
+
+		return d.module.Get(r.Context(), deliveryID)
+	case DeliveryActionDelete:
+		return callDeliveryAction(r, action, noDeliveryResult(d.module.Delete))
+	case DeliveryActionPrint:
+		return callDeliveryAction(r, action, d.module.Print)
+	}
+
+	shipments, ok := d.module.(DeliveryShipmentModule)
+	if !ok {
+		return nil, ErrDeliveryActionNotSupported
+	}
+
+	switch action {
+	case DeliveryActionShipmentSave:
+		return callDeliveryAction(r, action, shipments.ShipmentSave)
+	case DeliveryActionShipmentDelete:
+		return callDeliveryAction(r, action, noDeliveryResult(shipments.ShipmentDelete))
+	}
+
+	return nil, ErrDeliveryActionNotSupported
+}
+
+type deliveryPayloadError struct {
+	action string
+	err    error
+}
+
+func (e *deliveryPayloadError) Error() string {
+	return fmt.Sprintf("invalid %s payload: %s", e.action, e.err)
+}
+
+func (e *deliveryPayloadError) Unwrap() error {
+	return e.err
+}
+
+// callDeliveryAction decodes the JSON payload from the form field with the action name and calls the module.
+func callDeliveryAction[Req, Res any](
+	r *http.Request, action string, call func(context.Context, Req) (Res, error),
+) (interface{}, error) {
+	var req Req
+
+	if err := json.Unmarshal([]byte(r.FormValue(action)), &req); err != nil {
+		return nil, &deliveryPayloadError{action: action, err: err}
+	}
+
+	return call(r.Context(), req)
+}
+
+func noDeliveryResult[Req any](call func(context.Context, Req) error) func(context.Context, Req) (interface{}, error) {
+	return func(ctx context.Context, req Req) (interface{}, error) {
+		return nil, call(ctx, req)
+	}
+}
+
+func writeDeliveryResult(w http.ResponseWriter, result interface{}) {
+	if file, ok := result.(DeliveryPrintFile); ok {
+		if file.ContentType == "" {
+			file.ContentType = "application/pdf"
+		}
+
+		w.Header().Set("Content-Type", file.ContentType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(file.Data)))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(file.Data)
+		return
+	}
+
+	if result == nil {
+		writeJSONResponse(w, http.StatusOK, SuccessfulResponse{Success: true})
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, struct {
+		Success bool        `json:"success"`
+		Result  interface{} `json:"result"`
+	}{Success: true, Result: result})
+}
+
+// normalizeActionPath returns the path of the action URL, which can be either absolute or relative,
+// without the trailing slash.
+func normalizeActionPath(path string) string {
+	if parsed, err := url.Parse(path); err == nil {
+		path = parsed.Path
+	}
+
+	return "/" + strings.Trim(path, "/")
+}
@@ -0,0 +1,147 @@
+package retailcrm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testDeliveryModule struct {
+	deleted []string
+	err     error
+}
+
+func (m *testDeliveryModule) Calculate(_ context.Context, req DeliveryCalculateRequest) ([]DeliveryCalculation, error) {
+	return []DeliveryCalculation{
+		{Code: "courier", Name: "Courier", Cost: 100 + req.DeclaredValue/100, MinTerm: 1, MaxTerm: 2},
+	}, m.err
+}
+
+func (m *testDeliveryModule) Save(_ context.Context, req DeliverySaveRequest) (DeliverySaveResult, error) {
+	return DeliverySaveResult{DeliveryID: "d-" + req.OrderNumber, TrackNumber: "track"}, m.err
+}
+
+func (m *testDeliveryModule) Get(_ context.Context, deliveryID string) (DeliveryInfo, error) {
+	return DeliveryInfo{DeliveryID: deliveryID, Status: "delivered"}, m.err
+}
+
+func (m *testDeliveryModule) Delete(_ context.Context, req DeliveryDeleteRequest) error {
+	m.deleted = append(m.deleted, req.DeliveryID)
+	return m.err
+}
+
+func (m *testDeliveryModule) Print(_ context.Context, req DeliveryPrintRequest) (DeliveryPrintFile, error) {
+	return DeliveryPrintFile{Data: []byte(req.Type + ":" + strings.Join(req.DeliveryIDs, ","))}, m.err
+}
+
+type testDeliveryShipmentModule struct {
+	testDeliveryModule
+}
+
+func (m *testDeliveryShipmentModule) ShipmentSave(
+	_ context.Context, req DeliveryShipmentSaveRequest,
+) (DeliveryShipmentSaveResult, error) {
+	return DeliveryShipmentSaveResult{ShipmentID: "s-" + req.Orders[0].DeliveryID}, m.err
+}
+
+func (m *testDeliveryShipmentModule) ShipmentDelete(context.Context, DeliveryShipmentDeleteRequest) error {
+	return m.err
+}
+
+func testDelivery() Delivery {
+	return Delivery{
+		Actions: StringMap{
+			DeliveryActionCalculate:      "calculate",
+			DeliveryActionSave:           "/save",
+			DeliveryActionGet:            "/get/",
+			DeliveryActionDelete:         "/delete",
+			DeliveryActionPrint:          "/print",
+			DeliveryActionShipmentSave:   "/shipment/save",
+			DeliveryActionShipmentDelete: "/shipment/delete",
+		},
+	}
+}
+
+func deliveryRequest(path, action, payload string) *http.Request {
+	form := url.Values{"clientId": {"client"}, action: {payload}}
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return req
+}
+
+func TestDeliveryRouter_Actions(t *testing.T) {
+	module := &testDeliveryShipmentModule{}
+	router := NewDeliveryRouter("client", testDelivery(), module)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, deliveryRequest("/calculate", DeliveryActionCalculate, `{"declaredValue": 1000}`))
+
+	var calculate struct {
+		Success bool                  `json:"success"`
+		Result  []DeliveryCalculation `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &calculate))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, calculate.Success)
+	assert.Equal(t, float64(110), calculate.Result[0].Cost)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, deliveryRequest("/save", DeliveryActionSave, `{"orderNumber": "10A", "delivery": {"tariff": "courier"}}`))
+	assert.JSONEq(t, `{"success": true, "result": {"deliveryId": "d-10A", "trackNumber": "track"}}`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/get?clientId=client&deliveryId=d-1", nil))
+	assert.JSONEq(t, `{"success": true, "result": {"deliveryId": "d-1", "status": "delivered"}}`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, deliveryRequest("/delete", DeliveryActionDelete, `{"deliveryId": "d-1"}`))
+	assert.JSONEq(t, `{"success": true}`, rec.Body.String())
+	assert.Equal(t, []string{"d-1"}, module.deleted)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, deliveryRequest("/print", DeliveryActionPrint, `{"type": "label", "deliveryIds": ["d-1", "d-2"]}`))
+	assert.Equal(t, "application/pdf", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "label:d-1,d-2", rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, deliveryRequest("/shipment/save", DeliveryActionShipmentSave, `{"orders": [{"deliveryId": "d-1"}]}`))
+	assert.JSONEq(t, `{"success": true, "result": {"shipmentId": "s-d-1"}}`, rec.Body.String())
+}
+
+func TestDeliveryRouter_Errors(t *testing.T) {
+	module := &testDeliveryModule{}
+	router := NewDeliveryRouter("client", testDelivery(), module)
+
+	rec, _ := serveWebhook(router, deliveryRequest("/unknown", DeliveryActionDelete, `{}`))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec, resp := serveWebhook(router, httptest.NewRequest(http.MethodGet, "/get?clientId=other&deliveryId=d-1", nil))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Equal(t, ErrInvalidClientID.Error(), resp.ErrorMessage)
+
+	rec, _ = serveWebhook(router, deliveryRequest("/delete", DeliveryActionDelete, `{"deliveryId":`))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Empty(t, module.deleted)
+
+	rec, _ = serveWebhook(router, httptest.NewRequest(http.MethodGet, "/get?clientId=client", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec, resp = serveWebhook(router, deliveryRequest("/shipment/delete", DeliveryActionShipmentDelete, `{"shipmentId": "s-1"}`))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, ErrDeliveryActionNotSupported.Error(), resp.ErrorMessage)
+
+	module.err = errors.New("tariff is not available")
+	rec, resp = serveWebhook(router, deliveryRequest("/calculate", DeliveryActionCalculate, `{}`))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.False(t, resp.Success)
+	assert.Equal(t, "tariff is not available", resp.ErrorMessage)
+}
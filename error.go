@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -192,3 +194,123 @@ func (e *apiError) withErrors(m APIErrorsList) APIError {
 	e.ErrorsList = m
 	return e
 }
+
+// FailedUpload is the entity which was rejected by the upload method.
+type FailedUpload struct {
+	ExternalID string
+	Reason     string
+}
+
+// PartialUploadError will be returned by OrdersUpload, CustomersUpload and CorporateCustomersUpload if only some of
+// the entities were uploaded (status code HTTPStatusUnknown). It contains the uploaded entities and the rejected ones
+// with the reasons from the errors field of the response. The error also implements APIError, so the response errors
+// are available via Errors method as before.
+//
+// Example:
+//
+//	_, _, err := client.OrdersUpload(orders)
+//
+//	var partial *retailcrm.PartialUploadError
+//	if errors.As(err, &partial) {
+//		for _, failed := range partial.Failed {
+//			log.Printf("%s was not uploaded: %s", failed.ExternalID, failed.Reason)
+//		}
+//	}
+type PartialUploadError struct {
+	APIError
+	Uploaded []IdentifiersPair
+	Failed   []FailedUpload
+}
+
+// Unwrap returns the underlying APIError.
+func (e *PartialUploadError) Unwrap() error {
+	return e.APIError
+}
+
+// FailedExternalIDs returns external IDs of the rejected entities.
+func (e *PartialUploadError) FailedExternalIDs() []string {
+	ids := make([]string, len(e.Failed))
+	for i, failed := range e.Failed {
+		ids[i] = failed.ExternalID
+	}
+
+	return ids
+}
+
+// newPartialUploadError wraps the error of the upload method which returned HTTPStatusUnknown status code.
+// Other errors are returned as is. External IDs of the uploaded entities are used to find the reasons by the position
+// of the entity in the request.
+func newPartialUploadError(err error, externalIDs []string, uploaded []IdentifiersPair, failed []ExternalID) error {
+	apiErr, ok := err.(APIError) // nolint:errorlint
+	if !ok {
+		return err
+	}
+
+	return &PartialUploadError{
+		APIError: apiErr,
+		Uploaded: uploaded,
+		Failed:   failedUploads(apiErr.Errors(), externalIDs, failed),
+	}
+}
+
+// failedUploads finds reasons of the rejected entities. Errors can be keyed by the external ID or by the position
+// of the entity in the request, or contain the external ID in the message.
+func failedUploads(errs APIErrorsList, externalIDs []string, failed []ExternalID) []FailedUpload {
+	keys := make([]string, 0, len(errs))
+	for key := range errs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	positions := newChunkPositions(externalIDs)
+	result := make([]FailedUpload, len(failed))
+	for i, item := range failed {
+		result[i] = FailedUpload{ExternalID: item.ExternalID}
+		if item.ExternalID == "" {
+			continue
+		}
+
+		if reason, ok := errs[item.ExternalID]; ok {
+			result[i].Reason = reason
+			continue
+		}
+
+		if index, ok := positions.take(item.ExternalID); ok && errs[strconv.Itoa(index)] != "" {
+			result[i].Reason = errs[strconv.Itoa(index)]
+			continue
+		}
+
+		for _, key := range keys {
+			if strings.Contains(errs[key], item.ExternalID) {
+				result[i].Reason = errs[key]
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+// causeError adds the context to the error cause. It matches the sentinel error with errors.Is
+// and unwraps to the cause, so the cause is available with errors.As.
+type causeError struct {
+	sentinel error
+	context  string
+	cause    error
+}
+
+func wrapError(sentinel error, context string, cause error) error {
+	return &causeError{sentinel: sentinel, context: context, cause: cause}
+}
+
+func (e *causeError) Error() string {
+	return e.sentinel.Error() + ": " + e.context + ": " + e.cause.Error()
+}
+
+func (e *causeError) Is(target error) bool {
+	return target == e.sentinel // nolint:errorlint
+}
+
+func (e *causeError) Unwrap() error {
+	return e.cause
+}
@@ -169,3 +169,68 @@ func (t *ErrorTest) TestFailure_HTML() {
 	t.Require().True(ok)
 	t.Assert().ErrorIs(apiErr, ErrAccountDoesNotExist)
 }
+
+func (t *ErrorTest) Test_PartialUploadError() {
+	b := []byte(`{"success": false,
+				"errorMsg": "Orders are loaded with errors",
+				"errors": {"ext-2": "Order already exists", "2": "Order with externalId=ext-3 has invalid status"}}`,
+	)
+
+	e := newPartialUploadError(CreateAPIError(b),
+		[]string{"ext-1", "ext-2", "ext-3", "ext-4"},
+		[]IdentifiersPair{{ID: 1, ExternalID: "ext-1"}},
+		[]ExternalID{{ExternalID: "ext-2"}, {ExternalID: "ext-3"}, {ExternalID: "ext-4"}},
+	)
+
+	var partial *PartialUploadError
+	t.Require().ErrorAs(e, &partial)
+	t.Assert().ErrorIs(e, ErrGeneric)
+	t.Assert().Equal("Orders are loaded with errors", e.Error())
+	t.Assert().Equal([]IdentifiersPair{{ID: 1, ExternalID: "ext-1"}}, partial.Uploaded)
+	t.Assert().Equal([]string{"ext-2", "ext-3", "ext-4"}, partial.FailedExternalIDs())
+	t.Assert().Equal([]FailedUpload{
+		{ExternalID: "ext-2", Reason: "Order already exists"},
+		{ExternalID: "ext-3", Reason: "Order with externalId=ext-3 has invalid status"},
+		{ExternalID: "ext-4"},
+	}, partial.Failed)
+
+	apiErr, ok := AsAPIError(e)
+	t.Require().True(ok)
+	t.Assert().Len(apiErr.Errors(), 2)
+}
+
+func (t *ErrorTest) Test_PartialUploadError_ErrorsList() {
+	b := []byte(`{"success": false,
+				"errorMsg": "Customers are loaded with errors",
+				"errors": ["Invalid email", "Invalid phone"]}`,
+	)
+
+	e := newPartialUploadError(CreateAPIError(b), []string{"a", "b"}, nil,
+		[]ExternalID{{ExternalID: "a"}, {ExternalID: "b"}})
+
+	var partial *PartialUploadError
+	t.Require().ErrorAs(e, &partial)
+	t.Assert().Equal([]FailedUpload{{ExternalID: "a", Reason: "Invalid email"}, {ExternalID: "b", Reason: "Invalid phone"}},
+		partial.Failed)
+}
+
+func (t *ErrorTest) Test_PartialUploadError_Positions() {
+	b := []byte(`{"success": false,
+				"errorMsg": "Orders are loaded with errors",
+				"errors": {"1": "Invalid status", "3": "Invalid phone", "orders": "Order c has no items"}}`,
+	)
+
+	e := newPartialUploadError(CreateAPIError(b),
+		[]string{"a", "b", "c", "d"},
+		[]IdentifiersPair{{ID: 1, ExternalID: "a"}},
+		[]ExternalID{{ExternalID: "b"}, {ExternalID: "c"}, {ExternalID: "d"}},
+	)
+
+	var partial *PartialUploadError
+	t.Require().ErrorAs(e, &partial)
+	t.Assert().Equal([]FailedUpload{
+		{ExternalID: "b", Reason: "Invalid status"},
+		{ExternalID: "c", Reason: "Order c has no items"},
+		{ExternalID: "d", Reason: "Invalid phone"},
+	}, partial.Failed)
+}
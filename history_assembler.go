@@ -0,0 +1,642 @@
+package retailcrm
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	historyCustomFieldPrefix = "custom_"
+	historyItemField         = "order_product"
+	historyPaymentField      = "payments"
+	historyPhonesField       = "phones"
+)
+
+// orderHistoryPaths contains JSON paths inside the Order for the history fields which cannot be
+// converted from snake_case to camelCase directly.
+var orderHistoryPaths = map[string]string{
+	"delivery_type":     "delivery.code",
+	"delivery_service":  "delivery.service",
+	"delivery_date":     "delivery.date",
+	"delivery_time":     "delivery.time",
+	"delivery_cost":     "delivery.cost",
+	"delivery_net_cost": "delivery.netCost",
+	"delivery_address":  "delivery.address",
+}
+
+// HistoryFields is a set of the changed history field names, e.g. "status" or "delivery_address.city".
+type HistoryFields map[string]struct{}
+
+// Has returns true if the field was changed.
+func (f HistoryFields) Has(field string) bool {
+	_, ok := f[field]
+	return ok
+}
+
+// List returns the sorted list of the changed fields.
+func (f HistoryFields) List() []string {
+	list := make([]string, 0, len(f))
+	for field := range f {
+		list = append(list, field)
+	}
+
+	sort.Strings(list)
+	return list
+}
+
+func (f HistoryFields) add(field string) {
+	if field != "" {
+		f[field] = struct{}{}
+	}
+}
+
+// OrderItemHistoryChanges contains changes of the single order item.
+type OrderItemHistoryChanges struct {
+	Item    OrderItem
+	Fields  HistoryFields
+	Created bool
+	Deleted bool
+}
+
+// PaymentHistoryChanges contains changes of the single order payment.
+type PaymentHistoryChanges struct {
+	Payment Payment
+	Fields  HistoryFields
+	Created bool
+	Deleted bool
+}
+
+// OrderHistoryChanges contains all changes of the single order which were assembled from the history records.
+//
+// Order contains the full data only if the order was created within the processed records. Otherwise, it contains
+// the order identifiers from the history and the changed fields. The same applies to the items and payments.
+// Use Snapshot to get the order together with its items and payments.
+type OrderHistoryChanges struct {
+	Order        Order
+	Fields       HistoryFields
+	Items        []*OrderItemHistoryChanges
+	Payments     []*PaymentHistoryChanges
+	CombinedTo   *Order
+	Created      bool
+	Deleted      bool
+	LastRecordID int
+}
+
+// Item returns changes of the order item with the provided ID.
+func (c *OrderHistoryChanges) Item(id int) (*OrderItemHistoryChanges, bool) {
+	for _, item := range c.Items {
+		if item.Item.ID == id {
+			return item, true
+		}
+	}
+
+	return nil, false
+}
+
+// Payment returns changes of the order payment with the provided ID.
+func (c *OrderHistoryChanges) Payment(id int) (*PaymentHistoryChanges, bool) {
+	for _, payment := range c.Payments {
+		if payment.Payment.ID == id {
+			return payment, true
+		}
+	}
+
+	return nil, false
+}
+
+// Snapshot returns the reconstructed order with the current items and payments. Deleted items and payments are
+// not included. Order field itself doesn't contain items and payments, they are stored in Items and Payments.
+func (c *OrderHistoryChanges) Snapshot() Order {
+	order := c.Order
+	order.Items = nil
+	order.Payments = nil
+
+	for _, item := range c.Items {
+		if !item.Deleted {
+			order.Items = append(order.Items, item.Item)
+		}
+	}
+
+	for _, payment := range c.Payments {
+		if payment.Deleted {
+			continue
+		}
+
+		if order.Payments == nil {
+			order.Payments = OrderPayments{}
+		}
+
+		order.Payments[strconv.Itoa(payment.Payment.ID)] = OrderPayment{
+			ID:         payment.Payment.ID,
+			ExternalID: payment.Payment.ExternalID,
+			Type:       payment.Payment.Type,
+			Status:     payment.Payment.Status,
+			PaidAt:     payment.Payment.PaidAt,
+			Amount:     payment.Payment.Amount,
+			Comment:    payment.Payment.Comment,
+		}
+	}
+
+	return order
+}
+
+func (c *OrderHistoryChanges) item(item *OrderItem) *OrderItemHistoryChanges {
+	if existing, ok := c.Item(item.ID); ok {
+		return existing
+	}
+
+	changes := &OrderItemHistoryChanges{Item: *item, Fields: HistoryFields{}}
+	c.Items = append(c.Items, changes)
+
+	return changes
+}
+
+func (c *OrderHistoryChanges) payment(payment *Payment) *PaymentHistoryChanges {
+	if existing, ok := c.Payment(payment.ID); ok {
+		return existing
+	}
+
+	changes := &PaymentHistoryChanges{Payment: *payment, Fields: HistoryFields{}}
+	changes.Payment.Order = nil
+	c.Payments = append(c.Payments, changes)
+
+	return changes
+}
+
+// OrdersHistoryAssembler folds the orders history records into the per-order changes.
+//
+// Example:
+//
+//	var client = retailcrm.New("https://demo.url", "09jIJ")
+//
+//	data, _, err := client.OrdersHistory(retailcrm.OrdersHistoryRequest{Filter: retailcrm.OrdersHistoryFilter{SinceID: 20}})
+//	if err != nil {
+//		log.Fatalf("error: %s", err)
+//	}
+//
+//	assembler := retailcrm.NewOrdersHistoryAssembler()
+//	if err := assembler.Add(data.History...); err != nil {
+//		log.Fatalf("error: %s", err)
+//	}
+//
+//	for _, changes := range assembler.Orders() {
+//		if changes.Fields.Has("status") {
+//			log.Printf("order %d status: %s\n", changes.Order.ID, changes.Order.Status)
+//		}
+//	}
+type OrdersHistoryAssembler struct {
+	orders []*OrderHistoryChanges
+	index  map[int]*OrderHistoryChanges
+}
+
+// NewOrdersHistoryAssembler instantiates new OrdersHistoryAssembler.
+func NewOrdersHistoryAssembler() *OrdersHistoryAssembler {
+	return &OrdersHistoryAssembler{index: map[int]*OrderHistoryChanges{}}
+}
+
+// Orders returns changes for every order in the order of their first appearance in the history.
+func (a *OrdersHistoryAssembler) Orders() []*OrderHistoryChanges {
+	return a.orders
+}
+
+// Order returns changes of the order with the provided ID.
+func (a *OrdersHistoryAssembler) Order(id int) (*OrderHistoryChanges, bool) {
+	changes, ok := a.index[id]
+	return changes, ok
+}
+
+// Add applies the history records. Records must be provided in the same order as they were returned by the API.
+// Records without the order are skipped. An error is returned if the value cannot be applied to the order field.
+func (a *OrdersHistoryAssembler) Add(records ...OrdersHistoryRecord) error {
+	for i := range records {
+		if err := a.apply(&records[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a *OrdersHistoryAssembler) apply(record *OrdersHistoryRecord) error {
+	if record.Order == nil {
+		return nil
+	}
+
+	changes, ok := a.index[record.Order.ID]
+	if !ok {
+		changes = &OrderHistoryChanges{Fields: HistoryFields{}}
+		a.orders = append(a.orders, changes)
+		a.index[record.Order.ID] = changes
+	}
+
+	changes.LastRecordID = record.ID
+	changes.Fields.add(record.Field)
+	mergeHistoryIdentifiers(&changes.Order, record.Order)
+
+	switch {
+	case record.Item != nil || strings.HasPrefix(record.Field, historyItemField):
+		return applyOrderItemHistory(changes, record)
+	case record.Payment != nil || strings.HasPrefix(record.Field, historyPaymentField):
+		return applyPaymentHistory(changes, record)
+	}
+
+	if record.Created {
+		changes.Created = true
+		changes.Order = *record.Order
+		changes.Order.Items = nil
+		changes.Order.Payments = nil
+
+		for i := range record.Order.Items {
+			changes.item(&record.Order.Items[i])
+		}
+
+		keys := make([]string, 0, len(record.Order.Payments))
+		for key := range record.Order.Payments {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			payment := record.Order.Payments[key]
+			changes.payment(&Payment{
+				ID:         payment.ID,
+				ExternalID: payment.ExternalID,
+				PaidAt:     payment.PaidAt,
+				Amount:     payment.Amount,
+				Comment:    payment.Comment,
+				Status:     payment.Status,
+				Type:       payment.Type,
+			})
+		}
+	}
+
+	if record.CombinedTo != nil {
+		changes.CombinedTo = record.CombinedTo
+	}
+
+	if record.Deleted {
+		changes.Deleted = true
+		return nil
+	}
+
+	if record.Created || record.Field == "" {
+		return nil
+	}
+
+	return applyHistoryValue(&changes.Order, orderHistoryPath(record.Field), record.NewValue)
+}
+
+func applyOrderItemHistory(changes *OrderHistoryChanges, record *OrdersHistoryRecord) error {
+	if record.Item == nil {
+		return nil
+	}
+
+	item := changes.item(record.Item)
+
+	if record.Field == historyItemField || record.Field == "" {
+		switch {
+		case record.Created || (record.OldValue == nil && record.NewValue != nil):
+			item.Created = true
+			item.Item = *record.Item
+		case record.Deleted || (record.OldValue != nil && record.NewValue == nil):
+			item.Deleted = true
+		}
+
+		return nil
+	}
+
+	field := strings.TrimPrefix(record.Field, historyItemField+".")
+	item.Fields.add(field)
+
+	return applyHistoryValue(&item.Item, snakeToCamelPath(field), record.NewValue)
+}
+
+func applyPaymentHistory(changes *OrderHistoryChanges, record *OrdersHistoryRecord) error {
+	if record.Payment == nil {
+		return nil
+	}
+
+	payment := changes.payment(record.Payment)
+
+	if record.Field == historyPaymentField || record.Field == "" {
+		switch {
+		case record.Created || (record.OldValue == nil && record.NewValue != nil):
+			payment.Created = true
+			payment.Payment = *record.Payment
+			payment.Payment.Order = nil
+		case record.Deleted || (record.OldValue != nil && record.NewValue == nil):
+			payment.Deleted = true
+		}
+
+		return nil
+	}
+
+	field := strings.TrimPrefix(record.Field, historyPaymentField+".")
+	payment.Fields.add(field)
+
+	return applyHistoryValue(&payment.Payment, snakeToCamelPath(field), record.NewValue)
+}
+
+func mergeHistoryIdentifiers(dst, src *Order) {
+	if dst.ID == 0 {
+		dst.ID = src.ID
+	}
+
+	if dst.ExternalID == "" {
+		dst.ExternalID = src.ExternalID
+	}
+
+	if dst.Number == "" {
+		dst.Number = src.Number
+	}
+
+	if dst.Site == "" {
+		dst.Site = src.Site
+	}
+}
+
+// CustomerHistoryChanges contains all changes of the single customer which were assembled from the history records.
+//
+// Customer contains the full snapshot only if the customer was created within the processed records. Otherwise,
+// it contains the customer identifiers from the history and the changed fields.
+type CustomerHistoryChanges struct {
+	Customer     Customer
+	Fields       HistoryFields
+	Created      bool
+	Deleted      bool
+	LastRecordID int
+}
+
+// CustomersHistoryAssembler folds the customers history records into the per-customer changes.
+type CustomersHistoryAssembler struct {
+	customers []*CustomerHistoryChanges
+	index     map[int]*CustomerHistoryChanges
+}
+
+// NewCustomersHistoryAssembler instantiates new CustomersHistoryAssembler.
+func NewCustomersHistoryAssembler() *CustomersHistoryAssembler {
+	return &CustomersHistoryAssembler{index: map[int]*CustomerHistoryChanges{}}
+}
+
+// Customers returns changes for every customer in the order of their first appearance in the history.
+func (a *CustomersHistoryAssembler) Customers() []*CustomerHistoryChanges {
+	return a.customers
+}
+
+// Customer returns changes of the customer with the provided ID.
+func (a *CustomersHistoryAssembler) Customer(id int) (*CustomerHistoryChanges, bool) {
+	changes, ok := a.index[id]
+	return changes, ok
+}
+
+// Add applies the history records. Records must be provided in the same order as they were returned by the API.
+// Records without the customer are skipped. An error is returned if the value cannot be applied to the customer field.
+func (a *CustomersHistoryAssembler) Add(records ...CustomerHistoryRecord) error {
+	for i := range records {
+		if err := a.apply(&records[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a *CustomersHistoryAssembler) apply(record *CustomerHistoryRecord) error {
+	if record.Customer == nil {
+		return nil
+	}
+
+	changes, ok := a.index[record.Customer.ID]
+	if !ok {
+		changes = &CustomerHistoryChanges{Fields: HistoryFields{}}
+		a.customers = append(a.customers, changes)
+		a.index[record.Customer.ID] = changes
+	}
+
+	changes.LastRecordID = record.ID
+	changes.Fields.add(record.Field)
+
+	if record.Created {
+		changes.Created = true
+		changes.Customer = *record.Customer
+
+		return nil
+	}
+
+	if changes.Customer.ID == 0 {
+		changes.Customer.ID = record.Customer.ID
+	}
+
+	if changes.Customer.ExternalID == "" {
+		changes.Customer.ExternalID = record.Customer.ExternalID
+	}
+
+	if changes.Customer.Site == "" {
+		changes.Customer.Site = record.Customer.Site
+	}
+
+	if record.Deleted {
+		changes.Deleted = true
+		return nil
+	}
+
+	switch record.Field {
+	case "":
+		return nil
+	case historyPhonesField:
+		applyPhonesHistory(&changes.Customer, record.OldValue, record.NewValue)
+		return nil
+	}
+
+	return applyHistoryValue(&changes.Customer, snakeToCamelPath(record.Field), record.NewValue)
+}
+
+func applyPhonesHistory(customer *Customer, oldValue, newValue interface{}) {
+	if old := historyPhoneNumber(oldValue); old != "" {
+		for i, phone := range customer.Phones {
+			if phone.Number == old {
+				customer.Phones = append(customer.Phones[:i], customer.Phones[i+1:]...)
+				break
+			}
+		}
+	}
+
+	if number := historyPhoneNumber(newValue); number != "" {
+		customer.Phones = append(customer.Phones, Phone{Number: number})
+	}
+}
+
+func historyPhoneNumber(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		if number, ok := v["number"].(string); ok {
+			return number
+		}
+	}
+
+	return ""
+}
+
+// orderHistoryPath returns JSON path inside the Order for the provided history field.
+func orderHistoryPath(field string) string {
+	if path, ok := orderHistoryPaths[field]; ok {
+		return path
+	}
+
+	if prefix, rest, ok := strings.Cut(field, "."); ok {
+		if path, ok := orderHistoryPaths[prefix]; ok {
+			return path + "." + snakeToCamelPath(rest)
+		}
+	}
+
+	return snakeToCamelPath(field)
+}
+
+// snakeToCamelPath converts history field name like "contragent.legal_name" into JSON path "contragent.legalName".
+// Custom fields like "custom_field_code" are converted into "customFields.field_code".
+func snakeToCamelPath(field string) string {
+	if strings.HasPrefix(field, historyCustomFieldPrefix) {
+		return "customFields." + strings.TrimPrefix(field, historyCustomFieldPrefix)
+	}
+
+	parts := strings.Split(field, ".")
+	for i, part := range parts {
+		words := strings.Split(part, "_")
+		for j := 1; j < len(words); j++ {
+			if words[j] != "" {
+				words[j] = strings.ToUpper(words[j][:1]) + words[j][1:]
+			}
+		}
+
+		parts[i] = strings.Join(words, "")
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// applyHistoryValue sets the value into the entity field located at the provided JSON path.
+// Unknown paths are ignored. Objects like {"code": "new"} or {"id": 1} are unwrapped for string and
+// integer fields.
+func applyHistoryValue(entity interface{}, path string, value interface{}) error {
+	target := reflect.ValueOf(entity).Elem()
+	segments := strings.Split(path, ".")
+
+	for i, segment := range segments {
+		for target.Kind() == reflect.Ptr {
+			if target.IsNil() {
+				target.Set(reflect.New(target.Type().Elem()))
+			}
+
+			target = target.Elem()
+		}
+
+		switch target.Kind() {
+		case reflect.Map:
+			if i != len(segments)-1 || target.Type().Key().Kind() != reflect.String {
+				return nil
+			}
+
+			return setHistoryMapValue(target, segment, value, path)
+		case reflect.Struct:
+			field, ok := fieldByJSONName(target, segment)
+			if !ok {
+				return nil
+			}
+
+			target = field
+		default:
+			return nil
+		}
+	}
+
+	return setHistoryFieldValue(target, value, path)
+}
+
+func setHistoryMapValue(target reflect.Value, key string, value interface{}, path string) error {
+	if value == nil {
+		if !target.IsNil() {
+			target.SetMapIndex(reflect.ValueOf(key), reflect.Value{})
+		}
+
+		return nil
+	}
+
+	if target.IsNil() {
+		target.Set(reflect.MakeMap(target.Type()))
+	}
+
+	elem := reflect.New(target.Type().Elem()).Elem()
+	if err := setHistoryFieldValue(elem, value, path); err != nil {
+		return err
+	}
+
+	target.SetMapIndex(reflect.ValueOf(key).Convert(target.Type().Key()), elem)
+	return nil
+}
+
+func setHistoryFieldValue(target reflect.Value, value interface{}, path string) error {
+	if value == nil {
+		target.Set(reflect.Zero(target.Type()))
+		return nil
+	}
+
+	if obj, ok := value.(map[string]interface{}); ok {
+		switch target.Kind() { // nolint:exhaustive
+		case reflect.String:
+			if code, ok := obj["code"]; ok {
+				value = code
+			}
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if id, ok := obj["id"]; ok {
+				value = id
+			}
+		}
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	result := reflect.New(target.Type())
+	if err := json.Unmarshal(data, result.Interface()); err != nil {
+		return fmt.Errorf("cannot apply history value to %s: %w", path, err)
+	}
+
+	target.Set(result.Elem())
+	return nil
+}
+
+func fieldByJSONName(target reflect.Value, name string) (reflect.Value, bool) {
+	typ := target.Type()
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := strings.Split(field.Tag.Get("json"), ",")[0]
+		if tag == name {
+			return target.Field(i), true
+		}
+
+		if field.Anonymous && tag == "" {
+			embedded := target.Field(i)
+			if embedded.Kind() == reflect.Struct {
+				if found, ok := fieldByJSONName(embedded, name); ok {
+					return found, true
+				}
+			}
+		}
+	}
+
+	return reflect.Value{}, false
+}
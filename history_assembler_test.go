@@ -0,0 +1,166 @@
+package retailcrm
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeOrdersHistory(t *testing.T, data string) []OrdersHistoryRecord {
+	var resp OrdersHistoryResponse
+	require.NoError(t, json.Unmarshal([]byte(data), &resp))
+
+	return resp.History
+}
+
+func TestOrdersHistoryAssembler_FieldChanges(t *testing.T) {
+	records := decodeOrdersHistory(t, `{"success": true, "history": [
+		{"id": 1, "field": "status", "oldValue": {"code": "new"}, "newValue": {"code": "complete"},
+			"order": {"id": 10, "externalId": "ext-10", "site": "main"}},
+		{"id": 2, "field": "manager_id", "oldValue": null, "newValue": {"id": 5}, "order": {"id": 10}},
+		{"id": 3, "field": "delivery_address.city", "oldValue": "Moscow", "newValue": "Kazan", "order": {"id": 10}},
+		{"id": 4, "field": "delivery_cost", "oldValue": 100, "newValue": 250.5, "order": {"id": 10}},
+		{"id": 5, "field": "contragent.legal_name", "newValue": "ACME", "order": {"id": 10}},
+		{"id": 6, "field": "custom_color", "newValue": "red", "order": {"id": 10}},
+		{"id": 7, "field": "first_name", "oldValue": "John", "newValue": "Ivan", "order": {"id": 20}},
+		{"id": 8, "field": "unknown_field", "newValue": "value", "order": {"id": 20}}
+	]}`)
+
+	assembler := NewOrdersHistoryAssembler()
+	require.NoError(t, assembler.Add(records...))
+	require.Len(t, assembler.Orders(), 2)
+
+	changes, ok := assembler.Order(10)
+	require.True(t, ok)
+	assert.Equal(t, 6, changes.LastRecordID)
+	assert.Equal(t, []string{
+		"contragent.legal_name", "custom_color", "delivery_address.city", "delivery_cost", "manager_id", "status",
+	}, changes.Fields.List())
+
+	order := changes.Snapshot()
+	assert.Equal(t, 10, order.ID)
+	assert.Equal(t, "ext-10", order.ExternalID)
+	assert.Equal(t, "main", order.Site)
+	assert.Equal(t, "complete", order.Status)
+	assert.Equal(t, 5, order.ManagerID)
+	assert.Equal(t, "Kazan", order.Delivery.Address.City)
+	assert.Equal(t, float32(250.5), order.Delivery.Cost)
+	assert.Equal(t, "ACME", order.Contragent.LegalName)
+	assert.Equal(t, "red", order.CustomFields["color"])
+
+	other, ok := assembler.Order(20)
+	require.True(t, ok)
+	assert.Equal(t, "Ivan", other.Order.FirstName)
+	assert.True(t, other.Fields.Has("unknown_field"))
+}
+
+func TestOrdersHistoryAssembler_CreatedOrderItemsAndPayments(t *testing.T) {
+	records := decodeOrdersHistory(t, `{"success": true, "history": [
+		{"id": 1, "created": true, "source": "api", "order": {"id": 10, "status": "new",
+			"items": [{"id": 100, "quantity": 1, "initialPrice": 50, "offer": {"id": 1}}],
+			"payments": [{"id": 200, "type": "cash", "amount": 50}]}},
+		{"id": 2, "field": "order_product.quantity", "oldValue": 1, "newValue": 3,
+			"order": {"id": 10}, "item": {"id": 100}},
+		{"id": 3, "field": "order_product.status", "newValue": {"code": "in-reserve"},
+			"order": {"id": 10}, "item": {"id": 100}},
+		{"id": 4, "field": "order_product", "oldValue": null, "newValue": {"id": 101},
+			"order": {"id": 10}, "item": {"id": 101, "quantity": 2, "offer": {"id": 2}}},
+		{"id": 5, "field": "order_product", "oldValue": {"id": 100}, "newValue": null,
+			"order": {"id": 10}, "item": {"id": 100}},
+		{"id": 6, "field": "payments.amount", "oldValue": 50, "newValue": 150,
+			"order": {"id": 10}, "payment": {"id": 200}},
+		{"id": 7, "field": "payments.status", "newValue": {"code": "paid"},
+			"order": {"id": 10}, "payment": {"id": 200}},
+		{"id": 8, "field": "payments", "oldValue": null, "newValue": {"code": "bank-card"},
+			"order": {"id": 10}, "payment": {"id": 201, "type": "bank-card", "amount": 10}}
+	]}`)
+
+	assembler := NewOrdersHistoryAssembler()
+	require.NoError(t, assembler.Add(records...))
+
+	changes, ok := assembler.Order(10)
+	require.True(t, ok)
+	assert.True(t, changes.Created)
+	assert.False(t, changes.Deleted)
+
+	removed, ok := changes.Item(100)
+	require.True(t, ok)
+	assert.True(t, removed.Deleted)
+	assert.Equal(t, float32(3), removed.Item.Quantity)
+	assert.Equal(t, "in-reserve", removed.Item.Status)
+	assert.True(t, removed.Fields.Has("quantity"))
+
+	added, ok := changes.Item(101)
+	require.True(t, ok)
+	assert.True(t, added.Created)
+
+	payment, ok := changes.Payment(200)
+	require.True(t, ok)
+	assert.Equal(t, float32(150), payment.Payment.Amount)
+	assert.Equal(t, "paid", payment.Payment.Status)
+
+	order := changes.Snapshot()
+	assert.Equal(t, "new", order.Status)
+	require.Len(t, order.Items, 1)
+	assert.Equal(t, 101, order.Items[0].ID)
+	require.Len(t, order.Payments, 2)
+	assert.Equal(t, "bank-card", order.Payments["201"].Type)
+	assert.Equal(t, float32(150), order.Payments["200"].Amount)
+}
+
+func TestOrdersHistoryAssembler_CombinedAndDeleted(t *testing.T) {
+	records := decodeOrdersHistory(t, `{"success": true, "history": [
+		{"id": 1, "field": "status", "newValue": {"code": "new"}, "order": {"id": 10}},
+		{"id": 2, "deleted": true, "field": "id", "oldValue": 10, "order": {"id": 10}, "combinedTo": {"id": 30}}
+	]}`)
+
+	assembler := NewOrdersHistoryAssembler()
+	require.NoError(t, assembler.Add(records...))
+
+	changes, ok := assembler.Order(10)
+	require.True(t, ok)
+	assert.True(t, changes.Deleted)
+	require.NotNil(t, changes.CombinedTo)
+	assert.Equal(t, 30, changes.CombinedTo.ID)
+}
+
+func TestOrdersHistoryAssembler_TypeMismatch(t *testing.T) {
+	records := decodeOrdersHistory(t, `{"success": true, "history": [
+		{"id": 1, "field": "manager_id", "newValue": "not a number", "order": {"id": 10}}
+	]}`)
+
+	assert.Error(t, NewOrdersHistoryAssembler().Add(records...))
+}
+
+func TestCustomersHistoryAssembler(t *testing.T) {
+	var resp CustomersHistoryResponse
+	require.NoError(t, json.Unmarshal([]byte(`{"success": true, "history": [
+		{"id": 1, "created": true, "customer": {"id": 5, "firstName": "Ivan", "phones": [{"number": "111"}]}},
+		{"id": 2, "field": "email", "newValue": "ivan@example.com", "customer": {"id": 5}},
+		{"id": 3, "field": "address.city", "newValue": "Moscow", "customer": {"id": 5}},
+		{"id": 4, "field": "phones", "oldValue": {"number": "111"}, "newValue": {"number": "222"}, "customer": {"id": 5}},
+		{"id": 5, "field": "vip", "newValue": true, "customer": {"id": 5}},
+		{"id": 6, "deleted": true, "customer": {"id": 6, "externalId": "ext-6"}}
+	]}`), &resp))
+
+	assembler := NewCustomersHistoryAssembler()
+	require.NoError(t, assembler.Add(resp.History...))
+	require.Len(t, assembler.Customers(), 2)
+
+	changes, ok := assembler.Customer(5)
+	require.True(t, ok)
+	assert.True(t, changes.Created)
+	assert.Equal(t, "Ivan", changes.Customer.FirstName)
+	assert.Equal(t, "ivan@example.com", changes.Customer.Email)
+	assert.Equal(t, "Moscow", changes.Customer.Address.City)
+	assert.Equal(t, []Phone{{Number: "222"}}, changes.Customer.Phones)
+	assert.True(t, changes.Customer.Vip)
+	assert.Equal(t, []string{"address.city", "email", "phones", "vip"}, changes.Fields.List())
+
+	deleted, ok := assembler.Customer(6)
+	require.True(t, ok)
+	assert.True(t, deleted.Deleted)
+	assert.Equal(t, "ext-6", deleted.Customer.ExternalID)
+}
@@ -0,0 +1,432 @@
+package retailcrm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+const (
+	// HistoryEntityOrder is the registry key for the orders history fields.
+	HistoryEntityOrder = "order"
+	// HistoryEntityCustomer is the registry key for the customers history fields.
+	HistoryEntityCustomer = "customer"
+	// HistoryEntityCorporateCustomer is the registry key for the corporate customers history fields.
+	HistoryEntityCorporateCustomer = "customer_corporate"
+	// HistoryEntityPack is the registry key for the packs history fields.
+	HistoryEntityPack = "pack"
+)
+
+var (
+	// ErrUnknownHistoryField will be returned if the history field is not present in the registry.
+	ErrUnknownHistoryField = errors.New("unknown history field")
+	// ErrHistoryValueType will be returned if the history value cannot be decoded into the provided destination.
+	ErrHistoryValueType = errors.New("history value type mismatch")
+)
+
+// HistoryCodeValue is the history value for the fields which reference a dictionary entry, e.g. "status".
+type HistoryCodeValue struct {
+	Code string `json:"code"`
+}
+
+// HistoryIDValue is the history value for the fields which reference an entity, e.g. "manager_id".
+type HistoryIDValue struct {
+	ID int `json:"id"`
+}
+
+var (
+	historyAnyType    = reflect.TypeOf((*interface{})(nil)).Elem()
+	historyStringType = reflect.TypeOf("")
+	historyBoolType   = reflect.TypeOf(false)
+	historyIntType    = reflect.TypeOf(0)
+	historyFloatType  = reflect.TypeOf(float64(0))
+	historyCodeType   = reflect.TypeOf(HistoryCodeValue{})
+	historyIDType     = reflect.TypeOf(HistoryIDValue{})
+)
+
+var historyAddressFields = map[string]reflect.Type{
+	"index":       historyStringType,
+	"country_iso": historyStringType,
+	"region":      historyStringType,
+	"region_id":   historyIntType,
+	"city":        historyStringType,
+	"city_id":     historyIntType,
+	"city_type":   historyStringType,
+	"street":      historyStringType,
+	"street_id":   historyIntType,
+	"street_type": historyStringType,
+	"building":    historyStringType,
+	"flat":        historyStringType,
+	"floor":       historyIntType,
+	"block":       historyIntType,
+	"house":       historyStringType,
+	"housing":     historyStringType,
+	"metro":       historyStringType,
+	"notes":       historyStringType,
+	"text":        historyStringType,
+}
+
+var historyContragentFields = map[string]reflect.Type{
+	"contragent_type":    historyStringType,
+	"legal_name":         historyStringType,
+	"legal_address":      historyStringType,
+	"INN":                historyStringType,
+	"OKPO":               historyStringType,
+	"KPP":                historyStringType,
+	"OGRN":               historyStringType,
+	"OGRNIP":             historyStringType,
+	"certificate_number": historyStringType,
+	"certificate_date":   historyStringType,
+	"BIK":                historyStringType,
+	"bank":               historyStringType,
+	"bank_address":       historyStringType,
+	"corr_account":       historyStringType,
+	"bank_account":       historyStringType,
+}
+
+var historySourceFields = map[string]reflect.Type{
+	"source":   historyStringType,
+	"medium":   historyStringType,
+	"campaign": historyStringType,
+	"keyword":  historyStringType,
+	"content":  historyStringType,
+}
+
+var historyFieldRegistry = struct {
+	fields map[string]map[string]reflect.Type
+	mutex  sync.RWMutex
+}{
+	fields: map[string]map[string]reflect.Type{
+		HistoryEntityOrder: withHistoryGroups(map[string]reflect.Type{
+			"id":                                    historyIntType,
+			"external_id":                           historyStringType,
+			"number":                                historyStringType,
+			"site":                                  historyStringType,
+			"created_at":                            historyStringType,
+			"status":                                historyCodeType,
+			"status_comment":                        historyStringType,
+			"order_type":                            historyCodeType,
+			"order_method":                          historyCodeType,
+			"manager_id":                            historyIDType,
+			"customer":                              reflect.TypeOf(Customer{}),
+			"contact":                               reflect.TypeOf(Customer{}),
+			"company":                               reflect.TypeOf(Company{}),
+			"first_name":                            historyStringType,
+			"last_name":                             historyStringType,
+			"patronymic":                            historyStringType,
+			"email":                                 historyStringType,
+			"phone":                                 historyStringType,
+			"additional_phone":                      historyStringType,
+			"customer_comment":                      historyStringType,
+			"manager_comment":                       historyStringType,
+			"call":                                  historyBoolType,
+			"expired":                               historyBoolType,
+			"shipped":                               historyBoolType,
+			"discount_manual_amount":                historyFloatType,
+			"discount_manual_percent":               historyFloatType,
+			"prepay_sum":                            historyFloatType,
+			"weight":                                historyFloatType,
+			"length":                                historyIntType,
+			"width":                                 historyIntType,
+			"height":                                historyIntType,
+			"shipment_store":                        historyCodeType,
+			"shipment_date":                         historyStringType,
+			"delivery_type":                         historyCodeType,
+			"delivery_service":                      reflect.TypeOf(OrderDeliveryService{}),
+			"delivery_date":                         historyStringType,
+			"delivery_time":                         reflect.TypeOf(OrderDeliveryTime{}),
+			"delivery_cost":                         historyFloatType,
+			"delivery_net_cost":                     historyFloatType,
+			"order_product":                         reflect.TypeOf(OrderItem{}),
+			"order_product.quantity":                historyFloatType,
+			"order_product.initial_price":           historyFloatType,
+			"order_product.purchase_price":          historyFloatType,
+			"order_product.discount_total":          historyFloatType,
+			"order_product.discount_manual_amount":  historyFloatType,
+			"order_product.discount_manual_percent": historyFloatType,
+			"order_product.status":                  historyCodeType,
+			"order_product.price_type":              historyCodeType,
+			"order_product.comment":                 historyStringType,
+			"order_product.vat_rate":                historyStringType,
+			"payments":                              reflect.TypeOf(Payment{}),
+			"payments.amount":                       historyFloatType,
+			"payments.type":                         historyCodeType,
+			"payments.status":                       historyCodeType,
+			"payments.paid_at":                      historyStringType,
+			"payments.comment":                      historyStringType,
+			"payments.external_id":                  historyStringType,
+		}, map[string]map[string]reflect.Type{
+			"delivery_address": historyAddressFields,
+			"contragent":       historyContragentFields,
+			"source":           historySourceFields,
+		}),
+		HistoryEntityCustomer: withHistoryGroups(map[string]reflect.Type{
+			"id":                              historyIntType,
+			"external_id":                     historyStringType,
+			"site":                            historyStringType,
+			"created_at":                      historyStringType,
+			"first_name":                      historyStringType,
+			"last_name":                       historyStringType,
+			"patronymic":                      historyStringType,
+			"sex":                             historyStringType,
+			"email":                           historyStringType,
+			"birthday":                        historyStringType,
+			"phones":                          historyAnyType,
+			"manager_id":                      historyIDType,
+			"vip":                             historyBoolType,
+			"bad":                             historyBoolType,
+			"personal_discount":               historyFloatType,
+			"cumulative_discount":             historyFloatType,
+			"discount_card_number":            historyStringType,
+			"email_marketing_unsubscribed_at": historyStringType,
+			"photo_url":                       historyStringType,
+		}, map[string]map[string]reflect.Type{
+			"address":    historyAddressFields,
+			"contragent": historyContragentFields,
+			"source":     historySourceFields,
+		}),
+		HistoryEntityCorporateCustomer: {
+			"id":                   historyIntType,
+			"external_id":          historyStringType,
+			"created_at":           historyStringType,
+			"nick_name":            historyStringType,
+			"manager_id":           historyIDType,
+			"vip":                  historyBoolType,
+			"bad":                  historyBoolType,
+			"personal_discount":    historyFloatType,
+			"discount_card_number": historyStringType,
+		},
+		HistoryEntityPack: {
+			"id":                   historyIntType,
+			"store":                historyCodeType,
+			"quantity":             historyFloatType,
+			"purchase_price":       historyFloatType,
+			"shipment_date":        historyStringType,
+			"invoice_number":       historyStringType,
+			"delivery_note_number": historyStringType,
+		},
+	},
+}
+
+func withHistoryGroups(
+	fields map[string]reflect.Type, groups map[string]map[string]reflect.Type,
+) map[string]reflect.Type {
+	for prefix, group := range groups {
+		for name, typ := range group {
+			fields[prefix+"."+name] = typ
+		}
+	}
+
+	return fields
+}
+
+// RegisterHistoryField adds the history field into the registry or replaces the existing one.
+// Type of the sample value will be used as the field type, pass nil to accept any value.
+//
+// Example:
+//
+//	retailcrm.RegisterHistoryField(retailcrm.HistoryEntityOrder, "delivery_data.track_number", "")
+func RegisterHistoryField(entity, field string, sample interface{}) {
+	typ := historyAnyType
+	if sample != nil {
+		typ = reflect.TypeOf(sample)
+	}
+
+	historyFieldRegistry.mutex.Lock()
+	defer historyFieldRegistry.mutex.Unlock()
+
+	if historyFieldRegistry.fields[entity] == nil {
+		historyFieldRegistry.fields[entity] = map[string]reflect.Type{}
+	}
+
+	historyFieldRegistry.fields[entity][field] = typ
+}
+
+// HistoryFieldType returns the registered type of the history field. Custom fields ("custom_*") accept any value.
+func HistoryFieldType(entity, field string) (reflect.Type, bool) {
+	if strings.HasPrefix(field, historyCustomFieldPrefix) {
+		return historyAnyType, true
+	}
+
+	historyFieldRegistry.mutex.RLock()
+	defer historyFieldRegistry.mutex.RUnlock()
+
+	typ, ok := historyFieldRegistry.fields[entity][field]
+	return typ, ok
+}
+
+// decodeHistoryValue decodes the history value into dst which must be a non-nil pointer.
+// Destination is checked against the registered field type before decoding.
+func decodeHistoryValue(entity, field string, value, dst interface{}) error {
+	typ, ok := HistoryFieldType(entity, field)
+	if !ok {
+		return fmt.Errorf("%w: %s.%s", ErrUnknownHistoryField, entity, field)
+	}
+
+	target := reflect.ValueOf(dst)
+	if target.Kind() != reflect.Ptr || target.IsNil() {
+		return fmt.Errorf("%w: %s.%s: destination must be a non-nil pointer", ErrHistoryValueType, entity, field)
+	}
+
+	target = target.Elem()
+	if !historyTypeCompatible(typ, target.Type()) {
+		return fmt.Errorf("%w: %s.%s is %s, got %s", ErrHistoryValueType, entity, field, typ, target.Type())
+	}
+
+	if value == nil {
+		target.Set(reflect.Zero(target.Type()))
+		return nil
+	}
+
+	if obj, ok := value.(map[string]interface{}); ok {
+		switch {
+		case typ == historyCodeType && target.Kind() == reflect.String:
+			value = obj["code"]
+		case typ == historyIDType && isHistoryIntKind(target.Kind()):
+			value = obj["id"]
+		}
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(data, dst); err != nil {
+		return wrapError(ErrHistoryValueType, entity+"."+field, err)
+	}
+
+	return nil
+}
+
+// historyTypeCompatible returns true if the value of the registered type can be decoded into dst.
+// Numbers can be decoded into any numeric type of the same family, code and ID values can be decoded into
+// strings and integers respectively.
+func historyTypeCompatible(registered, dst reflect.Type) bool {
+	switch {
+	case registered == historyAnyType, dst == registered:
+		return true
+	case registered == historyCodeType:
+		return dst.Kind() == reflect.String
+	case registered == historyIDType:
+		return isHistoryIntKind(dst.Kind())
+	case registered.Kind() == reflect.Float64:
+		return dst.Kind() == reflect.Float32 || dst.Kind() == reflect.Float64
+	case isHistoryIntKind(registered.Kind()):
+		return isHistoryIntKind(dst.Kind())
+	case registered.Kind() == reflect.String:
+		return dst.Kind() == reflect.String
+	case registered.Kind() == reflect.Struct && dst.Kind() == reflect.Ptr:
+		return dst.Elem() == registered
+	}
+
+	return false
+}
+
+func isHistoryIntKind(kind reflect.Kind) bool {
+	switch kind { // nolint:exhaustive
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	default:
+		return false
+	}
+}
+
+// historyCode returns the dictionary code from the history value of the code field.
+func historyCode(entity, field string, value interface{}) (string, error) {
+	typ, ok := HistoryFieldType(entity, field)
+	if !ok {
+		return "", fmt.Errorf("%w: %s.%s", ErrUnknownHistoryField, entity, field)
+	}
+
+	if typ != historyCodeType {
+		return "", fmt.Errorf("%w: %s.%s is %s, not a code", ErrHistoryValueType, entity, field, typ)
+	}
+
+	var code string
+	err := decodeHistoryValue(entity, field, value, &code)
+	return code, err
+}
+
+// NewValueAs decodes the new value of the record into dst.
+func (r OrdersHistoryRecord) NewValueAs(dst interface{}) error {
+	return decodeHistoryValue(HistoryEntityOrder, r.Field, r.NewValue, dst)
+}
+
+// OldValueAs decodes the old value of the record into dst.
+func (r OrdersHistoryRecord) OldValueAs(dst interface{}) error {
+	return decodeHistoryValue(HistoryEntityOrder, r.Field, r.OldValue, dst)
+}
+
+// NewCode returns the dictionary code from the new value of the record, e.g. the new status code.
+func (r OrdersHistoryRecord) NewCode() (string, error) {
+	return historyCode(HistoryEntityOrder, r.Field, r.NewValue)
+}
+
+// OldCode returns the dictionary code from the old value of the record, e.g. the previous status code.
+func (r OrdersHistoryRecord) OldCode() (string, error) {
+	return historyCode(HistoryEntityOrder, r.Field, r.OldValue)
+}
+
+// NewValueAs decodes the new value of the record into dst.
+func (r CustomerHistoryRecord) NewValueAs(dst interface{}) error {
+	return decodeHistoryValue(HistoryEntityCustomer, r.Field, r.NewValue, dst)
+}
+
+// OldValueAs decodes the old value of the record into dst.
+func (r CustomerHistoryRecord) OldValueAs(dst interface{}) error {
+	return decodeHistoryValue(HistoryEntityCustomer, r.Field, r.OldValue, dst)
+}
+
+// NewCode returns the dictionary code from the new value of the record.
+func (r CustomerHistoryRecord) NewCode() (string, error) {
+	return historyCode(HistoryEntityCustomer, r.Field, r.NewValue)
+}
+
+// OldCode returns the dictionary code from the old value of the record.
+func (r CustomerHistoryRecord) OldCode() (string, error) {
+	return historyCode(HistoryEntityCustomer, r.Field, r.OldValue)
+}
+
+// NewValueAs decodes the new value of the record into dst.
+func (r CorporateCustomerHistoryRecord) NewValueAs(dst interface{}) error {
+	return decodeHistoryValue(HistoryEntityCorporateCustomer, r.Field, r.NewValue, dst)
+}
+
+// OldValueAs decodes the old value of the record into dst.
+func (r CorporateCustomerHistoryRecord) OldValueAs(dst interface{}) error {
+	return decodeHistoryValue(HistoryEntityCorporateCustomer, r.Field, r.OldValue, dst)
+}
+
+// NewCode returns the dictionary code from the new value of the record.
+func (r CorporateCustomerHistoryRecord) NewCode() (string, error) {
+	return historyCode(HistoryEntityCorporateCustomer, r.Field, r.NewValue)
+}
+
+// OldCode returns the dictionary code from the old value of the record.
+func (r CorporateCustomerHistoryRecord) OldCode() (string, error) {
+	return historyCode(HistoryEntityCorporateCustomer, r.Field, r.OldValue)
+}
+
+// NewValueAs decodes the new value of the record into dst.
+func (r PacksHistoryRecord) NewValueAs(dst interface{}) error {
+	return decodeHistoryValue(HistoryEntityPack, r.Field, r.NewValue, dst)
+}
+
+// OldValueAs decodes the old value of the record into dst.
+func (r PacksHistoryRecord) OldValueAs(dst interface{}) error {
+	return decodeHistoryValue(HistoryEntityPack, r.Field, r.OldValue, dst)
+}
+
+// NewCode returns the dictionary code from the new value of the record, e.g. the new store code.
+func (r PacksHistoryRecord) NewCode() (string, error) {
+	return historyCode(HistoryEntityPack, r.Field, r.NewValue)
+}
+
+// OldCode returns the dictionary code from the old value of the record.
+func (r PacksHistoryRecord) OldCode() (string, error) {
+	return historyCode(HistoryEntityPack, r.Field, r.OldValue)
+}
@@ -0,0 +1,120 @@
+package retailcrm
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrdersHistoryRecord_Code(t *testing.T) {
+	record := OrdersHistoryRecord{
+		Field:    "status",
+		OldValue: map[string]interface{}{"code": "new"},
+		NewValue: map[string]interface{}{"code": "complete"},
+	}
+
+	code, err := record.NewCode()
+	require.NoError(t, err)
+	assert.Equal(t, "complete", code)
+
+	code, err = record.OldCode()
+	require.NoError(t, err)
+	assert.Equal(t, "new", code)
+
+	var value HistoryCodeValue
+	require.NoError(t, record.NewValueAs(&value))
+	assert.Equal(t, "complete", value.Code)
+
+	var number float64
+	assert.ErrorIs(t, record.NewValueAs(&number), ErrHistoryValueType)
+}
+
+func TestOrdersHistoryRecord_ValueAs(t *testing.T) {
+	var amount float32
+	record := OrdersHistoryRecord{Field: "payments.amount", NewValue: 100.5}
+	require.NoError(t, record.NewValueAs(&amount))
+	assert.Equal(t, float32(100.5), amount)
+
+	var manager int
+	record = OrdersHistoryRecord{Field: "manager_id", NewValue: map[string]interface{}{"id": float64(12)}}
+	require.NoError(t, record.NewValueAs(&manager))
+	assert.Equal(t, 12, manager)
+
+	manager = 5
+	require.NoError(t, record.OldValueAs(&manager))
+	assert.Equal(t, 0, manager, "Nil value must reset the destination")
+
+	var city string
+	record = OrdersHistoryRecord{Field: "delivery_address.city", NewValue: "Moscow"}
+	require.NoError(t, record.NewValueAs(&city))
+	assert.Equal(t, "Moscow", city)
+
+	var item *OrderItem
+	record = OrdersHistoryRecord{Field: "order_product", NewValue: map[string]interface{}{"id": float64(3)}}
+	require.NoError(t, record.NewValueAs(&item))
+	assert.Equal(t, 3, item.ID)
+
+	var custom interface{}
+	record = OrdersHistoryRecord{Field: "custom_anything", NewValue: []interface{}{"a"}}
+	require.NoError(t, record.NewValueAs(&custom))
+	assert.Equal(t, []interface{}{"a"}, custom)
+}
+
+func TestOrdersHistoryRecord_ValueAsFail(t *testing.T) {
+	var value string
+	record := OrdersHistoryRecord{Field: "statsu", NewValue: "new"}
+	assert.ErrorIs(t, record.NewValueAs(&value), ErrUnknownHistoryField)
+
+	_, err := record.NewCode()
+	assert.ErrorIs(t, err, ErrUnknownHistoryField)
+
+	record = OrdersHistoryRecord{Field: "delivery_address.city", NewValue: "Moscow"}
+	_, err = record.NewCode()
+	assert.ErrorIs(t, err, ErrHistoryValueType)
+
+	var number int
+	assert.ErrorIs(t, record.NewValueAs(&number), ErrHistoryValueType)
+	assert.ErrorIs(t, record.NewValueAs(value), ErrHistoryValueType)
+
+	record = OrdersHistoryRecord{Field: "payments.amount", NewValue: "not a number"}
+	var amount float64
+	var typeErr *json.UnmarshalTypeError
+	err = record.NewValueAs(&amount)
+	assert.ErrorIs(t, err, ErrHistoryValueType)
+	assert.ErrorAs(t, err, &typeErr)
+}
+
+func TestRegisterHistoryField(t *testing.T) {
+	record := PacksHistoryRecord{Field: "track_number", NewValue: "RA123"}
+
+	var track string
+	require.ErrorIs(t, record.NewValueAs(&track), ErrUnknownHistoryField)
+
+	RegisterHistoryField(HistoryEntityPack, "track_number", "")
+	defer func() {
+		historyFieldRegistry.mutex.Lock()
+		delete(historyFieldRegistry.fields[HistoryEntityPack], "track_number")
+		historyFieldRegistry.mutex.Unlock()
+	}()
+
+	require.NoError(t, record.NewValueAs(&track))
+	assert.Equal(t, "RA123", track)
+
+	typ, ok := HistoryFieldType(HistoryEntityPack, "track_number")
+	require.True(t, ok)
+	assert.Equal(t, "string", typ.String())
+}
+
+func TestCustomerHistoryRecord_ValueAs(t *testing.T) {
+	var vip bool
+	record := CustomerHistoryRecord{Field: "vip", NewValue: true}
+	require.NoError(t, record.NewValueAs(&vip))
+	assert.True(t, vip)
+
+	var store string
+	pack := PacksHistoryRecord{Field: "store", NewValue: map[string]interface{}{"code": "main"}}
+	require.NoError(t, pack.NewValueAs(&store))
+	assert.Equal(t, "main", store)
+}
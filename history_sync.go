@@ -0,0 +1,437 @@
+package retailcrm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	// HistoryStreamOrders is the checkpoint key for the orders history.
+	HistoryStreamOrders = "orders"
+	// HistoryStreamCustomers is the checkpoint key for the customers history.
+	HistoryStreamCustomers = "customers"
+	// HistoryStreamCorporateCustomers is the checkpoint key for the corporate customers history.
+	HistoryStreamCorporateCustomers = "customers-corporate"
+	// HistoryStreamPacks is the checkpoint key for the packs history.
+	HistoryStreamPacks = "packs"
+
+	// DefaultHistorySyncInterval is the delay between history polls used by HistorySync.Run.
+	DefaultHistorySyncInterval = time.Minute
+	// DefaultHistorySyncLimit is the page size used by HistorySync.
+	DefaultHistorySyncLimit = 100
+
+	historySyncRetryDelay = time.Second // First delay after the transient error in HistorySync.Run.
+)
+
+// HistoryCheckpointStore persists ID of the last processed history record for every history stream.
+type HistoryCheckpointStore interface {
+	// Load returns ID of the last processed record for the stream. It must return 0 if there is no checkpoint yet.
+	Load(ctx context.Context, stream string) (int, error)
+	// Save persists ID of the last processed record for the stream.
+	Save(ctx context.Context, stream string, lastID int) error
+}
+
+// MemoryCheckpointStore keeps checkpoints in memory. Checkpoints are lost when the process exits.
+// Zero value is ready to use.
+type MemoryCheckpointStore struct {
+	checkpoints map[string]int
+	mutex       sync.RWMutex
+}
+
+// NewMemoryCheckpointStore instantiates new MemoryCheckpointStore.
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{checkpoints: map[string]int{}}
+}
+
+// Load returns ID of the last processed record for the stream.
+func (s *MemoryCheckpointStore) Load(_ context.Context, stream string) (int, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.checkpoints[stream], nil
+}
+
+// Save persists ID of the last processed record for the stream.
+func (s *MemoryCheckpointStore) Save(_ context.Context, stream string, lastID int) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.checkpoints == nil {
+		s.checkpoints = map[string]int{}
+	}
+
+	s.checkpoints[stream] = lastID
+	return nil
+}
+
+// FileCheckpointStore keeps checkpoints for all streams in a single JSON file.
+// The file is replaced atomically on every save.
+type FileCheckpointStore struct {
+	path  string
+	mutex sync.Mutex
+}
+
+// NewFileCheckpointStore instantiates new FileCheckpointStore. The file will be created on the first save.
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{path: path}
+}
+
+// Load returns ID of the last processed record for the stream.
+func (s *FileCheckpointStore) Load(_ context.Context, stream string) (int, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	checkpoints, err := s.read()
+	if err != nil {
+		return 0, err
+	}
+
+	return checkpoints[stream], nil
+}
+
+// Save persists ID of the last processed record for the stream.
+func (s *FileCheckpointStore) Save(_ context.Context, stream string, lastID int) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	checkpoints, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	checkpoints[stream] = lastID
+
+	data, err := json.Marshal(checkpoints)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path)
+}
+
+func (s *FileCheckpointStore) read() (map[string]int, error) {
+	checkpoints := map[string]int{}
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return checkpoints, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) == 0 {
+		return checkpoints, nil
+	}
+
+	if err := json.Unmarshal(data, &checkpoints); err != nil {
+		return nil, err
+	}
+
+	return checkpoints, nil
+}
+
+// HistorySync polls history of the entities starting from the last saved checkpoint and passes every new record
+// to the registered handler. Checkpoint is saved only after the record was processed successfully, which means that
+// records are delivered at least once: the same record can be received again after a failure or a restart.
+//
+// Example:
+//
+//	var client = retailcrm.New("https://demo.url", "09jIJ")
+//
+//	sync := retailcrm.NewHistorySync(client, retailcrm.NewFileCheckpointStore("history.json")).
+//		OnOrders(func(ctx context.Context, record retailcrm.OrdersHistoryRecord) error {
+//			log.Printf("%d: %s\n", record.ID, record.Field)
+//			return nil
+//		})
+//
+//	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+//	defer cancel()
+//
+//	if err := sync.Run(ctx); err != nil {
+//		log.Fatalf("error: %s", err)
+//	}
+type HistorySync struct {
+	client   *Client
+	store    HistoryCheckpointStore
+	streams  []historyStream
+	interval time.Duration
+	limit    int
+}
+
+type historyStream struct {
+	name string
+	run  func(ctx context.Context, sinceID int) error
+}
+
+// NewHistorySync instantiates new HistorySync. Use On* methods to register handlers for the history streams.
+func NewHistorySync(client *Client, store HistoryCheckpointStore) *HistorySync {
+	return &HistorySync{
+		client:   client,
+		store:    store,
+		interval: DefaultHistorySyncInterval,
+		limit:    DefaultHistorySyncLimit,
+	}
+}
+
+// WithInterval sets the delay between history polls. DefaultHistorySyncInterval is used if the interval
+// is not positive.
+func (s *HistorySync) WithInterval(interval time.Duration) *HistorySync {
+	if interval <= 0 {
+		interval = DefaultHistorySyncInterval
+	}
+
+	s.interval = interval
+	return s
+}
+
+// WithLimit sets the amount of records which will be requested at once.
+func (s *HistorySync) WithLimit(limit int) *HistorySync {
+	s.limit = limit
+	return s
+}
+
+// OnOrders registers handler for the orders history.
+func (s *HistorySync) OnOrders(handler func(context.Context, OrdersHistoryRecord) error) *HistorySync {
+	return s.register(HistoryStreamOrders, func(ctx context.Context, sinceID int) error {
+		return syncHistoryStream(ctx, s, HistoryStreamOrders, sinceID,
+			func(ctx context.Context, sinceID int) ([]OrdersHistoryRecord, *Pagination, int, error) {
+				resp, status, err := s.client.OrdersHistoryCtx(ctx, OrdersHistoryRequest{
+					Filter: OrdersHistoryFilter{SinceID: sinceID},
+					Limit:  s.limit,
+				})
+				return resp.History, resp.Pagination, status, err
+			},
+			func(record OrdersHistoryRecord) int { return record.ID },
+			handler,
+		)
+	})
+}
+
+// OnCustomers registers handler for the customers history.
+func (s *HistorySync) OnCustomers(handler func(context.Context, CustomerHistoryRecord) error) *HistorySync {
+	return s.register(HistoryStreamCustomers, func(ctx context.Context, sinceID int) error {
+		return syncHistoryStream(ctx, s, HistoryStreamCustomers, sinceID,
+			func(ctx context.Context, sinceID int) ([]CustomerHistoryRecord, *Pagination, int, error) {
+				resp, status, err := s.client.CustomersHistoryCtx(ctx, CustomersHistoryRequest{
+					Filter: CustomersHistoryFilter{SinceID: sinceID},
+					Limit:  s.limit,
+				})
+				return resp.History, resp.Pagination, status, err
+			},
+			func(record CustomerHistoryRecord) int { return record.ID },
+			handler,
+		)
+	})
+}
+
+// OnCorporateCustomers registers handler for the corporate customers history.
+func (s *HistorySync) OnCorporateCustomers(
+	handler func(context.Context, CorporateCustomerHistoryRecord) error,
+) *HistorySync {
+	return s.register(HistoryStreamCorporateCustomers, func(ctx context.Context, sinceID int) error {
+		return syncHistoryStream(ctx, s, HistoryStreamCorporateCustomers, sinceID,
+			func(ctx context.Context, sinceID int) ([]CorporateCustomerHistoryRecord, *Pagination, int, error) {
+				resp, status, err := s.client.CorporateCustomersHistoryCtx(ctx, CorporateCustomersHistoryRequest{
+					Filter: CorporateCustomersHistoryFilter{SinceID: sinceID},
+					Limit:  s.limit,
+				})
+				return resp.History, resp.Pagination, status, err
+			},
+			func(record CorporateCustomerHistoryRecord) int { return record.ID },
+			handler,
+		)
+	})
+}
+
+// OnPacks registers handler for the packs history.
+func (s *HistorySync) OnPacks(handler func(context.Context, PacksHistoryRecord) error) *HistorySync {
+	return s.register(HistoryStreamPacks, func(ctx context.Context, sinceID int) error {
+		return syncHistoryStream(ctx, s, HistoryStreamPacks, sinceID,
+			func(ctx context.Context, sinceID int) ([]PacksHistoryRecord, *Pagination, int, error) {
+				resp, status, err := s.client.PacksHistoryCtx(ctx, PacksHistoryRequest{
+					Filter: OrdersHistoryFilter{SinceID: sinceID},
+					Limit:  s.limit,
+				})
+				return resp.History, resp.Pagination, status, err
+			},
+			func(record PacksHistoryRecord) int { return record.ID },
+			handler,
+		)
+	})
+}
+
+func (s *HistorySync) register(name string, run func(ctx context.Context, sinceID int) error) *HistorySync {
+	s.streams = append(s.streams, historyStream{name: name, run: run})
+	return s
+}
+
+// SyncOnce processes all new records from every registered stream and returns.
+func (s *HistorySync) SyncOnce(ctx context.Context) error {
+	for _, stream := range s.streams {
+		sinceID, err := s.store.Load(ctx, stream.name)
+		if err != nil {
+			return err
+		}
+
+		if err := stream.run(ctx, sinceID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Run polls history until the context is canceled. Transient API errors (network errors, 5xx responses and rate
+// limiting) are retried with the exponential backoff up to the poll interval. Run returns nil if it was stopped
+// by the context and the first other error otherwise, e.g. the handler or the checkpoint store error. Records which
+// were processed before the error or cancellation are checkpointed.
+func (s *HistorySync) Run(ctx context.Context) error {
+	failures := 0
+
+	for {
+		err := s.SyncOnce(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		delay := s.interval
+
+		var transient *historyTransientError
+		switch {
+		case errors.As(err, &transient):
+			delay = historyRetryDelay(failures, s.interval)
+			failures++
+		case err != nil:
+			return err
+		default:
+			failures = 0
+		}
+
+		if err := sleepWithContext(ctx, delay); err != nil {
+			return nil
+		}
+	}
+}
+
+// historyRetryDelay returns the delay after the transient error which is doubled after every failure.
+func historyRetryDelay(failures int, interval time.Duration) time.Duration {
+	delay := historySyncRetryDelay
+	for i := 0; i < failures && delay < interval; i++ {
+		delay *= 2
+	}
+
+	if delay > interval {
+		return interval
+	}
+
+	return delay
+}
+
+// historyTransientError wraps the history request error which can be retried.
+type historyTransientError struct {
+	err error
+}
+
+func (e *historyTransientError) Error() string {
+	return e.err.Error()
+}
+
+func (e *historyTransientError) Unwrap() error {
+	return e.err
+}
+
+// syncHistoryStream requests history pages starting from sinceID and passes every record to the handler.
+func syncHistoryStream[T any](
+	ctx context.Context,
+	s *HistorySync,
+	name string,
+	sinceID int,
+	fetch func(ctx context.Context, sinceID int) ([]T, *Pagination, int, error),
+	idOf func(T) int,
+	handler func(context.Context, T) error,
+) error {
+	lastID := sinceID
+
+	for {
+		records, pagination, status, err := fetch(ctx, lastID)
+		if err != nil {
+			if errors.Is(err, ErrRateLimited) || ClassifyRetry(status, err) != RetryReasonNone {
+				return &historyTransientError{err: err}
+			}
+
+			return err
+		}
+
+		processed := lastID
+		for _, record := range records {
+			if err := ctx.Err(); err != nil {
+				return s.checkpoint(ctx, name, lastID, processed, err)
+			}
+
+			if err := handler(ctx, record); err != nil {
+				return s.checkpoint(ctx, name, lastID, processed, err)
+			}
+
+			processed = idOf(record)
+		}
+
+		if err := s.checkpoint(ctx, name, lastID, processed, nil); err != nil {
+			return err
+		}
+
+		if len(records) == 0 || processed == lastID {
+			return nil
+		}
+
+		lastID = processed
+
+		if pagination == nil || pagination.TotalPageCount <= 1 {
+			return nil
+		}
+	}
+}
+
+// checkpoint saves the processed ID if it was changed and returns the provided error or the saving error.
+// It uses context.Background() if the provided context is already canceled, so progress is not lost on shutdown.
+func (s *HistorySync) checkpoint(ctx context.Context, name string, lastID, processed int, cause error) error {
+	if processed == lastID {
+		return cause
+	}
+
+	if ctx.Err() != nil {
+		ctx = context.Background()
+	}
+
+	if err := s.store.Save(ctx, name, processed); err != nil {
+		if cause != nil {
+			return cause
+		}
+
+		return err
+	}
+
+	return cause
+}
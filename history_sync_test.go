@@ -0,0 +1,208 @@
+package retailcrm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gock "gopkg.in/h2non/gock.v1"
+)
+
+func TestMemoryCheckpointStore(t *testing.T) {
+	store := NewMemoryCheckpointStore()
+
+	id, err := store.Load(context.Background(), HistoryStreamOrders)
+	require.NoError(t, err)
+	assert.Equal(t, 0, id)
+
+	require.NoError(t, store.Save(context.Background(), HistoryStreamOrders, 10))
+	require.NoError(t, store.Save(context.Background(), HistoryStreamCustomers, 20))
+
+	id, err = store.Load(context.Background(), HistoryStreamOrders)
+	require.NoError(t, err)
+	assert.Equal(t, 10, id)
+}
+
+func TestMemoryCheckpointStore_ZeroValue(t *testing.T) {
+	var store MemoryCheckpointStore
+
+	require.NoError(t, store.Save(context.Background(), HistoryStreamOrders, 10))
+
+	id, err := store.Load(context.Background(), HistoryStreamOrders)
+	require.NoError(t, err)
+	assert.Equal(t, 10, id)
+}
+
+func TestFileCheckpointStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+	store := NewFileCheckpointStore(path)
+
+	id, err := store.Load(context.Background(), HistoryStreamOrders)
+	require.NoError(t, err)
+	assert.Equal(t, 0, id)
+
+	require.NoError(t, store.Save(context.Background(), HistoryStreamOrders, 10))
+	require.NoError(t, store.Save(context.Background(), HistoryStreamPacks, 30))
+
+	reopened := NewFileCheckpointStore(path)
+
+	id, err = reopened.Load(context.Background(), HistoryStreamOrders)
+	require.NoError(t, err)
+	assert.Equal(t, 10, id)
+
+	id, err = reopened.Load(context.Background(), HistoryStreamPacks)
+	require.NoError(t, err)
+	assert.Equal(t, 30, id)
+}
+
+func TestHistorySync_SyncOnce(t *testing.T) {
+	defer gock.OffAll()
+
+	gock.New(crmURL).
+		Get("/api/v5/orders/history").
+		MatchParam("filter[sinceId]", "5").
+		Reply(http.StatusOK).
+		BodyString(`{"success": true, "pagination": {"limit": 2, "totalCount": 3, "currentPage": 1, "totalPageCount": 2},
+			"history": [{"id": 6, "field": "status"}, {"id": 7, "field": "manager_id"}]}`)
+
+	gock.New(crmURL).
+		Get("/api/v5/orders/history").
+		MatchParam("filter[sinceId]", "7").
+		Reply(http.StatusOK).
+		BodyString(`{"success": true, "pagination": {"limit": 2, "totalCount": 1, "currentPage": 1, "totalPageCount": 1},
+			"history": [{"id": 8, "field": "status"}]}`)
+
+	store := NewMemoryCheckpointStore()
+	require.NoError(t, store.Save(context.Background(), HistoryStreamOrders, 5))
+
+	var ids []int
+	sync := NewHistorySync(client(), store).
+		WithLimit(2).
+		OnOrders(func(_ context.Context, record OrdersHistoryRecord) error {
+			ids = append(ids, record.ID)
+			return nil
+		})
+
+	require.NoError(t, sync.SyncOnce(context.Background()))
+	assert.Equal(t, []int{6, 7, 8}, ids)
+	assert.True(t, gock.IsDone())
+
+	lastID, err := store.Load(context.Background(), HistoryStreamOrders)
+	require.NoError(t, err)
+	assert.Equal(t, 8, lastID)
+}
+
+func TestHistorySync_HandlerError(t *testing.T) {
+	defer gock.OffAll()
+
+	gock.New(crmURL).
+		Get("/api/v5/customers/history").
+		Reply(http.StatusOK).
+		BodyString(`{"success": true, "pagination": {"limit": 100, "totalCount": 3, "currentPage": 1, "totalPageCount": 1},
+			"history": [{"id": 1}, {"id": 2}, {"id": 3}]}`)
+
+	handlerErr := errors.New("cannot apply")
+	store := NewMemoryCheckpointStore()
+	sync := NewHistorySync(client(), store).
+		OnCustomers(func(_ context.Context, record CustomerHistoryRecord) error {
+			if record.ID == 3 {
+				return handlerErr
+			}
+
+			return nil
+		})
+
+	require.ErrorIs(t, sync.SyncOnce(context.Background()), handlerErr)
+
+	lastID, err := store.Load(context.Background(), HistoryStreamCustomers)
+	require.NoError(t, err)
+	assert.Equal(t, 2, lastID, "Failed record must be received again on the next sync")
+}
+
+func TestHistorySync_Run(t *testing.T) {
+	defer gock.OffAll()
+
+	gock.New(crmURL).
+		Get("/api/v5/orders/packs/history").
+		Reply(http.StatusOK).
+		BodyString(`{"success": true, "pagination": {"totalPageCount": 1}, "history": [{"id": 1}]}`)
+
+	gock.New(crmURL).
+		Get("/api/v5/orders/packs/history").
+		MatchParam("filter[sinceId]", "1").
+		Persist().
+		Reply(http.StatusOK).
+		BodyString(`{"success": true, "pagination": {"totalPageCount": 0}, "history": []}`)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := NewMemoryCheckpointStore()
+	calls := 0
+	sync := NewHistorySync(client(), store).
+		WithInterval(10 * time.Millisecond).
+		OnPacks(func(_ context.Context, record PacksHistoryRecord) error {
+			calls++
+			cancel()
+			return nil
+		})
+
+	require.NoError(t, sync.Run(ctx))
+	assert.Equal(t, 1, calls)
+
+	lastID, err := store.Load(context.Background(), HistoryStreamPacks)
+	require.NoError(t, err)
+	assert.Equal(t, 1, lastID)
+}
+
+func TestHistorySync_RunRetriesTransientErrors(t *testing.T) {
+	defer gock.OffAll()
+
+	gock.New(crmURL).
+		Get("/api/v5/orders/packs/history").
+		Reply(http.StatusInternalServerError).
+		BodyString(`{"success": false, "errorMsg": "Internal error"}`)
+
+	gock.New(crmURL).
+		Get("/api/v5/orders/packs/history").
+		Reply(http.StatusOK).
+		BodyString(`{"success": true, "pagination": {"totalPageCount": 1}, "history": [{"id": 1}]}`)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	calls := 0
+	sync := NewHistorySync(client(), &MemoryCheckpointStore{}).
+		WithInterval(10 * time.Millisecond).
+		OnPacks(func(_ context.Context, record PacksHistoryRecord) error {
+			calls++
+			cancel()
+			return nil
+		})
+
+	require.NoError(t, sync.Run(ctx))
+	assert.Equal(t, 1, calls)
+}
+
+func TestHistorySync_WithInterval(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	sync := NewHistorySync(client(), NewMemoryCheckpointStore()).WithInterval(0)
+	assert.Equal(t, DefaultHistorySyncInterval, sync.interval)
+	assert.NoError(t, sync.Run(ctx))
+
+	assert.Equal(t, DefaultHistorySyncInterval, sync.WithInterval(-time.Second).interval)
+}
+
+func TestHistoryRetryDelay(t *testing.T) {
+	assert.Equal(t, time.Second, historyRetryDelay(0, time.Minute))
+	assert.Equal(t, 4*time.Second, historyRetryDelay(2, time.Minute))
+	assert.Equal(t, time.Minute, historyRetryDelay(100, time.Minute))
+	assert.Equal(t, 10*time.Millisecond, historyRetryDelay(0, 10*time.Millisecond))
+}
@@ -1,5 +1,17 @@
 package retailcrm
 
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
 // BasicLogger provides basic functionality for logging.
 type BasicLogger interface {
 	Printf(string, ...interface{})
@@ -23,3 +35,297 @@ func DebugLoggerAdapter(logger DebugLogger) BasicLogger {
 func (l *debugLoggerAdapter) Printf(format string, v ...interface{}) {
 	l.logger.Debugf(format, v...)
 }
+
+// LogLevel is the severity of the structured log record.
+type LogLevel int
+
+const (
+	// LogLevelDebug is used for requests and responses.
+	LogLevelDebug LogLevel = iota
+	// LogLevelInfo is used for informational messages.
+	LogLevelInfo
+	// LogLevelWarn is used for retried attempts.
+	LogLevelWarn
+	// LogLevelError is used for requests which were failed without response.
+	LogLevelError
+)
+
+// String returns text representation of the level.
+func (l LogLevel) String() string {
+	switch l {
+	case LogLevelDebug:
+		return "DEBUG"
+	case LogLevelInfo:
+		return "INFO"
+	case LogLevelWarn:
+		return "WARN"
+	default:
+		return "ERROR"
+	}
+}
+
+// LogField is the key-value pair which is attached to the structured log record.
+type LogField struct {
+	Key   string
+	Value interface{}
+}
+
+// StructuredLogger receives log records with the level and the fields. It is used instead of BasicLogger
+// for the requests and responses if it is set. Records are sent regardless of Client.Debug, so the logger
+// should filter them by the level.
+type StructuredLogger interface {
+	Log(ctx context.Context, level LogLevel, msg string, fields ...LogField)
+}
+
+// WithStructuredLogger sets the StructuredLogger into the Client.
+func (c *Client) WithStructuredLogger(logger StructuredLogger) *Client {
+	c.structuredLogger = logger
+	return c
+}
+
+// WithLogRedactor sets the LogRedactor which is used to hide sensitive data in the logs.
+func (c *Client) WithLogRedactor(redactor *LogRedactor) *Client {
+	c.redactor = redactor
+	return c
+}
+
+const (
+	// DefaultLogBodyLimit is the maximum length of the logged body.
+	DefaultLogBodyLimit = 4096
+
+	redactedValue = "***"
+)
+
+// DefaultRedactedFields contains JSON fields and query parameters which are hidden by the default LogRedactor.
+var DefaultRedactedFields = []string{
+	"phone", "phones", "additionalPhone", "email", "token", "accessToken", "apiKey", "password", "secret",
+}
+
+// LogRedactor hides sensitive data in the logs: it masks values of the configured JSON fields and query parameters
+// (case-insensitive) and truncates long bodies.
+type LogRedactor struct {
+	fields    map[string]struct{}
+	bodyLimit int
+}
+
+// NewLogRedactor instantiates new LogRedactor with DefaultRedactedFields and DefaultLogBodyLimit.
+func NewLogRedactor() *LogRedactor {
+	return (&LogRedactor{fields: map[string]struct{}{}, bodyLimit: DefaultLogBodyLimit}).
+		WithFields(DefaultRedactedFields...)
+}
+
+// WithFields adds the fields which values will be hidden.
+func (r *LogRedactor) WithFields(fields ...string) *LogRedactor {
+	for _, field := range fields {
+		r.fields[strings.ToLower(field)] = struct{}{}
+	}
+
+	return r
+}
+
+// WithBodyLimit sets the maximum length of the logged body. Zero disables truncation.
+func (r *LogRedactor) WithBodyLimit(limit int) *LogRedactor {
+	r.bodyLimit = limit
+	return r
+}
+
+// Body returns the body with hidden sensitive fields. Bodies which are not JSON are only truncated.
+func (r *LogRedactor) Body(body []byte) string {
+	result := string(body)
+
+	var data interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	if decoder.Decode(&data) == nil {
+		if redacted, err := json.Marshal(r.redact(data)); err == nil {
+			result = string(redacted)
+		}
+	}
+
+	if r.bodyLimit > 0 && len(result) > r.bodyLimit {
+		return fmt.Sprintf("%s... (%d bytes truncated)", result[:r.bodyLimit], len(result)-r.bodyLimit)
+	}
+
+	return result
+}
+
+// URL returns the URL with hidden sensitive query parameters. Name of the parameter is the last part
+// in the square brackets, e.g. "filter[email]" is matched by "email".
+func (r *LogRedactor) URL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.RawQuery == "" {
+		return rawURL
+	}
+
+	query := parsed.Query()
+	redacted := false
+
+	for key := range query {
+		name := strings.TrimSuffix(key, "[]")
+		if i := strings.LastIndex(name, "["); i >= 0 {
+			name = strings.TrimSuffix(name[i+1:], "]")
+		}
+
+		if r.matches(name) {
+			query.Set(key, redactedValue)
+			redacted = true
+		}
+	}
+
+	if redacted {
+		parsed.RawQuery = query.Encode()
+	}
+
+	return parsed.String()
+}
+
+// Error returns the error text with hidden sensitive query parameters of the request URL, e.g. when the error
+// is *url.Error returned by the HTTP client.
+func (r *LogRedactor) Error(err error) string {
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return err.Error()
+	}
+
+	return strings.ReplaceAll(err.Error(), urlErr.URL, r.URL(urlErr.URL))
+}
+
+func (r *LogRedactor) matches(field string) bool {
+	_, ok := r.fields[strings.ToLower(field)]
+	return ok
+}
+
+func (r *LogRedactor) redact(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if r.matches(key) {
+				v[key] = redactedValue
+				continue
+			}
+
+			v[key] = r.redact(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = r.redact(item)
+		}
+	}
+
+	return value
+}
+
+// MaskAPIKey returns the API key with only first four characters visible.
+func MaskAPIKey(key string) string {
+	if len(key) <= 8 { // nolint:gomnd
+		return redactedValue
+	}
+
+	return key[:4] + redactedValue
+}
+
+type requestAttemptKey struct{}
+
+func withRequestAttempt(ctx context.Context, attempt uint) context.Context {
+	return context.WithValue(ctx, requestAttemptKey{}, attempt)
+}
+
+func requestAttempt(ctx context.Context) uint {
+	attempt, ok := ctx.Value(requestAttemptKey{}).(uint)
+	if !ok {
+		return 1
+	}
+
+	return attempt
+}
+
+func (c *Client) logRedactor() *LogRedactor {
+	if c.redactor == nil {
+		return defaultLogRedactor
+	}
+
+	return c.redactor
+}
+
+var defaultLogRedactor = NewLogRedactor()
+
+// logRequest writes the request into the StructuredLogger or into the BasicLogger if Client.Debug is enabled.
+func (c *Client) logRequest(req *http.Request) {
+	if c.structuredLogger == nil && !c.Debug {
+		return
+	}
+
+	requestURL := c.logRedactor().URL(req.URL.String())
+
+	if c.structuredLogger == nil {
+		c.writeLog("API Request: %s %s", requestURL, MaskAPIKey(c.Key))
+		return
+	}
+
+	c.structuredLogger.Log(req.Context(), LogLevelDebug, "API request",
+		LogField{Key: "method", Value: req.Method},
+		LogField{Key: "url", Value: requestURL},
+		LogField{Key: "apiKey", Value: MaskAPIKey(c.Key)},
+		LogField{Key: "attempt", Value: requestAttempt(req.Context())},
+	)
+}
+
+// logResponse writes the response body into the StructuredLogger or into the BasicLogger if Client.Debug is enabled.
+func (c *Client) logResponse(ctx context.Context, statusCode int, body []byte, duration time.Duration) {
+	if c.structuredLogger == nil && !c.Debug {
+		return
+	}
+
+	if c.structuredLogger == nil {
+		c.writeLog("API Response: %s", c.logRedactor().Body(body))
+		return
+	}
+
+	c.structuredLogger.Log(ctx, LogLevelDebug, "API response",
+		LogField{Key: "status", Value: statusCode},
+		LogField{Key: "duration", Value: duration},
+		LogField{Key: "attempt", Value: requestAttempt(ctx)},
+		LogField{Key: "body", Value: c.logRedactor().Body(body)},
+	)
+}
+
+// logRetry writes the retried attempt into the StructuredLogger or into the BasicLogger if Client.Debug is enabled.
+func (c *Client) logRetry(ctx context.Context, attempt RetryAttempt, delay time.Duration) {
+	if c.structuredLogger == nil {
+		if c.Debug {
+			c.writeLog("API Error: %s (%d), retrying in %v (attempt %d)",
+				attempt.Reason, attempt.StatusCode, delay, attempt.Attempt)
+		}
+
+		return
+	}
+
+	fields := []LogField{
+		{Key: "reason", Value: attempt.Reason.String()},
+		{Key: "status", Value: attempt.StatusCode},
+		{Key: "delay", Value: delay},
+		{Key: "attempt", Value: attempt.Attempt},
+	}
+
+	if attempt.Err != nil {
+		fields = append(fields, LogField{Key: "error", Value: c.logRedactor().Error(attempt.Err)})
+	}
+
+	c.structuredLogger.Log(ctx, LogLevelWarn, "API request will be retried", fields...)
+}
+
+// logTransportError writes the request which was failed without response into the StructuredLogger.
+func (c *Client) logTransportError(req *http.Request, err error, duration time.Duration) {
+	if c.structuredLogger == nil {
+		return
+	}
+
+	c.structuredLogger.Log(req.Context(), LogLevelError, "API request failed",
+		LogField{Key: "method", Value: req.Method},
+		LogField{Key: "url", Value: c.logRedactor().URL(req.URL.String())},
+		LogField{Key: "duration", Value: duration},
+		LogField{Key: "attempt", Value: requestAttempt(req.Context())},
+		LogField{Key: "error", Value: c.logRedactor().Error(err)},
+	)
+}
@@ -0,0 +1,45 @@
+//go:build go1.21
+
+package retailcrm
+
+import (
+	"context"
+	"log/slog"
+)
+
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+// SlogLogger returns StructuredLogger which writes records into the provided *slog.Logger.
+//
+// Example:
+//
+//	client := retailcrm.New("https://demo.url", "09jIJ").
+//		WithStructuredLogger(retailcrm.SlogLogger(slog.Default()))
+func SlogLogger(logger *slog.Logger) StructuredLogger {
+	return &slogLogger{logger: logger}
+}
+
+// Log writes the record into *slog.Logger.
+func (l *slogLogger) Log(ctx context.Context, level LogLevel, msg string, fields ...LogField) {
+	attrs := make([]slog.Attr, 0, len(fields))
+	for _, field := range fields {
+		attrs = append(attrs, slog.Any(field.Key, field.Value))
+	}
+
+	l.logger.LogAttrs(ctx, slogLevel(level), msg, attrs...)
+}
+
+func slogLevel(level LogLevel) slog.Level {
+	switch level {
+	case LogLevelDebug:
+		return slog.LevelDebug
+	case LogLevelInfo:
+		return slog.LevelInfo
+	case LogLevelWarn:
+		return slog.LevelWarn
+	default:
+		return slog.LevelError
+	}
+}
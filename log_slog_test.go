@@ -0,0 +1,53 @@
+//go:build go1.21
+
+package retailcrm
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gock "gopkg.in/h2non/gock.v1"
+)
+
+func TestSlogLogger(t *testing.T) {
+	defer gock.OffAll()
+
+	gock.New(crmURL).
+		Get("/api/v5/customers").
+		Reply(http.StatusOK).
+		BodyString(`{"success": true, "customers": [{"id": 1, "email": "john@example.com"}]}`)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	c := client().WithStructuredLogger(SlogLogger(logger))
+	c.Key = "0123456789abcdef"
+
+	_, _, err := c.Customers(CustomersRequest{Filter: CustomersFilter{Email: "john@example.com"}})
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var request, response map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &request))
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &response))
+
+	assert.Equal(t, "DEBUG", request["level"])
+	assert.Equal(t, "API request", request["msg"])
+	assert.Equal(t, "0123***", request["apiKey"])
+	assert.Equal(t, float64(1), request["attempt"])
+	assert.NotContains(t, request["url"], "john@example.com")
+
+	assert.Equal(t, "API response", response["msg"])
+	assert.Equal(t, float64(http.StatusOK), response["status"])
+	assert.Contains(t, response, "duration")
+	assert.NotContains(t, response["body"], "john@example.com")
+	assert.NotContains(t, buf.String(), c.Key)
+}
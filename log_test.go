@@ -1,10 +1,17 @@
 package retailcrm
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gock "gopkg.in/h2non/gock.v1"
 )
 
 type wrappedLogger struct {
@@ -22,3 +29,163 @@ func TestDebugLoggerAdapter_Printf(t *testing.T) {
 
 	assert.Equal(t, "Test message #1", wrapped.lastMessage)
 }
+
+type recordedLog struct {
+	level  LogLevel
+	msg    string
+	fields map[string]interface{}
+}
+
+type recordingLogger struct {
+	records []recordedLog
+}
+
+func (l *recordingLogger) Log(_ context.Context, level LogLevel, msg string, fields ...LogField) {
+	record := recordedLog{level: level, msg: msg, fields: map[string]interface{}{}}
+	for _, field := range fields {
+		record.fields[field.Key] = field.Value
+	}
+
+	l.records = append(l.records, record)
+}
+
+type printfLogger struct {
+	lines []string
+}
+
+func (l *printfLogger) Printf(format string, v ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+}
+
+func TestLogRedactor_Body(t *testing.T) {
+	redactor := NewLogRedactor().WithFields("firstName")
+
+	body := redactor.Body([]byte(`{"success": true, "customer": {"firstName": "John", "id": 12345678901234567890,
+		"phones": [{"number": "+79990000000"}], "contragent": {"legalName": "LLC"}, "Email": "john@example.com"}}`))
+
+	assert.NotContains(t, body, "John")
+	assert.NotContains(t, body, "+79990000000")
+	assert.NotContains(t, body, "john@example.com")
+	assert.Contains(t, body, "LLC")
+	assert.Contains(t, body, "12345678901234567890", "Numbers must not lose precision")
+
+	assert.Equal(t, "not json", redactor.Body([]byte("not json")))
+	assert.Equal(t, "abcde... (5 bytes truncated)", NewLogRedactor().WithBodyLimit(5).Body([]byte("abcdefghij")))
+}
+
+func TestLogRedactor_URL(t *testing.T) {
+	redactor := NewLogRedactor()
+
+	assert.Equal(t, "https://demo.url/api/v5/customers?filter%5Bemail%5D=%2A%2A%2A&limit=20",
+		redactor.URL("https://demo.url/api/v5/customers?filter[email]=john@example.com&limit=20"))
+	assert.Equal(t, "https://demo.url/api/v5/orders?limit=20", redactor.URL("https://demo.url/api/v5/orders?limit=20"))
+}
+
+func TestLogRedactor_Error(t *testing.T) {
+	redactor := NewLogRedactor()
+	err := fmt.Errorf("request failed: %w", &url.Error{
+		Op:  "Get",
+		URL: "https://demo.url/api/v5/customers?filter[email]=john@example.com",
+		Err: errors.New("connection refused"),
+	})
+
+	assert.Equal(t,
+		`request failed: Get "https://demo.url/api/v5/customers?filter%5Bemail%5D=%2A%2A%2A": connection refused`,
+		redactor.Error(err))
+	assert.Equal(t, "connection refused", redactor.Error(errors.New("connection refused")))
+}
+
+func TestMaskAPIKey(t *testing.T) {
+	assert.Equal(t, "09jI***", MaskAPIKey("09jIJ09j0JKhgyfvyuUIKhiugF"))
+	assert.Equal(t, "***", MaskAPIKey("short"))
+}
+
+func TestClient_DebugLogMasksKey(t *testing.T) {
+	defer gock.OffAll()
+
+	gock.New(crmURL).
+		Get("/api/v5/customers").
+		Reply(http.StatusOK).
+		BodyString(`{"success": true, "customers": [{"id": 1, "email": "john@example.com"}]}`)
+
+	logger := &printfLogger{}
+	c := client().WithLogger(logger)
+	c.Key = "0123456789abcdef"
+
+	_, _, err := c.Customers(CustomersRequest{})
+	require.NoError(t, err)
+
+	require.Len(t, logger.lines, 2)
+	assert.Contains(t, logger.lines[0], "API Request: ")
+	assert.Contains(t, logger.lines[0], "0123***")
+	assert.NotContains(t, strings.Join(logger.lines, "\n"), c.Key)
+	assert.NotContains(t, logger.lines[1], "john@example.com")
+}
+
+func TestClient_WithStructuredLogger(t *testing.T) {
+	defer gock.OffAll()
+
+	gock.New(crmURL).
+		Get("/api/v5/orders").
+		Reply(http.StatusServiceUnavailable).
+		BodyString(`{"success": false}`)
+
+	gock.New(crmURL).
+		Get("/api/v5/orders").
+		Reply(http.StatusOK).
+		BodyString(`{"success": true}`)
+
+	logger := &recordingLogger{}
+	c := client().WithStructuredLogger(logger)
+	c.Debug = false
+	c.EnableRateLimiter(2)
+
+	_, _, err := c.Orders(OrdersRequest{})
+	require.NoError(t, err)
+
+	var messages []string
+	for _, record := range logger.records {
+		messages = append(messages, record.level.String()+" "+record.msg)
+	}
+
+	assert.Equal(t, []string{
+		"DEBUG API request", "DEBUG API response", "WARN API request will be retried",
+		"DEBUG API request", "DEBUG API response",
+	}, messages)
+	assert.Equal(t, uint(2), logger.records[3].fields["attempt"])
+	assert.Equal(t, http.StatusOK, logger.records[4].fields["status"])
+	assert.Equal(t, "rate limited", logger.records[2].fields["reason"])
+}
+
+func TestClient_StructuredLoggerFailures(t *testing.T) {
+	defer gock.OffAll()
+
+	gock.New(crmURL).
+		Get("/api/v5/orders").
+		Reply(http.StatusInternalServerError).
+		BodyString(`{"success": false, "errorMsg": "Internal error"}`)
+
+	gock.New(crmURL).
+		Get("/api/v5/customers").
+		ReplyError(errors.New("connection refused"))
+
+	logger := &recordingLogger{}
+	c := client().WithStructuredLogger(logger)
+	c.Debug = false
+
+	_, status, err := c.Orders(OrdersRequest{})
+	require.Error(t, err)
+	assert.Equal(t, http.StatusInternalServerError, status)
+
+	_, _, err = c.Customers(CustomersRequest{Filter: CustomersFilter{Email: "john@example.com"}})
+	require.Error(t, err)
+
+	require.Len(t, logger.records, 4)
+	assert.Equal(t, "API response", logger.records[1].msg)
+	assert.Equal(t, http.StatusInternalServerError, logger.records[1].fields["status"])
+	assert.Contains(t, logger.records[1].fields["body"], "Internal error")
+
+	assert.Equal(t, "API request failed", logger.records[3].msg)
+	assert.Contains(t, logger.records[3].fields["error"], "connection refused")
+	assert.NotContains(t, logger.records[3].fields["error"], "john@example.com")
+}
@@ -0,0 +1,246 @@
+package retailcrm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+)
+
+// MgTransportActionRequest is sent to the MgTransportActions URLs. The request is read from the JSON body
+// or from the query and form parameters.
+type MgTransportActionRequest struct {
+	ClientID       string `json:"clientId"`
+	ChannelID      int    `json:"channelId,omitempty"`
+	CustomerID     int    `json:"customerId,omitempty"`
+	ExternalUserID string `json:"externalUserId,omitempty"`
+	ExternalChatID string `json:"externalChatId,omitempty"`
+}
+
+// MgTransportProvider is implemented by the messenger transport.
+type MgTransportProvider interface {
+	// Visits returns the site visits of the chat customer. It is called by MgTransportActions.Visits.
+	Visits(ctx context.Context, req MgTransportActionRequest) (ChatVisitsResponse, error)
+	// Online returns the last online time of the chat customer. It is called by MgTransportActions.Online.
+	Online(ctx context.Context, req MgTransportActionRequest) (ChatCustomerOnline, error)
+	// SyncTemplates uploads the channel templates, e.g. using EditMGChannelTemplate.
+	// It is called by MgTransportActions.ManualTemplatesSync.
+	SyncTemplates(ctx context.Context, req MgTransportActionRequest) error
+}
+
+// MgTransportWebhook is the message which MG sends to MgTransport.WebhookURL, e.g. when the manager sends
+// the message to the channel. Data depends on the Type and can be decoded with Decode.
+type MgTransportWebhook struct {
+	Type string                 `json:"type"`
+	Meta MgTransportWebhookMeta `json:"meta"`
+	Data json.RawMessage        `json:"data"`
+}
+
+// MgTransportWebhookMeta contains the ID and the Unix timestamp of the webhook.
+type MgTransportWebhookMeta struct {
+	ID        uint64 `json:"id"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// Decode unmarshals the webhook data into the provided value.
+func (w MgTransportWebhook) Decode(v interface{}) error {
+	return json.Unmarshal(w.Data, v)
+}
+
+// MgTransportWebhookProvider can be implemented by the MgTransportProvider which handles MgTransport.WebhookURL.
+type MgTransportWebhookProvider interface {
+	// Webhook processes the webhook and returns the response for MG, e.g. with the ID of the sent message.
+	// Empty JSON object is responded if the result is nil.
+	Webhook(ctx context.Context, webhook MgTransportWebhook) (interface{}, error)
+}
+
+// MgTransportHandler is the http.Handler which serves the actions and the webhooks of the messenger transport.
+// Request paths are matched with MgTransport.Actions and MgTransport.WebhookURL. ChatVisitsResponse
+// and ChatCustomerOnline are responded as is, the templates synchronization is responded with SuccessfulResponse.
+// Provider errors are responded with 500 status code and a generic message.
+//
+// Webhooks are served only if the provider implements MgTransportWebhookProvider. MG doesn't send the clientId
+// with the webhooks, so they are rejected with 403 status code until WithWebhookVerifier is called.
+//
+// Example:
+//
+//	transport := retailcrm.MgTransport{
+//		WebhookURL: "https://transport.example.com/webhook",
+//		Actions: &retailcrm.MgTransportActions{
+//			Visits:              "/actions/visits",
+//			Online:              "/actions/online",
+//			ManualTemplatesSync: "/actions/templates",
+//		},
+//	}
+//
+//	handler := retailcrm.NewMgTransportHandler("client-id", transport, provider).
+//		WithWebhookVerifier(func(r *http.Request) (bool, error) {
+//			return r.Header.Get("X-Transport-Token") == token, nil
+//		})
+//
+//	http.Handle("/actions/", handler)
+//	http.Handle("/webhook", handler)
+type MgTransportHandler struct {
+	actions  map[string]string
+	provider MgTransportProvider
+	checker  ClientIDChecker
+	verifier func(r *http.Request) (bool, error)
+}
+
+// NewMgTransportHandler instantiates new MgTransportHandler which accepts requests with the provided clientId.
+func NewMgTransportHandler(clientID string, transport MgTransport, provider MgTransportProvider) *MgTransportHandler {
+	actions := map[string]string{}
+
+	if transport.Actions != nil {
+		for action, path := range map[string]string{
+			"visits":              transport.Actions.Visits,
+			"online":              transport.Actions.Online,
+			"manualTemplatesSync": transport.Actions.ManualTemplatesSync,
+		} {
+			if path != "" {
+				actions[normalizeActionPath(path)] = action
+			}
+		}
+	}
+
+	if _, ok := provider.(MgTransportWebhookProvider); ok && transport.WebhookURL != "" {
+		actions[normalizeActionPath(transport.WebhookURL)] = "webhook"
+	}
+
+	return &MgTransportHandler{actions: actions, provider: provider, checker: staticClientID(clientID)}
+}
+
+// WithClientIDChecker replaces the clientId check, e.g. with StoreClientIDChecker to accept requests from all
+// the accounts in the ConnectionStore.
+func (h *MgTransportHandler) WithClientIDChecker(checker ClientIDChecker) *MgTransportHandler {
+	h.checker = checker
+	return h
+}
+
+// WithWebhookVerifier sets the function which checks that the webhook is sent by MG, e.g. compares the token
+// in the request with the one which was issued for the transport.
+func (h *MgTransportHandler) WithWebhookVerifier(verifier func(r *http.Request) (bool, error)) *MgTransportHandler {
+	h.verifier = verifier
+	return h
+}
+
+// ServeHTTP routes the request to the MgTransportProvider.
+func (h *MgTransportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	action, ok := h.actions[normalizeActionPath(r.URL.Path)]
+	if !ok {
+		writeErrorResponse(w, http.StatusNotFound, "unknown action")
+		return
+	}
+
+	if action == "webhook" {
+		h.serveWebhook(w, r)
+		return
+	}
+
+	req, err := parseMgTransportActionRequest(r)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !checkClientID(w, r, h.checker, req.ClientID) {
+		return
+	}
+
+	var result interface{}
+
+	switch action {
+	case "visits":
+		result, err = h.provider.Visits(r.Context(), req)
+	case "online":
+		result, err = h.provider.Online(r.Context(), req)
+	default:
+		result, err = SuccessfulResponse{Success: true}, h.provider.SyncTemplates(r.Context(), req)
+	}
+
+	if err != nil {
+		writeErrorResponse(w, http.StatusInternalServerError, internalErrorMessage)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, result)
+}
+
+func (h *MgTransportHandler) serveWebhook(w http.ResponseWriter, r *http.Request) {
+	if h.verifier == nil {
+		writeErrorResponse(w, http.StatusForbidden, "webhook verifier is not configured")
+		return
+	}
+
+	ok, err := h.verifier(r)
+	if err != nil {
+		writeErrorResponse(w, http.StatusInternalServerError, internalErrorMessage)
+		return
+	}
+
+	if !ok {
+		writeErrorResponse(w, http.StatusForbidden, "invalid webhook")
+		return
+	}
+
+	var webhook MgTransportWebhook
+	body, err := io.ReadAll(io.LimitReader(r.Body, DefaultWebhookMaxBodySize))
+	if err == nil {
+		err = json.Unmarshal(body, &webhook)
+	}
+
+	if err != nil || webhook.Type == "" {
+		writeErrorResponse(w, http.StatusBadRequest, "invalid webhook")
+		return
+	}
+
+	result, err := h.provider.(MgTransportWebhookProvider).Webhook(r.Context(), webhook)
+	if err != nil {
+		writeErrorResponse(w, http.StatusInternalServerError, internalErrorMessage)
+		return
+	}
+
+	if result == nil {
+		result = struct{}{}
+	}
+
+	writeJSONResponse(w, http.StatusOK, result)
+}
+
+func parseMgTransportActionRequest(r *http.Request) (MgTransportActionRequest, error) {
+	var req MgTransportActionRequest
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "application/json" {
+		body, err := io.ReadAll(io.LimitReader(r.Body, DefaultWebhookMaxBodySize))
+		if err != nil {
+			return req, err
+		}
+
+		if err := json.Unmarshal(body, &req); err != nil {
+			return req, fmt.Errorf("invalid request: %w", err)
+		}
+
+		return req, nil
+	}
+
+	req.ClientID = r.FormValue("clientId")
+	req.ExternalUserID = r.FormValue("externalUserId")
+	req.ExternalChatID = r.FormValue("externalChatId")
+
+	for field, value := range map[string]*int{"channelId": &req.ChannelID, "customerId": &req.CustomerID} {
+		if raw := r.FormValue(field); raw != "" {
+			id, err := strconv.Atoi(raw)
+			if err != nil {
+				return req, fmt.Errorf("invalid %s: %w", field, err)
+			}
+
+			*value = id
+		}
+	}
+
+	return req, nil
+}
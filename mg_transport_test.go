@@ -0,0 +1,168 @@
+package retailcrm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testMgTransportProvider struct {
+	synced []int
+	err    error
+}
+
+func (p *testMgTransportProvider) Visits(_ context.Context, req MgTransportActionRequest) (ChatVisitsResponse, error) {
+	return ChatVisitsResponse{
+		Device:      ChatDevice{Lang: "en", Browser: "Firefox", OS: "Linux"},
+		Country:     "US",
+		City:        req.ExternalUserID,
+		LastVisit:   ChatLastVisit{CreatedAt: SystemTime(time.Date(2024, 1, 10, 12, 30, 0, 0, time.UTC)), Pages: []ChatVisitedPage{}},
+		CountVisits: 3,
+	}, p.err
+}
+
+func (p *testMgTransportProvider) Online(context.Context, MgTransportActionRequest) (ChatCustomerOnline, error) {
+	return ChatCustomerOnline{LastOnline: SystemTime(time.Date(2024, 1, 10, 12, 30, 0, 0, time.UTC))}, p.err
+}
+
+func (p *testMgTransportProvider) SyncTemplates(_ context.Context, req MgTransportActionRequest) error {
+	p.synced = append(p.synced, req.ChannelID)
+	return p.err
+}
+
+type testMgTransportWebhookProvider struct {
+	testMgTransportProvider
+	webhooks []MgTransportWebhook
+}
+
+func (p *testMgTransportWebhookProvider) Webhook(_ context.Context, webhook MgTransportWebhook) (interface{}, error) {
+	p.webhooks = append(p.webhooks, webhook)
+	if webhook.Type == "message_read" {
+		return nil, p.err
+	}
+
+	return map[string]string{"external_message_id": "ext-1"}, p.err
+}
+
+func testMgTransport() MgTransport {
+	return MgTransport{
+		WebhookURL: "https://transport.example.com/webhook",
+		Actions: &MgTransportActions{
+			Visits:              "https://transport.example.com/actions/visits",
+			Online:              "/actions/online",
+			ManualTemplatesSync: "/actions/templates",
+		},
+	}
+}
+
+func TestMgTransportHandler_Actions(t *testing.T) {
+	provider := &testMgTransportProvider{}
+	handler := NewMgTransportHandler("client", testMgTransport(), provider)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/actions/visits?clientId=client&externalUserId=Boston", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{
+		"device": {"lang": "en", "browser": "Firefox", "os": "Linux"},
+		"country": "US",
+		"city": "Boston",
+		"lastVisit": {"createdAt": "2024-01-10 12:30:00", "source": "", "pages": [], "duration": 0},
+		"countVisits": 3
+	}`, rec.Body.String())
+
+	req := httptest.NewRequest(http.MethodPost, "/actions/online", strings.NewReader(`{"clientId": "client", "externalChatId": "1"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.JSONEq(t, `{"lastOnline": "2024-01-10 12:30:00"}`, rec.Body.String())
+
+	rec, resp := serveWebhook(handler, httptest.NewRequest(http.MethodGet, "/actions/templates?clientId=client&channelId=5", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, resp.Success)
+	assert.Equal(t, []int{5}, provider.synced)
+}
+
+func TestMgTransportHandler_Errors(t *testing.T) {
+	provider := &testMgTransportProvider{}
+	handler := NewMgTransportHandler("client", testMgTransport(), provider)
+
+	rec, _ := serveWebhook(handler, httptest.NewRequest(http.MethodGet, "/webhook?clientId=client", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code, "webhooks must not be served if the provider doesn't handle them")
+
+	rec, resp := serveWebhook(handler, httptest.NewRequest(http.MethodGet, "/actions/online?clientId=other", nil))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Equal(t, ErrInvalidClientID.Error(), resp.ErrorMessage)
+
+	rec, _ = serveWebhook(handler, httptest.NewRequest(http.MethodGet, "/actions/templates?clientId=client&channelId=abc", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Empty(t, provider.synced)
+
+	provider.err = errors.New("channel is not found")
+	rec, resp = serveWebhook(handler, httptest.NewRequest(http.MethodGet, "/actions/visits?clientId=client", nil))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, internalErrorMessage, resp.ErrorMessage, "provider error must not be sent to the CRM")
+}
+
+func TestMgTransportHandler_Webhook(t *testing.T) {
+	provider := &testMgTransportWebhookProvider{}
+	handler := NewMgTransportHandler("client", testMgTransport(), provider).
+		WithWebhookVerifier(func(r *http.Request) (bool, error) {
+			return r.Header.Get("X-Transport-Token") == "token", nil
+		})
+
+	send := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Transport-Token", "token")
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	rec := send(`{"type": "message_sent", "meta": {"id": 7, "timestamp": 1704889800}, "data": {"content": "Hello"}}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"external_message_id": "ext-1"}`, rec.Body.String())
+
+	rec = send(`{"type": "message_read", "meta": {"id": 8}, "data": {}}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{}`, rec.Body.String())
+
+	require.Len(t, provider.webhooks, 2)
+	assert.Equal(t, uint64(7), provider.webhooks[0].Meta.ID)
+
+	var data struct {
+		Content string `json:"content"`
+	}
+	require.NoError(t, provider.webhooks[0].Decode(&data))
+	assert.Equal(t, "Hello", data.Content)
+
+	assert.Equal(t, http.StatusBadRequest, send(`{"meta": {"id": 9}}`).Code)
+
+	provider.err = errors.New("chat is not found")
+	rec = send(`{"type": "message_sent", "meta": {"id": 10}, "data": {}}`)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "chat is not found")
+}
+
+func TestMgTransportHandler_WebhookVerification(t *testing.T) {
+	provider := &testMgTransportWebhookProvider{}
+	body := `{"type": "message_sent", "meta": {"id": 1}, "data": {}}`
+
+	handler := NewMgTransportHandler("client", testMgTransport(), provider)
+	rec, _ := serveWebhook(handler, httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body)))
+	assert.Equal(t, http.StatusForbidden, rec.Code, "webhooks must be rejected without the verifier")
+
+	handler.WithWebhookVerifier(func(*http.Request) (bool, error) { return false, nil })
+	rec, _ = serveWebhook(handler, httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body)))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Empty(t, provider.webhooks)
+}
@@ -0,0 +1,61 @@
+package retailcrm
+
+import (
+	"net/http"
+	"time"
+)
+
+// RoundTripperFunc is an adapter which allows using ordinary functions as http.RoundTripper.
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req).
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps every outgoing request of the Client, including the repeated attempts.
+// Request is created for every attempt and already contains the X-API-KEY header, so middleware may modify it.
+//
+// Example:
+//
+//	func RequestID(next http.RoundTripper) http.RoundTripper {
+//		return retailcrm.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+//			req.Header.Set("X-Request-ID", uuid.NewString())
+//			return next.RoundTrip(req)
+//		})
+//	}
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// Use appends the middlewares to the chain. The first middleware is the outermost one: it receives the request
+// first and the response last.
+func (c *Client) Use(mw ...Middleware) *Client {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.middlewares = append(c.middlewares, mw...)
+	return c
+}
+
+// do sends the request through the middleware chain using the HTTP client.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	req.Header.Set("X-API-KEY", c.Key)
+
+	c.mutex.RLock()
+	middlewares := c.middlewares
+	c.mutex.RUnlock()
+
+	var next http.RoundTripper = RoundTripperFunc(c.httpClient.Do)
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		next = middlewares[i](next)
+	}
+
+	c.logRequest(req)
+
+	started := time.Now()
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		c.logTransportError(req, err, time.Since(started))
+	}
+
+	return resp, err
+}
@@ -0,0 +1,95 @@
+package retailcrm
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gock "gopkg.in/h2non/gock.v1"
+)
+
+func TestClient_Use(t *testing.T) {
+	defer gock.OffAll()
+
+	gock.New(crmURL).
+		Get("/api/v5/orders").
+		MatchHeader("X-API-KEY", "rotated").
+		MatchHeader("X-Request-ID", "req-1").
+		Reply(http.StatusOK).
+		BodyString(`{"success": true}`)
+
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name+":request")
+				resp, err := next.RoundTrip(req)
+				calls = append(calls, name+":response")
+				return resp, err
+			})
+		}
+	}
+
+	c := client().Use(trace("outer"), trace("inner")).Use(func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("X-API-KEY", "rotated")
+			req.Header.Set("X-Request-ID", "req-1")
+			return next.RoundTrip(req)
+		})
+	})
+
+	_, status, err := c.Orders(OrdersRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []string{"outer:request", "inner:request", "inner:response", "outer:response"}, calls)
+	assert.True(t, gock.IsDone())
+}
+
+func TestClient_UseWrapsEveryAttempt(t *testing.T) {
+	defer gock.OffAll()
+
+	gock.New(crmURL).
+		Post("/api/v5/orders/create").
+		Reply(http.StatusServiceUnavailable).
+		BodyString(`{"success": false}`)
+
+	gock.New(crmURL).
+		Post("/api/v5/orders/create").
+		Reply(http.StatusCreated).
+		BodyString(`{"success": true, "id": 1}`)
+
+	attempts := 0
+	c := client().Use(func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			attempts++
+			return next.RoundTrip(req)
+		})
+	})
+	c.EnableRateLimiter(2)
+
+	_, status, err := c.OrderCreate(Order{ExternalID: "ext-1"})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestClient_UseShortCircuit(t *testing.T) {
+	failure := errors.New("circuit is open")
+	c := client().Use(func(http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(*http.Request) (*http.Response, error) {
+			return nil, failure
+		})
+	})
+
+	_, _, err := c.GetRequest("/orders")
+	require.ErrorIs(t, err, failure)
+
+	_, _, err = c.PostRequest("/orders/create", url.Values{})
+	require.ErrorIs(t, err, failure)
+
+	_, _, err = c.GetOrderPlate(ByID, "1", "site", 1)
+	require.ErrorIs(t, err, failure)
+}
@@ -0,0 +1,174 @@
+package retailcrm
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// RequestInfo describes the API request which is being observed.
+type RequestInfo struct {
+	Method     string // API method name, e.g. "OrderCreate". Ctx suffix is omitted. Empty for GetRequest and PostRequest.
+	HTTPMethod string
+	Path       string // Request path without API prefix and query string, e.g. "/orders/create".
+}
+
+// RequestResult contains the result of the observed API request.
+type RequestResult struct {
+	StatusCode int
+	Err        error
+	Attempts   uint
+	Duration   time.Duration // Total duration including rate limiting and delays between attempts.
+	Cached     bool          // True if the response was taken from the ReferenceCache. Attempts is zero in this case.
+}
+
+// Observer receives events of every API request. It can be used for tracing and metrics.
+// Observer is called synchronously, so it must not block.
+type Observer interface {
+	// RequestStart is called before the first attempt. Returned context is used for the request and for
+	// the following events of the same request.
+	RequestStart(ctx context.Context, info RequestInfo) context.Context
+	// RateLimitWait is called after the limiter has allowed the attempt. Wait is the time spent in Limiter.Limit.
+	RateLimitWait(ctx context.Context, info RequestInfo, wait time.Duration)
+	// Retry is called before the delay between attempts.
+	Retry(ctx context.Context, info RequestInfo, attempt RetryAttempt, delay time.Duration)
+	// RequestDone is called after the last attempt.
+	RequestDone(ctx context.Context, info RequestInfo, result RequestResult)
+}
+
+// NopObserver implements Observer and does nothing. It can be embedded to implement only some of the events.
+type NopObserver struct{}
+
+// RequestStart returns the provided context.
+func (NopObserver) RequestStart(ctx context.Context, _ RequestInfo) context.Context {
+	return ctx
+}
+
+// RateLimitWait does nothing.
+func (NopObserver) RateLimitWait(context.Context, RequestInfo, time.Duration) {}
+
+// Retry does nothing.
+func (NopObserver) Retry(context.Context, RequestInfo, RetryAttempt, time.Duration) {}
+
+// RequestDone does nothing.
+func (NopObserver) RequestDone(context.Context, RequestInfo, RequestResult) {}
+
+// WithObserver sets the Observer which receives events of every API request. Pass nil to remove it.
+func (c *Client) WithObserver(observer Observer) *Client {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.observer = observer
+	return c
+}
+
+// observation tracks the single request for the Observer. Methods do nothing for the nil observation.
+type observation struct {
+	observer Observer
+	info     RequestInfo
+	started  time.Time
+	attempts uint
+	cached   bool
+}
+
+// observe returns the observation of the request or nil if there is no Observer.
+func (c *Client) observe(ctx context.Context, method, uri string) *observation {
+	c.mutex.RLock()
+	observer := c.observer
+	c.mutex.RUnlock()
+
+	if observer == nil {
+		return nil
+	}
+
+	return &observation{
+		observer: observer,
+		info: RequestInfo{
+			Method:     apiMethod(ctx),
+			HTTPMethod: method,
+			Path:       requestPath(uri),
+		},
+	}
+}
+
+func (o *observation) start(ctx context.Context) context.Context {
+	if o == nil {
+		return ctx
+	}
+
+	o.started = time.Now()
+	return o.observer.RequestStart(ctx, o.info)
+}
+
+func (o *observation) attempt() {
+	if o != nil {
+		o.attempts++
+	}
+}
+
+func (o *observation) rateLimitWait(ctx context.Context, wait time.Duration) {
+	if o != nil {
+		o.observer.RateLimitWait(ctx, o.info, wait)
+	}
+}
+
+func (o *observation) retry(ctx context.Context, attempt RetryAttempt, delay time.Duration) {
+	if o != nil {
+		o.observer.Retry(ctx, o.info, attempt, delay)
+	}
+}
+
+func (o *observation) done(ctx context.Context, statusCode int, err error) {
+	if o == nil {
+		return
+	}
+
+	o.observer.RequestDone(ctx, o.info, RequestResult{
+		StatusCode: statusCode,
+		Err:        err,
+		Attempts:   o.attempts,
+		Duration:   time.Since(o.started),
+		Cached:     o.cached,
+	})
+}
+
+// observeCacheHit reports the response which was taken from the reference cache without sending the request.
+func (c *Client) observeCacheHit(ctx context.Context, uri string) {
+	obs := c.observe(ctx, http.MethodGet, uri)
+	if obs == nil {
+		return
+	}
+
+	obs.cached = true
+	obs.done(obs.start(ctx), http.StatusOK, nil)
+}
+
+type apiMethodKey struct{}
+
+// withAPIMethod returns the context with the name of the Client method which makes the request.
+func withAPIMethod(ctx context.Context, method string) context.Context {
+	return context.WithValue(ctx, apiMethodKey{}, method)
+}
+
+// apiMethod returns the name of the Client method set by withAPIMethod.
+func apiMethod(ctx context.Context) string {
+	method, _ := ctx.Value(apiMethodKey{}).(string)
+	return method
+}
+
+// requestPath returns the request path without API prefix and query string.
+func requestPath(uri string) string {
+	path := uri
+	if parsed, err := url.Parse(uri); err == nil {
+		path = parsed.Path
+	}
+
+	path = strings.TrimPrefix(path, "/api/v5")
+	if path == "" {
+		return "/"
+	}
+
+	return path
+}
@@ -0,0 +1,121 @@
+package retailcrm
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gock "gopkg.in/h2non/gock.v1"
+)
+
+type observerContextKey struct{}
+
+type recordingObserver struct {
+	events  []string
+	infos   []RequestInfo
+	results []RequestResult
+	traced  bool
+}
+
+func (o *recordingObserver) RequestStart(ctx context.Context, info RequestInfo) context.Context {
+	o.events = append(o.events, "start")
+	o.infos = append(o.infos, info)
+	return context.WithValue(ctx, observerContextKey{}, "trace")
+}
+
+func (o *recordingObserver) RateLimitWait(ctx context.Context, _ RequestInfo, wait time.Duration) {
+	o.events = append(o.events, "wait")
+	o.traced = ctx.Value(observerContextKey{}) == "trace"
+}
+
+func (o *recordingObserver) Retry(_ context.Context, _ RequestInfo, attempt RetryAttempt, _ time.Duration) {
+	o.events = append(o.events, "retry")
+	o.infos = append(o.infos, RequestInfo{Method: attempt.Reason.String()})
+}
+
+func (o *recordingObserver) RequestDone(_ context.Context, _ RequestInfo, result RequestResult) {
+	o.events = append(o.events, "done")
+	o.results = append(o.results, result)
+}
+
+func TestClient_WithObserver(t *testing.T) {
+	defer gock.OffAll()
+
+	gock.New(crmURL).
+		Post("/api/v5/orders/create").
+		Reply(http.StatusServiceUnavailable).
+		BodyString(`{"success": false}`)
+
+	gock.New(crmURL).
+		Post("/api/v5/orders/create").
+		Reply(http.StatusCreated).
+		BodyString(`{"success": true, "id": 1}`)
+
+	observer := &recordingObserver{}
+	c := client().WithObserver(observer)
+	c.EnableRateLimiter(2)
+
+	_, status, err := c.OrderCreate(Order{ExternalID: "ext-1"})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, status)
+
+	assert.Equal(t, []string{"start", "wait", "retry", "wait", "done"}, observer.events)
+	assert.Equal(t, RequestInfo{Method: "OrderCreate", HTTPMethod: http.MethodPost, Path: "/orders/create"},
+		observer.infos[0])
+	assert.Equal(t, "rate limited", observer.infos[1].Method)
+	assert.True(t, observer.traced, "Context returned by RequestStart must be used")
+
+	require.Len(t, observer.results, 1)
+	assert.Equal(t, http.StatusCreated, observer.results[0].StatusCode)
+	assert.Equal(t, uint(2), observer.results[0].Attempts)
+	assert.Positive(t, observer.results[0].Duration)
+}
+
+func TestClient_WithObserver_MethodName(t *testing.T) {
+	defer gock.OffAll()
+
+	gock.New(crmURL).
+		Get("/api/v5/orders").
+		Reply(http.StatusOK).
+		BodyString(`{"success": true, "pagination": {"totalPageCount": 1}, "orders": [{"id": 1}]}`)
+
+	gock.New(crmURL).
+		Get("/api/v5/customers").
+		Reply(http.StatusBadRequest).
+		BodyString(`{"success": false, "errorMsg": "Errors in the input parameters"}`)
+
+	observer := &recordingObserver{}
+	c := client().WithObserver(observer)
+
+	orders := c.OrdersIterCtx(context.Background(), OrdersRequest{})
+	for orders.Next() {
+	}
+
+	_, _, err := c.CustomersCtx(context.Background(), CustomersRequest{})
+	require.Error(t, err)
+
+	assert.Equal(t, []string{"start", "done", "start", "done"}, observer.events)
+	assert.Equal(t, "Orders", observer.infos[0].Method)
+	assert.Equal(t, "Customers", observer.infos[1].Method)
+	assert.Equal(t, "/customers", observer.infos[1].Path)
+	assert.Equal(t, http.StatusBadRequest, observer.results[1].StatusCode)
+	assert.Error(t, observer.results[1].Err)
+
+	gock.New(crmURL).
+		Get("/api/v5/users").
+		Reply(http.StatusOK).
+		BodyString(`{"success": true}`)
+
+	_, _, err = c.GetRequestCtx(context.Background(), "/users")
+	require.NoError(t, err)
+	assert.Equal(t, "", observer.infos[2].Method)
+	assert.Equal(t, "/users", observer.infos[2].Path)
+}
+
+func TestRequestPath(t *testing.T) {
+	assert.Equal(t, "/orders", requestPath("/orders?page=1"))
+	assert.Equal(t, "/orders/1/plates/2/print", requestPath("https://demo.url/api/v5/orders/1/plates/2/print?by=id"))
+}
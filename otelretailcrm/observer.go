@@ -0,0 +1,195 @@
+// Package otelretailcrm provides OpenTelemetry tracing and metrics for the retailCRM API client.
+//
+// Example:
+//
+//	observer, err := otelretailcrm.NewObserver(nil, nil)
+//	if err != nil {
+//		log.Fatalln(err)
+//	}
+//
+//	client := retailcrm.New("https://demo.url", "09jIJ").
+//		WithObserver(observer).
+//		Use(otelretailcrm.Middleware())
+package otelretailcrm
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	retailcrm "github.com/retailcrm/api-client-go/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName is the name of the tracer and the meter.
+const InstrumentationName = "github.com/retailcrm/api-client-go/v2/otelretailcrm"
+
+// Attribute keys which are used in spans and metrics.
+const (
+	MethodKey     = attribute.Key("retailcrm.method")
+	AttemptsKey   = attribute.Key("retailcrm.attempts")
+	RetryKey      = attribute.Key("retailcrm.retry.reason")
+	HTTPMethodKey = attribute.Key("http.request.method")
+	StatusCodeKey = attribute.Key("http.response.status_code")
+	PathKey       = attribute.Key("url.path")
+	CacheHitKey   = attribute.Key("retailcrm.cache.hit")
+)
+
+// Observer implements retailcrm.Observer. It creates the client span for every API request and records
+// the following metrics:
+//
+//   - retailcrm.client.requests - counter of the finished requests including the reference cache hits;
+//   - retailcrm.client.request.duration - histogram of the request durations in seconds;
+//   - retailcrm.client.retries - counter of the retried attempts;
+//   - retailcrm.client.rate_limit.wait - histogram of the time spent in the rate limiter in seconds.
+type Observer struct {
+	tracer        trace.Tracer
+	requests      metric.Int64Counter
+	duration      metric.Float64Histogram
+	retries       metric.Int64Counter
+	rateLimitWait metric.Float64Histogram
+}
+
+// NewObserver instantiates new Observer. Global providers are used if nil providers are passed.
+func NewObserver(tracerProvider trace.TracerProvider, meterProvider metric.MeterProvider) (*Observer, error) {
+	if tracerProvider == nil {
+		tracerProvider = otel.GetTracerProvider()
+	}
+
+	if meterProvider == nil {
+		meterProvider = otel.GetMeterProvider()
+	}
+
+	meter := meterProvider.Meter(InstrumentationName)
+	observer := &Observer{tracer: tracerProvider.Tracer(InstrumentationName)}
+
+	var err error
+
+	observer.requests, err = meter.Int64Counter("retailcrm.client.requests",
+		metric.WithDescription("Number of the finished API requests."))
+	if err != nil {
+		return nil, err
+	}
+
+	observer.duration, err = meter.Float64Histogram("retailcrm.client.request.duration",
+		metric.WithDescription("Duration of the API requests including retries."), metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+
+	observer.retries, err = meter.Int64Counter("retailcrm.client.retries",
+		metric.WithDescription("Number of the retried request attempts."))
+	if err != nil {
+		return nil, err
+	}
+
+	observer.rateLimitWait, err = meter.Float64Histogram("retailcrm.client.rate_limit.wait",
+		metric.WithDescription("Time spent in the rate limiter before the request attempt."), metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+
+	return observer, nil
+}
+
+// RequestStart starts the client span for the request.
+func (o *Observer) RequestStart(ctx context.Context, info retailcrm.RequestInfo) context.Context {
+	ctx, _ = o.tracer.Start(ctx, spanName(info),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(requestAttributes(info)...),
+	)
+
+	return ctx
+}
+
+// RateLimitWait records the time spent in the rate limiter.
+func (o *Observer) RateLimitWait(ctx context.Context, info retailcrm.RequestInfo, wait time.Duration) {
+	trace.SpanFromContext(ctx).AddEvent("rate_limit.wait", trace.WithAttributes(
+		attribute.Float64("retailcrm.rate_limit.wait", wait.Seconds()),
+	))
+
+	o.rateLimitWait.Record(ctx, wait.Seconds(), metric.WithAttributes(MethodKey.String(info.Method)))
+}
+
+// Retry records the retried attempt.
+func (o *Observer) Retry(
+	ctx context.Context, info retailcrm.RequestInfo, attempt retailcrm.RetryAttempt, delay time.Duration,
+) {
+	trace.SpanFromContext(ctx).AddEvent("retry", trace.WithAttributes(
+		attribute.Int("retailcrm.attempt", int(attempt.Attempt)),
+		RetryKey.String(attempt.Reason.String()),
+		StatusCodeKey.Int(attempt.StatusCode),
+		attribute.Float64("retailcrm.retry.delay", delay.Seconds()),
+	))
+
+	o.retries.Add(ctx, 1, metric.WithAttributes(MethodKey.String(info.Method), RetryKey.String(attempt.Reason.String())))
+}
+
+// RequestDone ends the span and records the request metrics.
+func (o *Observer) RequestDone(ctx context.Context, info retailcrm.RequestInfo, result retailcrm.RequestResult) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		StatusCodeKey.Int(result.StatusCode),
+		AttemptsKey.Int(int(result.Attempts)),
+		CacheHitKey.Bool(result.Cached),
+	)
+
+	if result.Err != nil {
+		span.RecordError(result.Err)
+		span.SetStatus(codes.Error, result.Err.Error())
+	}
+
+	span.End()
+
+	attrs := metric.WithAttributes(
+		MethodKey.String(info.Method),
+		HTTPMethodKey.String(info.HTTPMethod),
+		StatusCodeKey.String(statusCode(result)),
+		CacheHitKey.Bool(result.Cached),
+	)
+
+	o.requests.Add(ctx, 1, attrs)
+	o.duration.Record(ctx, result.Duration.Seconds(), attrs)
+}
+
+// Middleware returns retailcrm.Middleware which injects the trace context into the request headers
+// using the global propagator. It must be used together with the Observer.
+func Middleware() retailcrm.Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return retailcrm.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			otel.GetTextMapPropagator().Inject(req.Context(), propagation.HeaderCarrier(req.Header))
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+func spanName(info retailcrm.RequestInfo) string {
+	if info.Method != "" {
+		return "retailcrm." + info.Method
+	}
+
+	return info.HTTPMethod + " " + info.Path
+}
+
+func requestAttributes(info retailcrm.RequestInfo) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		MethodKey.String(info.Method),
+		HTTPMethodKey.String(info.HTTPMethod),
+		PathKey.String(info.Path),
+	}
+}
+
+// statusCode returns the status code label. Requests without response are labeled as "error".
+func statusCode(result retailcrm.RequestResult) string {
+	if result.StatusCode == 0 {
+		return "error"
+	}
+
+	return strconv.Itoa(result.StatusCode)
+}
@@ -0,0 +1,171 @@
+package otelretailcrm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	retailcrm "github.com/retailcrm/api-client-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type testEnv struct {
+	spans   *tracetest.InMemoryExporter
+	metrics *sdkmetric.ManualReader
+	client  *retailcrm.Client
+	headers []http.Header
+}
+
+func newTestEnv(t *testing.T, handler func(w http.ResponseWriter, attempt int)) *testEnv {
+	env := &testEnv{
+		spans:   tracetest.NewInMemoryExporter(),
+		metrics: sdkmetric.NewManualReader(),
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		env.headers = append(env.headers, r.Header.Clone())
+		handler(w, len(env.headers))
+	}))
+	t.Cleanup(server.Close)
+
+	observer, err := NewObserver(
+		sdktrace.NewTracerProvider(sdktrace.WithSyncer(env.spans)),
+		sdkmetric.NewMeterProvider(sdkmetric.WithReader(env.metrics)),
+	)
+	require.NoError(t, err)
+
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	env.client = retailcrm.New(server.URL, "key").
+		WithHTTPClient(server.Client()).
+		WithObserver(observer).
+		Use(Middleware())
+
+	return env
+}
+
+func (e *testEnv) collect(t *testing.T) map[string]metricdata.Metrics {
+	var data metricdata.ResourceMetrics
+	require.NoError(t, e.metrics.Collect(context.Background(), &data))
+
+	result := map[string]metricdata.Metrics{}
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			result[m.Name] = m
+		}
+	}
+
+	return result
+}
+
+func TestObserver_Success(t *testing.T) {
+	env := newTestEnv(t, func(w http.ResponseWriter, attempt int) {
+		if attempt == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"success": false}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"success": true, "orders": []}`))
+	})
+	env.client.EnableRateLimiter(2)
+
+	_, status, err := env.client.Orders(retailcrm.OrdersRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+
+	spans := env.spans.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "retailcrm.Orders", spans[0].Name)
+	assert.Contains(t, spans[0].Attributes, StatusCodeKey.Int(http.StatusOK))
+	assert.Contains(t, spans[0].Attributes, AttemptsKey.Int(2))
+	assert.Contains(t, spans[0].Attributes, PathKey.String("/orders"))
+
+	var events []string
+	for _, event := range spans[0].Events {
+		events = append(events, event.Name)
+	}
+	assert.Equal(t, []string{"rate_limit.wait", "retry", "rate_limit.wait"}, events)
+
+	require.Len(t, env.headers, 2)
+	assert.Contains(t, env.headers[1].Get("Traceparent"), spans[0].SpanContext.TraceID().String())
+
+	metrics := env.collect(t)
+	requests := metrics["retailcrm.client.requests"].Data.(metricdata.Sum[int64])
+	require.Len(t, requests.DataPoints, 1)
+	assert.Equal(t, int64(1), requests.DataPoints[0].Value)
+
+	code, _ := requests.DataPoints[0].Attributes.Value(StatusCodeKey)
+	assert.Equal(t, "200", code.AsString())
+
+	retries := metrics["retailcrm.client.retries"].Data.(metricdata.Sum[int64])
+	require.Len(t, retries.DataPoints, 1)
+
+	reason, _ := retries.DataPoints[0].Attributes.Value(RetryKey)
+	assert.Equal(t, "rate limited", reason.AsString())
+
+	waits := metrics["retailcrm.client.rate_limit.wait"].Data.(metricdata.Histogram[float64])
+	require.Len(t, waits.DataPoints, 1)
+	assert.Equal(t, uint64(2), waits.DataPoints[0].Count)
+
+	durations := metrics["retailcrm.client.request.duration"].Data.(metricdata.Histogram[float64])
+	require.Len(t, durations.DataPoints, 1)
+	assert.Equal(t, uint64(1), durations.DataPoints[0].Count)
+}
+
+func TestObserver_Error(t *testing.T) {
+	env := newTestEnv(t, func(w http.ResponseWriter, _ int) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"success": false, "errorMsg": "Errors in the input parameters"}`))
+	})
+
+	_, _, err := env.client.OrderCreate(retailcrm.Order{})
+	require.Error(t, err)
+
+	spans := env.spans.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "retailcrm.OrderCreate", spans[0].Name)
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.Equal(t, "Errors in the input parameters", spans[0].Status.Description)
+
+	requests := env.collect(t)["retailcrm.client.requests"].Data.(metricdata.Sum[int64])
+	require.Len(t, requests.DataPoints, 1)
+
+	method, _ := requests.DataPoints[0].Attributes.Value(HTTPMethodKey)
+	assert.Equal(t, http.MethodPost, method.AsString())
+}
+
+func TestObserver_CacheHit(t *testing.T) {
+	env := newTestEnv(t, func(w http.ResponseWriter, _ int) {
+		_, _ = w.Write([]byte(`{"success": true, "sites": {}}`))
+	})
+	env.client.WithReferenceCache(retailcrm.NewMemoryReferenceCache(), 0)
+
+	for i := 0; i < 2; i++ {
+		_, _, err := env.client.Sites()
+		require.NoError(t, err)
+	}
+
+	require.Len(t, env.headers, 1)
+
+	spans := env.spans.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Contains(t, spans[0].Attributes, CacheHitKey.Bool(false))
+	assert.Contains(t, spans[1].Attributes, CacheHitKey.Bool(true))
+	assert.Contains(t, spans[1].Attributes, AttemptsKey.Int(0))
+
+	requests := env.collect(t)["retailcrm.client.requests"].Data.(metricdata.Sum[int64])
+	require.Len(t, requests.DataPoints, 2)
+
+	for _, point := range requests.DataPoints {
+		assert.Equal(t, int64(1), point.Value)
+	}
+}
@@ -0,0 +1,391 @@
+package retailcrm
+
+import "context"
+
+// PageFetcher fetches a single page of the page-based list endpoint.
+// It must return the page items and the pagination data from the response.
+type PageFetcher[T any] func(ctx context.Context, page int) ([]T, *Pagination, error)
+
+// CursorFetcher fetches a single page of the cursor-based list endpoint.
+// It must return the page items and the cursor pagination data from the response.
+type CursorFetcher[T any] func(ctx context.Context, cursor string) ([]T, *CursorPagination, error)
+
+// pager contains iteration logic which is shared between Paginator and CursorPaginator.
+// fetch must return items of the next page and true if this page is the last one.
+type pager[T any] struct {
+	ctx     context.Context
+	fetch   func(ctx context.Context) ([]T, bool, error)
+	err     error
+	items   []T
+	current T
+	index   int
+	done    bool
+}
+
+func newPager[T any](ctx context.Context) pager[T] {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return pager[T]{ctx: ctx}
+}
+
+// Next advances the iterator to the next item. It returns false when there are no items left or an error occurred.
+// Use Err to check if iteration was stopped because of an error.
+func (p *pager[T]) Next() bool {
+	if p.err != nil {
+		return false
+	}
+
+	for p.index >= len(p.items) {
+		if p.done {
+			return false
+		}
+
+		if err := p.ctx.Err(); err != nil {
+			p.err = err
+			return false
+		}
+
+		items, last, err := p.fetch(p.ctx)
+		if err != nil {
+			p.err = err
+			return false
+		}
+
+		p.items = items
+		p.index = 0
+		p.done = last || len(items) == 0
+	}
+
+	p.current = p.items[p.index]
+	p.index++
+
+	return true
+}
+
+// Item returns the current item. It should be called only after Next returned true.
+func (p *pager[T]) Item() T {
+	return p.current
+}
+
+// Err returns the error which stopped the iteration.
+func (p *pager[T]) Err() error {
+	return p.err
+}
+
+// Chan returns the channel which will receive all remaining items. The channel is closed when iteration is finished,
+// after that Err can be used to check if iteration was stopped because of an error.
+// Cancel the iterator context if you want to stop reading from the channel before it's closed.
+func (p *pager[T]) Chan() <-chan T {
+	ch := make(chan T)
+
+	go func() {
+		defer close(ch)
+
+		for p.Next() {
+			select {
+			case ch <- p.Item():
+			case <-p.ctx.Done():
+				p.err = p.ctx.Err()
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+// Paginator lazily iterates over all items of the page-based list endpoint.
+// Pages are requested only when all items from the previous page were consumed, so the rate limiter
+// configured in the Client is respected. Iteration stops on the first error.
+//
+// Example:
+//
+//	var client = retailcrm.New("https://demo.url", "09jIJ")
+//
+//	orders := client.OrdersIter(retailcrm.OrdersRequest{Filter: retailcrm.OrdersFilter{City: "Moscow"}, Limit: 100})
+//
+//	for orders.Next() {
+//		log.Printf("%v\n", orders.Item().ID)
+//	}
+//
+//	if err := orders.Err(); err != nil {
+//		log.Fatalf("error: %s", err)
+//	}
+type Paginator[T any] struct {
+	pager[T]
+	pagination *Pagination
+	page       int
+}
+
+// Paginate returns Paginator which will request pages using the provided PageFetcher.
+// Iteration starts from the provided page or from the first page if page is less than 1.
+func Paginate[T any](ctx context.Context, page int, fetch PageFetcher[T]) *Paginator[T] {
+	if page < 1 {
+		page = 1
+	}
+
+	p := &Paginator[T]{pager: newPager[T](ctx), page: page}
+	p.fetch = func(ctx context.Context) ([]T, bool, error) {
+		items, pagination, err := fetch(ctx, p.page)
+		if err != nil {
+			return nil, false, err
+		}
+
+		p.pagination = pagination
+		last := pagination == nil || p.page >= pagination.TotalPageCount
+		p.page++
+
+		return items, last, nil
+	}
+
+	return p
+}
+
+// Pagination returns the pagination data from the last received page.
+func (p *Paginator[T]) Pagination() *Pagination {
+	return p.pagination
+}
+
+// CursorPaginator lazily iterates over all items of the cursor-based list endpoint.
+// It follows the next cursor from every response until the API returns an empty one.
+//
+// Example:
+//
+//	var client = retailcrm.New("https://demo.url", "09jIJ")
+//
+//	operations := client.BonusOperationsIter(retailcrm.BonusOperationsRequest{Limit: 100, Cursor: savedCursor})
+//
+//	for operations.Next() {
+//		log.Printf("%v\n", operations.Item().Amount)
+//		savedCursor = operations.Cursor()
+//	}
+//
+//	if err := operations.Err(); err != nil {
+//		log.Fatalf("error: %s", err)
+//	}
+type CursorPaginator[T any] struct {
+	pager[T]
+	cursor     string
+	nextCursor string
+}
+
+// PaginateCursor returns CursorPaginator which will request pages using the provided CursorFetcher.
+// Iteration starts from the provided cursor or from the beginning if cursor is empty.
+func PaginateCursor[T any](ctx context.Context, cursor string, fetch CursorFetcher[T]) *CursorPaginator[T] {
+	p := &CursorPaginator[T]{pager: newPager[T](ctx), nextCursor: cursor}
+	p.fetch = func(ctx context.Context) ([]T, bool, error) {
+		items, pagination, err := fetch(ctx, p.nextCursor)
+		if err != nil {
+			return nil, false, err
+		}
+
+		p.cursor = p.nextCursor
+		p.nextCursor = ""
+
+		if pagination != nil {
+			p.nextCursor = pagination.NextCursor
+		}
+
+		return items, p.nextCursor == "", nil
+	}
+
+	return p
+}
+
+// Cursor returns the cursor which can be persisted and used later to resume iteration after the current item.
+// It points to the next page when the current item is the last one on its page and to the current page otherwise,
+// which means that already processed items from the current page can be received again after resuming.
+func (p *CursorPaginator[T]) Cursor() string {
+	if p.index >= len(p.items) && p.nextCursor != "" {
+		return p.nextCursor
+	}
+
+	return p.cursor
+}
+
+// OrdersIter returns Paginator which iterates over all orders matched the specified filters.
+// Iteration starts from parameters.Page or from the first page if it is not specified.
+func (c *Client) OrdersIter(parameters OrdersRequest) *Paginator[Order] {
+	return c.OrdersIterCtx(c.defaultContext(), parameters)
+}
+
+// OrdersIterCtx is the same as OrdersIter, but uses the provided context.Context.
+func (c *Client) OrdersIterCtx(ctx context.Context, parameters OrdersRequest) *Paginator[Order] {
+	return Paginate(ctx, parameters.Page, func(ctx context.Context, page int) ([]Order, *Pagination, error) {
+		parameters.Page = page
+		resp, _, err := c.OrdersCtx(ctx, parameters)
+		return resp.Orders, resp.Pagination, err
+	})
+}
+
+// CustomersIter returns Paginator which iterates over all customers matched the specified filters.
+// Iteration starts from parameters.Page or from the first page if it is not specified.
+func (c *Client) CustomersIter(parameters CustomersRequest) *Paginator[Customer] {
+	return c.CustomersIterCtx(c.defaultContext(), parameters)
+}
+
+// CustomersIterCtx is the same as CustomersIter, but uses the provided context.Context.
+func (c *Client) CustomersIterCtx(ctx context.Context, parameters CustomersRequest) *Paginator[Customer] {
+	return Paginate(ctx, parameters.Page, func(ctx context.Context, page int) ([]Customer, *Pagination, error) {
+		parameters.Page = page
+		resp, _, err := c.CustomersCtx(ctx, parameters)
+		return resp.Customers, resp.Pagination, err
+	})
+}
+
+// CorporateCustomersIter returns Paginator which iterates over all corporate customers matched the specified filters.
+// Iteration starts from parameters.Page or from the first page if it is not specified.
+func (c *Client) CorporateCustomersIter(parameters CorporateCustomersRequest) *Paginator[CorporateCustomer] {
+	return c.CorporateCustomersIterCtx(c.defaultContext(), parameters)
+}
+
+// CorporateCustomersIterCtx is the same as CorporateCustomersIter, but uses the provided context.Context.
+func (c *Client) CorporateCustomersIterCtx(
+	ctx context.Context, parameters CorporateCustomersRequest,
+) *Paginator[CorporateCustomer] {
+	return Paginate(ctx, parameters.Page, func(ctx context.Context, page int) ([]CorporateCustomer, *Pagination, error) {
+		parameters.Page = page
+		resp, _, err := c.CorporateCustomersCtx(ctx, parameters)
+		return resp.CustomersCorporate, resp.Pagination, err
+	})
+}
+
+// TasksIter returns Paginator which iterates over all tasks matched the specified filters.
+// Iteration starts from parameters.Page or from the first page if it is not specified.
+func (c *Client) TasksIter(parameters TasksRequest) *Paginator[Task] {
+	return c.TasksIterCtx(c.defaultContext(), parameters)
+}
+
+// TasksIterCtx is the same as TasksIter, but uses the provided context.Context.
+func (c *Client) TasksIterCtx(ctx context.Context, parameters TasksRequest) *Paginator[Task] {
+	return Paginate(ctx, parameters.Page, func(ctx context.Context, page int) ([]Task, *Pagination, error) {
+		parameters.Page = page
+		resp, _, err := c.TasksCtx(ctx, parameters)
+		return resp.Tasks, resp.Pagination, err
+	})
+}
+
+// PacksIter returns Paginator which iterates over all packs matched the specified filters.
+// Iteration starts from parameters.Page or from the first page if it is not specified.
+func (c *Client) PacksIter(parameters PacksRequest) *Paginator[Pack] {
+	return c.PacksIterCtx(c.defaultContext(), parameters)
+}
+
+// PacksIterCtx is the same as PacksIter, but uses the provided context.Context.
+func (c *Client) PacksIterCtx(ctx context.Context, parameters PacksRequest) *Paginator[Pack] {
+	return Paginate(ctx, parameters.Page, func(ctx context.Context, page int) ([]Pack, *Pagination, error) {
+		parameters.Page = page
+		resp, _, err := c.PacksCtx(ctx, parameters)
+		return resp.Packs, resp.Pagination, err
+	})
+}
+
+// ProductsIter returns Paginator which iterates over all products matched the specified filters.
+// Iteration starts from parameters.Page or from the first page if it is not specified.
+func (c *Client) ProductsIter(parameters ProductsRequest) *Paginator[Product] {
+	return c.ProductsIterCtx(c.defaultContext(), parameters)
+}
+
+// ProductsIterCtx is the same as ProductsIter, but uses the provided context.Context.
+func (c *Client) ProductsIterCtx(ctx context.Context, parameters ProductsRequest) *Paginator[Product] {
+	return Paginate(ctx, parameters.Page, func(ctx context.Context, page int) ([]Product, *Pagination, error) {
+		parameters.Page = page
+		resp, _, err := c.ProductsCtx(ctx, parameters)
+		return resp.Products, resp.Pagination, err
+	})
+}
+
+// InventoriesIter returns Paginator which iterates over all offers from the inventories matched the specified filters.
+// Iteration starts from parameters.Page or from the first page if it is not specified.
+func (c *Client) InventoriesIter(parameters InventoriesRequest) *Paginator[Offer] {
+	return c.InventoriesIterCtx(c.defaultContext(), parameters)
+}
+
+// InventoriesIterCtx is the same as InventoriesIter, but uses the provided context.Context.
+func (c *Client) InventoriesIterCtx(ctx context.Context, parameters InventoriesRequest) *Paginator[Offer] {
+	return Paginate(ctx, parameters.Page, func(ctx context.Context, page int) ([]Offer, *Pagination, error) {
+		parameters.Page = page
+		resp, _, err := c.InventoriesCtx(ctx, parameters)
+		return resp.Offers, resp.Pagination, err
+	})
+}
+
+// CostsIter returns Paginator which iterates over all costs matched the specified filters.
+// Iteration starts from parameters.Page or from the first page if it is not specified.
+func (c *Client) CostsIter(parameters CostsRequest) *Paginator[Cost] {
+	return c.CostsIterCtx(c.defaultContext(), parameters)
+}
+
+// CostsIterCtx is the same as CostsIter, but uses the provided context.Context.
+func (c *Client) CostsIterCtx(ctx context.Context, parameters CostsRequest) *Paginator[Cost] {
+	return Paginate(ctx, parameters.Page, func(ctx context.Context, page int) ([]Cost, *Pagination, error) {
+		parameters.Page = page
+		resp, _, err := c.CostsCtx(ctx, parameters)
+		return resp.Costs, resp.Pagination, err
+	})
+}
+
+// FilesIter returns Paginator which iterates over all files matched the specified filters.
+// Iteration starts from parameters.Page or from the first page if it is not specified.
+func (c *Client) FilesIter(parameters FilesRequest) *Paginator[File] {
+	return c.FilesIterCtx(c.defaultContext(), parameters)
+}
+
+// FilesIterCtx is the same as FilesIter, but uses the provided context.Context.
+func (c *Client) FilesIterCtx(ctx context.Context, parameters FilesRequest) *Paginator[File] {
+	return Paginate(ctx, parameters.Page, func(ctx context.Context, page int) ([]File, *Pagination, error) {
+		parameters.Page = page
+		resp, _, err := c.FilesCtx(ctx, parameters)
+		return resp.Files, resp.Pagination, err
+	})
+}
+
+// UsersIter returns Paginator which iterates over all users matched the specified filters.
+// Iteration starts from parameters.Page or from the first page if it is not specified.
+func (c *Client) UsersIter(parameters UsersRequest) *Paginator[User] {
+	return c.UsersIterCtx(c.defaultContext(), parameters)
+}
+
+// UsersIterCtx is the same as UsersIter, but uses the provided context.Context.
+func (c *Client) UsersIterCtx(ctx context.Context, parameters UsersRequest) *Paginator[User] {
+	return Paginate(ctx, parameters.Page, func(ctx context.Context, page int) ([]User, *Pagination, error) {
+		parameters.Page = page
+		resp, _, err := c.UsersCtx(ctx, parameters)
+		return resp.Users, resp.Pagination, err
+	})
+}
+
+// SegmentsIter returns Paginator which iterates over all segments matched the specified filters.
+// Iteration starts from parameters.Page or from the first page if it is not specified.
+func (c *Client) SegmentsIter(parameters SegmentsRequest) *Paginator[Segment] {
+	return c.SegmentsIterCtx(c.defaultContext(), parameters)
+}
+
+// SegmentsIterCtx is the same as SegmentsIter, but uses the provided context.Context.
+func (c *Client) SegmentsIterCtx(ctx context.Context, parameters SegmentsRequest) *Paginator[Segment] {
+	return Paginate(ctx, parameters.Page, func(ctx context.Context, page int) ([]Segment, *Pagination, error) {
+		parameters.Page = page
+		resp, _, err := c.SegmentsCtx(ctx, parameters)
+		return resp.Segments, resp.Pagination, err
+	})
+}
+
+// BonusOperationsIter returns CursorPaginator which iterates over all bonus operations matched the specified filters.
+// Iteration starts from parameters.Cursor or from the beginning if it is not specified.
+func (c *Client) BonusOperationsIter(parameters BonusOperationsRequest) *CursorPaginator[BonusOperation] {
+	return c.BonusOperationsIterCtx(c.defaultContext(), parameters)
+}
+
+// BonusOperationsIterCtx is the same as BonusOperationsIter, but uses the provided context.Context.
+func (c *Client) BonusOperationsIterCtx(
+	ctx context.Context, parameters BonusOperationsRequest,
+) *CursorPaginator[BonusOperation] {
+	return PaginateCursor(ctx, parameters.Cursor,
+		func(ctx context.Context, cursor string) ([]BonusOperation, *CursorPagination, error) {
+			parameters.Cursor = cursor
+			resp, _, err := c.BonusOperationsCtx(ctx, parameters)
+			return resp.BonusOperations, resp.Pagination, err
+		})
+}
@@ -0,0 +1,271 @@
+package retailcrm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gock "gopkg.in/h2non/gock.v1"
+)
+
+func pagesFetcher(pages [][]int, calls *[]int) PageFetcher[int] {
+	return func(_ context.Context, page int) ([]int, *Pagination, error) {
+		*calls = append(*calls, page)
+
+		return pages[page-1], &Pagination{
+			Limit:          20,
+			CurrentPage:    page,
+			TotalPageCount: len(pages),
+		}, nil
+	}
+}
+
+func TestPaginate_AllPages(t *testing.T) {
+	var calls []int
+	p := Paginate(context.Background(), 0, pagesFetcher([][]int{{1, 2}, {3}, {4, 5}}, &calls))
+
+	var items []int
+	for p.Next() {
+		items = append(items, p.Item())
+	}
+
+	require.NoError(t, p.Err())
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, items)
+	assert.Equal(t, []int{1, 2, 3}, calls)
+	assert.Equal(t, 3, p.Pagination().CurrentPage)
+	assert.False(t, p.Next())
+}
+
+func TestPaginate_StartPage(t *testing.T) {
+	var calls []int
+	p := Paginate(context.Background(), 2, pagesFetcher([][]int{{1, 2}, {3}, {4, 5}}, &calls))
+
+	var items []int
+	for p.Next() {
+		items = append(items, p.Item())
+	}
+
+	require.NoError(t, p.Err())
+	assert.Equal(t, []int{3, 4, 5}, items)
+	assert.Equal(t, []int{2, 3}, calls)
+}
+
+func TestPaginate_Lazy(t *testing.T) {
+	var calls []int
+	p := Paginate(context.Background(), 1, pagesFetcher([][]int{{1, 2}, {3}}, &calls))
+
+	require.True(t, p.Next())
+	require.True(t, p.Next())
+	assert.Equal(t, []int{1}, calls, "Second page must not be requested before first page is consumed")
+
+	require.True(t, p.Next())
+	assert.Equal(t, 3, p.Item())
+	assert.Equal(t, []int{1, 2}, calls)
+}
+
+func TestPaginate_StopsOnError(t *testing.T) {
+	fetchErr := errors.New("fetch failed")
+	calls := 0
+	p := Paginate(context.Background(), 1, func(_ context.Context, page int) ([]int, *Pagination, error) {
+		calls++
+		if page == 2 {
+			return nil, nil, fetchErr
+		}
+
+		return []int{page}, &Pagination{CurrentPage: page, TotalPageCount: 3}, nil
+	})
+
+	var items []int
+	for p.Next() {
+		items = append(items, p.Item())
+	}
+
+	assert.ErrorIs(t, p.Err(), fetchErr)
+	assert.Equal(t, []int{1}, items)
+	assert.False(t, p.Next())
+	assert.Equal(t, 2, calls)
+}
+
+func TestPaginate_EmptyPageStopsIteration(t *testing.T) {
+	p := Paginate(context.Background(), 1, func(_ context.Context, page int) ([]int, *Pagination, error) {
+		return nil, &Pagination{CurrentPage: page, TotalPageCount: 10}, nil
+	})
+
+	assert.False(t, p.Next())
+	assert.NoError(t, p.Err())
+}
+
+func TestPaginate_Chan(t *testing.T) {
+	var calls []int
+	p := Paginate(context.Background(), 1, pagesFetcher([][]int{{1, 2}, {3}}, &calls))
+
+	var items []int
+	for item := range p.Chan() {
+		items = append(items, item)
+	}
+
+	require.NoError(t, p.Err())
+	assert.Equal(t, []int{1, 2, 3}, items)
+}
+
+func TestPaginate_ChanCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := Paginate(ctx, 1, func(_ context.Context, page int) ([]int, *Pagination, error) {
+		return []int{page}, &Pagination{CurrentPage: page, TotalPageCount: 100}, nil
+	})
+
+	ch := p.Chan()
+	assert.Equal(t, 1, <-ch)
+	cancel()
+
+	for range ch {
+	}
+
+	assert.ErrorIs(t, p.Err(), context.Canceled)
+}
+
+func TestClient_OrdersIter(t *testing.T) {
+	defer gock.OffAll()
+
+	gock.New(crmURL).
+		Get("/api/v5/orders").
+		MatchParam("page", "1").
+		Reply(http.StatusOK).
+		BodyString(`{"success": true, "pagination": {"limit": 20, "totalCount": 3, "currentPage": 1,
+			"totalPageCount": 2}, "orders": [{"id": 1}, {"id": 2}]}`)
+
+	gock.New(crmURL).
+		Get("/api/v5/orders").
+		MatchParam("page", "2").
+		Reply(http.StatusOK).
+		BodyString(`{"success": true, "pagination": {"limit": 20, "totalCount": 3, "currentPage": 2,
+			"totalPageCount": 2}, "orders": [{"id": 3}]}`)
+
+	orders := client().OrdersIter(OrdersRequest{Limit: 20})
+
+	var ids []int
+	for orders.Next() {
+		ids = append(ids, orders.Item().ID)
+	}
+
+	require.NoError(t, orders.Err())
+	assert.Equal(t, []int{1, 2, 3}, ids)
+	assert.True(t, gock.IsDone())
+}
+
+func TestClient_OrdersIter_Fail(t *testing.T) {
+	defer gock.OffAll()
+
+	gock.New(crmURL).
+		Get("/api/v5/orders").
+		Reply(http.StatusBadRequest).
+		BodyString(`{"success": false, "errorMsg": "Errors in the input parameters"}`)
+
+	orders := client().OrdersIter(OrdersRequest{})
+
+	assert.False(t, orders.Next())
+	assert.Error(t, orders.Err())
+}
+
+func cursorFetcher(pages map[string][]int, next map[string]string, calls *[]string) CursorFetcher[int] {
+	return func(_ context.Context, cursor string) ([]int, *CursorPagination, error) {
+		*calls = append(*calls, cursor)
+		return pages[cursor], &CursorPagination{NextCursor: next[cursor]}, nil
+	}
+}
+
+func TestPaginateCursor_FollowsNextCursor(t *testing.T) {
+	var calls []string
+	p := PaginateCursor(context.Background(), "", cursorFetcher(
+		map[string][]int{"": {1, 2}, "c2": {3}, "c3": {4}},
+		map[string]string{"": "c2", "c2": "c3"},
+		&calls,
+	))
+
+	var items []int
+	for p.Next() {
+		items = append(items, p.Item())
+	}
+
+	require.NoError(t, p.Err())
+	assert.Equal(t, []int{1, 2, 3, 4}, items)
+	assert.Equal(t, []string{"", "c2", "c3"}, calls)
+}
+
+func TestPaginateCursor_Resume(t *testing.T) {
+	var calls []string
+	p := PaginateCursor(context.Background(), "c2", cursorFetcher(
+		map[string][]int{"": {1, 2}, "c2": {3, 4}, "c3": {5}},
+		map[string]string{"": "c2", "c2": "c3"},
+		&calls,
+	))
+
+	assert.Equal(t, "c2", p.Cursor())
+
+	require.True(t, p.Next())
+	assert.Equal(t, 3, p.Item())
+	assert.Equal(t, "c2", p.Cursor(), "Cursor must point to the current page until it is consumed")
+
+	require.True(t, p.Next())
+	assert.Equal(t, 4, p.Item())
+	assert.Equal(t, "c3", p.Cursor(), "Cursor must point to the next page after the last item of the page")
+
+	require.True(t, p.Next())
+	assert.Equal(t, 5, p.Item())
+	assert.Equal(t, "c3", p.Cursor(), "Cursor must stay on the last page when there is no next cursor")
+
+	assert.False(t, p.Next())
+	require.NoError(t, p.Err())
+	assert.Equal(t, []string{"c2", "c3"}, calls)
+}
+
+func TestPaginateCursor_StopsOnError(t *testing.T) {
+	fetchErr := errors.New("fetch failed")
+	p := PaginateCursor(context.Background(), "", func(_ context.Context, cursor string) ([]int, *CursorPagination, error) {
+		if cursor != "" {
+			return nil, nil, fetchErr
+		}
+
+		return []int{1}, &CursorPagination{NextCursor: "next"}, nil
+	})
+
+	require.True(t, p.Next())
+	assert.False(t, p.Next())
+	assert.ErrorIs(t, p.Err(), fetchErr)
+	assert.Equal(t, "next", p.Cursor())
+}
+
+func TestClient_BonusOperationsIter(t *testing.T) {
+	defer gock.OffAll()
+
+	gock.New(crmURL).
+		Get("/api/v5/loyalty/bonus/operations").
+		MatchParam("cursor", "saved").
+		Reply(http.StatusOK).
+		BodyString(`{"success": true, "pagination": {"nextCursor": "next"},
+			"bonusOperations": [{"type": "credit_manual", "amount": 10}, {"type": "charge_manual", "amount": 5}]}`)
+
+	gock.New(crmURL).
+		Get("/api/v5/loyalty/bonus/operations").
+		MatchParam("cursor", "next").
+		Reply(http.StatusOK).
+		BodyString(`{"success": true, "pagination": {}, "bonusOperations": [{"type": "burn", "amount": 1}]}`)
+
+	operations := client().BonusOperationsIter(BonusOperationsRequest{
+		Filter: BonusOperationsFilter{Loyalties: []int{2}},
+		Limit:  2,
+		Cursor: "saved",
+	})
+
+	var types []string
+	for item := range operations.Chan() {
+		types = append(types, item.Type)
+	}
+
+	require.NoError(t, operations.Err())
+	assert.Equal(t, []string{"credit_manual", "charge_manual", "burn"}, types)
+	assert.True(t, gock.IsDone())
+}
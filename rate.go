@@ -1,9 +1,11 @@
 package retailcrm
 
 import (
+	"container/list"
 	"context"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"golang.org/x/time/rate"
@@ -16,6 +18,11 @@ const (
 	TelephonyRPS   = 40
 	regularDelay   = time.Second / RPS          // Delay between regular requests.
 	telephonyDelay = time.Second / TelephonyRPS // Delay between telephony requests.
+
+	// DefaultLimiterIdleTimeout is the time after which unused key is removed from MultiKeyLimiter.
+	DefaultLimiterIdleTimeout = 10 * time.Minute
+	// DefaultLimiterMaxKeys is the maximum amount of keys which are stored in MultiKeyLimiter at once.
+	DefaultLimiterMaxKeys = 10000
 )
 
 // Limiter describes basic rate limiter.
@@ -42,6 +49,10 @@ type SingleKeyLimiter struct {
 
 // NewSingleKeyLimiter instantiates new SingleKeyLimiter.
 func NewSingleKeyLimiter() Limiter {
+	return newSingleKeyLimiter()
+}
+
+func newSingleKeyLimiter() *SingleKeyLimiter {
 	return &SingleKeyLimiter{
 		regularLimiter:   rate.NewLimiter(rate.Limit(RPS), 1),
 		telephonyLimiter: rate.NewLimiter(rate.Limit(TelephonyRPS), 1),
@@ -56,3 +67,110 @@ func (r *SingleKeyLimiter) Limit(ctx context.Context, uri, _ string) error {
 
 	return r.regularLimiter.Wait(ctx)
 }
+
+// MultiKeyLimiter manages API request rates for many keys. Every key has its own regular and telephony limits.
+// Keys which were not used for the idle timeout are removed, and the least recently used key is removed when
+// the maximum amount of keys is reached. Removed key starts with the full budget on the next request.
+//
+// The same instance can be shared between many clients:
+//
+//	limiter := retailcrm.NewMultiKeyLimiter().WithIdleTimeout(time.Hour)
+//
+//	first := retailcrm.New("https://first.retailcrm.pro", "first-key").EnableCustomRateLimiter(limiter, 0)
+//	second := retailcrm.New("https://second.retailcrm.pro", "second-key").EnableCustomRateLimiter(limiter, 0)
+type MultiKeyLimiter struct {
+	limiters    map[string]*list.Element
+	recent      *list.List // Keys ordered by the last usage, the most recent first.
+	idleTimeout time.Duration
+	maxKeys     int
+	now         func() time.Time
+	mutex       sync.Mutex
+}
+
+type keyLimiter struct {
+	key      string
+	limiter  *SingleKeyLimiter
+	lastUsed time.Time
+}
+
+// NewMultiKeyLimiter instantiates new MultiKeyLimiter with DefaultLimiterIdleTimeout and DefaultLimiterMaxKeys.
+func NewMultiKeyLimiter() *MultiKeyLimiter {
+	return &MultiKeyLimiter{
+		limiters:    map[string]*list.Element{},
+		recent:      list.New(),
+		idleTimeout: DefaultLimiterIdleTimeout,
+		maxKeys:     DefaultLimiterMaxKeys,
+		now:         time.Now,
+	}
+}
+
+// WithIdleTimeout sets the time after which unused key is removed.
+func (m *MultiKeyLimiter) WithIdleTimeout(timeout time.Duration) *MultiKeyLimiter {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.idleTimeout = timeout
+	return m
+}
+
+// WithMaxKeys sets the maximum amount of keys which are stored at once.
+func (m *MultiKeyLimiter) WithMaxKeys(maxKeys int) *MultiKeyLimiter {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.maxKeys = maxKeys
+	m.evict(m.now())
+	return m
+}
+
+// Limit the request for the provided key.
+func (m *MultiKeyLimiter) Limit(ctx context.Context, uri, key string) error {
+	return m.limiter(key).Limit(ctx, uri, key)
+}
+
+// Len returns the amount of keys which are stored at the moment.
+func (m *MultiKeyLimiter) Len() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.recent.Len()
+}
+
+// limiter returns limiter for the key, creating it if necessary.
+func (m *MultiKeyLimiter) limiter(key string) *SingleKeyLimiter {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	now := m.now()
+
+	if elem, ok := m.limiters[key]; ok {
+		item := elem.Value.(*keyLimiter)
+		item.lastUsed = now
+		m.recent.MoveToFront(elem)
+		m.evict(now)
+
+		return item.limiter
+	}
+
+	item := &keyLimiter{key: key, limiter: newSingleKeyLimiter(), lastUsed: now}
+	m.limiters[key] = m.recent.PushFront(item)
+	m.evict(now)
+
+	return item.limiter
+}
+
+// evict removes idle keys and the least recently used keys over the limit. It must be called under the lock.
+func (m *MultiKeyLimiter) evict(now time.Time) {
+	for elem := m.recent.Back(); elem != nil; elem = m.recent.Back() {
+		item := elem.Value.(*keyLimiter)
+		overLimit := m.maxKeys > 0 && m.recent.Len() > m.maxKeys
+		idle := m.idleTimeout > 0 && now.Sub(item.lastUsed) > m.idleTimeout
+
+		if !overLimit && !idle {
+			return
+		}
+
+		m.recent.Remove(elem)
+		delete(m.limiters, item.key)
+	}
+}
@@ -0,0 +1,208 @@
+package retailcrm
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// DefaultAdaptiveRecoveryInterval is the minimal time between rate changes used by AdaptiveLimiter.
+	DefaultAdaptiveRecoveryInterval = time.Second
+
+	adaptiveDecreaseFactor = 0.5 // Rate multiplier which is applied after the throttled response.
+	adaptiveMinRateRatio   = 0.1 // Minimal rate relative to the maximal rate.
+	adaptiveRecoveryRatio  = 0.1 // Rate increase relative to the maximal rate.
+)
+
+// AdaptiveLimitMetrics contains the current state of the single route limit.
+type AdaptiveLimitMetrics struct {
+	Limit        float64   // Current requests per second.
+	MaxLimit     float64   // Configured requests per second.
+	Throttled    uint64    // Amount of throttled responses.
+	BlockedUntil time.Time // Time until which requests are paused by the Retry-After header.
+}
+
+// AdaptiveLimiterMetrics contains the current state of the regular and telephony limits.
+type AdaptiveLimiterMetrics struct {
+	Regular   AdaptiveLimitMetrics
+	Telephony AdaptiveLimitMetrics
+}
+
+// AdaptiveLimiter manages API request rates for the single key and adjusts them using the responses.
+// Rate is halved after the 429 or 503 response (or X-RateLimit-Remaining: 0) and recovers gradually after
+// successful responses. Rate is lowered at most once per recovery interval, so the throttled responses to the same
+// burst of requests halve it only once. Requests are paused until the time from Retry-After header.
+// X-RateLimit-Limit header is not used because it doesn't specify the time window of the limit.
+//
+// Example:
+//
+//	limiter := retailcrm.NewAdaptiveLimiter()
+//	client := retailcrm.New("https://demo.url", "09jIJ").EnableCustomRateLimiter(limiter, 0)
+//
+//	// ...
+//
+//	log.Printf("current limit: %.2f rps", limiter.Metrics().Regular.Limit)
+type AdaptiveLimiter struct {
+	regular   *adaptiveRoute
+	telephony *adaptiveRoute
+	now       func() time.Time
+}
+
+type adaptiveRoute struct {
+	limiter          *rate.Limiter
+	maxRate          float64
+	recoveryInterval time.Duration
+	lastChange       time.Time
+	lastDecrease     time.Time
+	blockedUntil     time.Time
+	throttled        uint64
+	mutex            sync.Mutex
+}
+
+// NewAdaptiveLimiter instantiates new AdaptiveLimiter which starts from RPS and TelephonyRPS limits.
+func NewAdaptiveLimiter() *AdaptiveLimiter {
+	return &AdaptiveLimiter{
+		regular:   newAdaptiveRoute(RPS),
+		telephony: newAdaptiveRoute(TelephonyRPS),
+		now:       time.Now,
+	}
+}
+
+func newAdaptiveRoute(maxRate float64) *adaptiveRoute {
+	return &adaptiveRoute{
+		limiter:          rate.NewLimiter(rate.Limit(maxRate), 1),
+		maxRate:          maxRate,
+		recoveryInterval: DefaultAdaptiveRecoveryInterval,
+	}
+}
+
+// WithRecoveryInterval sets the minimal time between rate increases and between rate decreases.
+func (l *AdaptiveLimiter) WithRecoveryInterval(interval time.Duration) *AdaptiveLimiter {
+	for _, route := range []*adaptiveRoute{l.regular, l.telephony} {
+		route.mutex.Lock()
+		route.recoveryInterval = interval
+		route.mutex.Unlock()
+	}
+
+	return l
+}
+
+// Limit the request.
+func (l *AdaptiveLimiter) Limit(ctx context.Context, uri, _ string) error {
+	route := l.route(uri)
+
+	if delay := route.blockedFor(l.now()); delay > 0 {
+		if err := sleepWithContext(ctx, delay); err != nil {
+			return err
+		}
+	}
+
+	return route.limiter.Wait(ctx)
+}
+
+// ProcessResponse adjusts the rate of the route using the response status and headers.
+func (l *AdaptiveLimiter) ProcessResponse(resp *http.Response) {
+	if resp == nil || resp.Request == nil || resp.Request.URL == nil {
+		return
+	}
+
+	l.route(strings.TrimPrefix(resp.Request.URL.Path, "/api/v5")).process(resp, l.now())
+}
+
+// Metrics returns the current limits.
+func (l *AdaptiveLimiter) Metrics() AdaptiveLimiterMetrics {
+	return AdaptiveLimiterMetrics{
+		Regular:   l.regular.metrics(),
+		Telephony: l.telephony.metrics(),
+	}
+}
+
+func (l *AdaptiveLimiter) route(uri string) *adaptiveRoute {
+	if strings.HasPrefix(uri, "/telephony") {
+		return l.telephony
+	}
+
+	return l.regular
+}
+
+func (r *adaptiveRoute) blockedFor(now time.Time) time.Duration {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.blockedUntil.Sub(now)
+}
+
+func (r *adaptiveRoute) process(resp *http.Response, now time.Time) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	current := float64(r.limiter.Limit())
+	remaining, hasRemaining := headerFloat(resp.Header, "X-RateLimit-Remaining")
+
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable ||
+		(hasRemaining && remaining <= 0) {
+		r.throttled++
+
+		// Throttled responses which arrive during the interval were most likely sent before the rate was lowered.
+		if r.lastDecrease.IsZero() || now.Sub(r.lastDecrease) >= r.recoveryInterval {
+			r.setRate(current*adaptiveDecreaseFactor, now)
+			r.lastDecrease = now
+		}
+
+		if delay, ok := RetryAfter(resp); ok && now.Add(delay).After(r.blockedUntil) {
+			r.blockedUntil = now.Add(delay)
+		}
+
+		return
+	}
+
+	if current < r.maxRate && now.Sub(r.lastChange) >= r.recoveryInterval {
+		r.setRate(current+r.maxRate*adaptiveRecoveryRatio, now)
+	}
+}
+
+// setRate changes the rate keeping it between the minimal and the maximal rate. It must be called under the lock.
+func (r *adaptiveRoute) setRate(value float64, now time.Time) {
+	if value > r.maxRate {
+		value = r.maxRate
+	}
+
+	if minRate := r.maxRate * adaptiveMinRateRatio; value < minRate {
+		value = minRate
+	}
+
+	r.limiter.SetLimit(rate.Limit(value))
+	r.lastChange = now
+}
+
+func (r *adaptiveRoute) metrics() AdaptiveLimitMetrics {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return AdaptiveLimitMetrics{
+		Limit:        float64(r.limiter.Limit()),
+		MaxLimit:     r.maxRate,
+		Throttled:    r.throttled,
+		BlockedUntil: r.blockedUntil,
+	}
+}
+
+func headerFloat(header http.Header, name string) (float64, bool) {
+	value := strings.TrimSpace(header.Get(name))
+	if value == "" {
+		return 0, false
+	}
+
+	result, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return result, true
+}
@@ -0,0 +1,142 @@
+package retailcrm
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gock "gopkg.in/h2non/gock.v1"
+)
+
+func adaptiveResponse(path string, status int, header http.Header) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &http.Response{
+		StatusCode: status,
+		Header:     header,
+		Request:    &http.Request{URL: &url.URL{Path: path}},
+	}
+}
+
+func TestAdaptiveLimiter_LowersAndRecovers(t *testing.T) {
+	now := time.Now()
+	limiter := NewAdaptiveLimiter()
+	limiter.now = func() time.Time { return now }
+
+	limiter.ProcessResponse(adaptiveResponse("/api/v5/orders", http.StatusServiceUnavailable, nil))
+	now = now.Add(DefaultAdaptiveRecoveryInterval)
+	limiter.ProcessResponse(adaptiveResponse("/api/v5/orders", http.StatusTooManyRequests, nil))
+
+	metrics := limiter.Metrics()
+	assert.InDelta(t, float64(RPS)/4, metrics.Regular.Limit, 0.001)
+	assert.Equal(t, uint64(2), metrics.Regular.Throttled)
+	assert.InDelta(t, TelephonyRPS, metrics.Telephony.Limit, 0.001, "Telephony limit must not be changed")
+
+	limiter.ProcessResponse(adaptiveResponse("/api/v5/orders", http.StatusOK, nil))
+	assert.InDelta(t, float64(RPS)/4, limiter.Metrics().Regular.Limit, 0.001, "Rate must not recover before the interval")
+
+	now = now.Add(DefaultAdaptiveRecoveryInterval)
+	limiter.ProcessResponse(adaptiveResponse("/api/v5/orders", http.StatusOK, nil))
+	assert.InDelta(t, float64(RPS)/4+RPS*adaptiveRecoveryRatio, limiter.Metrics().Regular.Limit, 0.001)
+
+	for i := 0; i < 20; i++ {
+		now = now.Add(DefaultAdaptiveRecoveryInterval)
+		limiter.ProcessResponse(adaptiveResponse("/api/v5/orders", http.StatusOK, nil))
+	}
+
+	assert.InDelta(t, RPS, limiter.Metrics().Regular.Limit, 0.001)
+}
+
+func TestAdaptiveLimiter_SingleDecreasePerInterval(t *testing.T) {
+	now := time.Now()
+	limiter := NewAdaptiveLimiter()
+	limiter.now = func() time.Time { return now }
+
+	for i := 0; i < 5; i++ {
+		limiter.ProcessResponse(adaptiveResponse("/api/v5/orders", http.StatusServiceUnavailable, nil))
+	}
+
+	metrics := limiter.Metrics()
+	assert.InDelta(t, float64(RPS)/2, metrics.Regular.Limit, 0.001, "Throttles of the same burst must halve the rate once")
+	assert.Equal(t, uint64(5), metrics.Regular.Throttled)
+
+	now = now.Add(DefaultAdaptiveRecoveryInterval / 2)
+	limiter.ProcessResponse(adaptiveResponse("/api/v5/orders", http.StatusTooManyRequests, nil))
+	assert.InDelta(t, float64(RPS)/2, limiter.Metrics().Regular.Limit, 0.001)
+
+	now = now.Add(DefaultAdaptiveRecoveryInterval / 2)
+	limiter.ProcessResponse(adaptiveResponse("/api/v5/orders", http.StatusTooManyRequests, nil))
+	assert.InDelta(t, float64(RPS)/4, limiter.Metrics().Regular.Limit, 0.001)
+}
+
+func TestAdaptiveLimiter_MinimalRate(t *testing.T) {
+	now := time.Now()
+	limiter := NewAdaptiveLimiter()
+	limiter.now = func() time.Time { return now }
+
+	for i := 0; i < 10; i++ {
+		now = now.Add(DefaultAdaptiveRecoveryInterval)
+		limiter.ProcessResponse(adaptiveResponse("/api/v5/telephony/call/event", http.StatusTooManyRequests, nil))
+	}
+
+	assert.InDelta(t, TelephonyRPS*adaptiveMinRateRatio, limiter.Metrics().Telephony.Limit, 0.001)
+	assert.InDelta(t, RPS, limiter.Metrics().Regular.Limit, 0.001)
+}
+
+func TestAdaptiveLimiter_Headers(t *testing.T) {
+	limiter := NewAdaptiveLimiter()
+
+	limiter.ProcessResponse(adaptiveResponse("/api/v5/orders", http.StatusOK, http.Header{
+		"X-Ratelimit-Limit": {"600"},
+	}))
+	assert.InDelta(t, RPS, limiter.Metrics().Regular.MaxLimit, 0.001, "Limit without the time window must be ignored")
+
+	limiter.ProcessResponse(adaptiveResponse("/api/v5/orders", http.StatusOK, http.Header{
+		"X-Ratelimit-Remaining": {"0"},
+	}))
+	assert.InDelta(t, float64(RPS)/2, limiter.Metrics().Regular.Limit, 0.001)
+}
+
+func TestAdaptiveLimiter_RetryAfterBlocksRequests(t *testing.T) {
+	limiter := NewAdaptiveLimiter()
+
+	limiter.ProcessResponse(adaptiveResponse("/api/v5/orders", http.StatusServiceUnavailable, http.Header{
+		"Retry-After": {"1"},
+	}))
+	assert.False(t, limiter.Metrics().Regular.BlockedUntil.IsZero())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	require.ErrorIs(t, limiter.Limit(ctx, "/orders", ""), context.DeadlineExceeded)
+	require.NoError(t, limiter.Limit(context.Background(), "/telephony/manager", ""))
+}
+
+func TestAdaptiveLimiter_Client(t *testing.T) {
+	defer gock.OffAll()
+
+	gock.New(crmURL).
+		Get("/api/v5/orders").
+		Reply(http.StatusServiceUnavailable).
+		BodyString(`{"success": false, "errorMsg": "Rate limit exceeded"}`)
+
+	gock.New(crmURL).
+		Get("/api/v5/orders").
+		Reply(http.StatusOK).
+		BodyString(`{"success": true}`)
+
+	limiter := NewAdaptiveLimiter()
+	c := client().EnableCustomRateLimiter(limiter, 2)
+
+	_, status, err := c.Orders(OrdersRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, uint64(1), limiter.Metrics().Regular.Throttled)
+	assert.Less(t, limiter.Metrics().Regular.Limit, float64(RPS))
+}
@@ -0,0 +1,439 @@
+package retailcrm
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// ErrTokenBucketMismatch will be returned by TokenBucketBackend if the bucket exists with another rate or burst.
+var ErrTokenBucketMismatch = errors.New("token bucket has another rate or burst")
+
+// maxTokenBucketRequestSize is the maximum size of the request body accepted by NewTokenBucketHandler.
+const maxTokenBucketRequestSize = 1 << 12
+
+// TokenBucketBackend stores token buckets which can be shared between processes.
+type TokenBucketBackend interface {
+	// Take reserves a token from the bucket and returns the delay after which the request can be sent.
+	// Bucket is created with the provided rate and burst if it does not exist yet, ErrTokenBucketMismatch
+	// is returned if the existing bucket has another rate or burst.
+	Take(ctx context.Context, bucket string, rps float64, burst int) (time.Duration, error)
+}
+
+// TokenBucketReservation is the token reserved by TokenBucketCanceler.
+type TokenBucketReservation struct {
+	ID    string        // Empty if the reservation cannot be canceled, e.g. if the token can be used immediately.
+	Delay time.Duration // Delay after which the request can be sent.
+}
+
+// TokenBucketCanceler can be implemented by TokenBucketBackend to give back the reserved tokens. DistributedLimiter
+// uses it instead of Take and cancels the reservation if the context is canceled before the delay has passed,
+// so the token can be used by another request.
+type TokenBucketCanceler interface {
+	// Reserve is the same as Take, but also returns the ID of the reservation.
+	Reserve(ctx context.Context, bucket string, rps float64, burst int) (TokenBucketReservation, error)
+	// Cancel gives back the reserved token. Reservations which were already spent or are unknown are ignored.
+	Cancel(ctx context.Context, bucket, id string) error
+}
+
+// DistributedLimiter manages API request rates using the TokenBucketBackend. Limiters in different processes which
+// use the same backend share the regular and telephony limits for the same key. Key is hashed before it is passed
+// to the backend. Backend error is returned from the API method which is being called. Reserved token is given back
+// if the context is canceled during the delay and the backend implements TokenBucketCanceler.
+//
+// Example:
+//
+//	backend := retailcrm.NewHTTPTokenBucketBackend("http://limiter.local:8080")
+//	client := retailcrm.New("https://demo.url", "09jIJ").
+//		EnableCustomRateLimiter(retailcrm.NewDistributedLimiter(backend), 0)
+type DistributedLimiter struct {
+	backend TokenBucketBackend
+}
+
+// NewDistributedLimiter instantiates new DistributedLimiter.
+func NewDistributedLimiter(backend TokenBucketBackend) *DistributedLimiter {
+	return &DistributedLimiter{backend: backend}
+}
+
+// Limit the request.
+func (l *DistributedLimiter) Limit(ctx context.Context, uri, key string) error {
+	bucket, rps := tokenBucketName(key, "regular"), float64(RPS)
+	if strings.HasPrefix(uri, "/telephony") {
+		bucket, rps = tokenBucketName(key, "telephony"), float64(TelephonyRPS)
+	}
+
+	canceler, ok := l.backend.(TokenBucketCanceler)
+	if !ok {
+		delay, err := l.backend.Take(ctx, bucket, rps, 1)
+		if err != nil || delay <= 0 {
+			return err
+		}
+
+		return sleepWithContext(ctx, delay)
+	}
+
+	reservation, err := canceler.Reserve(ctx, bucket, rps, 1)
+	if err != nil || reservation.Delay <= 0 {
+		return err
+	}
+
+	if err := sleepWithContext(ctx, reservation.Delay); err != nil {
+		if reservation.ID != "" {
+			// The context is already canceled, so the token is given back without it.
+			_ = canceler.Cancel(context.Background(), bucket, reservation.ID)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func tokenBucketName(key, route string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:]) + ":" + route
+}
+
+// MemoryTokenBucketBackend keeps token buckets in memory. It can be shared between limiters in the same process
+// or served to other processes via NewTokenBucketHandler.
+//
+// Rate and burst of the bucket are fixed when the bucket is created, Take with other values returns
+// ErrTokenBucketMismatch. Buckets which were not used for the idle timeout are removed, and the least recently used
+// bucket is removed when the maximum amount of buckets is reached, as in MultiKeyLimiter.
+type MemoryTokenBucketBackend struct {
+	buckets     map[string]*list.Element
+	recent      *list.List // Buckets ordered by the last usage, the most recent first.
+	idleTimeout time.Duration
+	maxBuckets  int
+	lastID      uint64 // ID of the last reservation which can be canceled.
+	now         func() time.Time
+	mutex       sync.Mutex
+}
+
+type tokenBucket struct {
+	name     string
+	limiter  *rate.Limiter
+	lastUsed time.Time // Time when the last reserved token is spent.
+	pending  map[string]pendingToken
+}
+
+// pendingToken is the reserved token which is not spent yet.
+type pendingToken struct {
+	reservation *rate.Reservation
+	spentAt     time.Time
+}
+
+// NewMemoryTokenBucketBackend instantiates new MemoryTokenBucketBackend with DefaultLimiterIdleTimeout
+// and DefaultLimiterMaxKeys.
+func NewMemoryTokenBucketBackend() *MemoryTokenBucketBackend {
+	return &MemoryTokenBucketBackend{
+		buckets:     map[string]*list.Element{},
+		recent:      list.New(),
+		idleTimeout: DefaultLimiterIdleTimeout,
+		maxBuckets:  DefaultLimiterMaxKeys,
+		now:         time.Now,
+	}
+}
+
+// WithIdleTimeout sets the time after which unused bucket is removed.
+func (b *MemoryTokenBucketBackend) WithIdleTimeout(timeout time.Duration) *MemoryTokenBucketBackend {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.idleTimeout = timeout
+	return b
+}
+
+// WithMaxBuckets sets the maximum amount of buckets which are stored at once.
+func (b *MemoryTokenBucketBackend) WithMaxBuckets(maxBuckets int) *MemoryTokenBucketBackend {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.maxBuckets = maxBuckets
+	b.evict(b.now())
+	return b
+}
+
+// Len returns the amount of buckets which are stored at the moment.
+func (b *MemoryTokenBucketBackend) Len() int {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.recent.Len()
+}
+
+// Take reserves a token from the bucket and returns the delay after which the request can be sent.
+func (b *MemoryTokenBucketBackend) Take(_ context.Context, bucket string, rps float64, burst int) (time.Duration, error) {
+	reservation, err := b.reserve(bucket, rps, burst, false)
+	return reservation.Delay, err
+}
+
+// Reserve reserves a token from the bucket. Reservation can be canceled until the token is spent.
+func (b *MemoryTokenBucketBackend) Reserve(
+	_ context.Context, bucket string, rps float64, burst int,
+) (TokenBucketReservation, error) {
+	return b.reserve(bucket, rps, burst, true)
+}
+
+// Cancel gives back the reserved token. Reservations which were already spent or are unknown are ignored.
+func (b *MemoryTokenBucketBackend) Cancel(_ context.Context, bucket, id string) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	elem, ok := b.buckets[bucket]
+	if !ok {
+		return nil
+	}
+
+	item := elem.Value.(*tokenBucket)
+	if token, ok := item.pending[id]; ok {
+		token.reservation.CancelAt(b.now())
+		delete(item.pending, id)
+	}
+
+	return nil
+}
+
+func (b *MemoryTokenBucketBackend) reserve(
+	bucket string, rps float64, burst int, cancelable bool,
+) (TokenBucketReservation, error) {
+	if rps <= 0 || burst <= 0 {
+		return TokenBucketReservation{}, errors.New("rate and burst must be positive")
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	now := b.now()
+	b.evict(now)
+
+	elem, ok := b.buckets[bucket]
+	if !ok {
+		elem = b.recent.PushFront(&tokenBucket{
+			name:    bucket,
+			limiter: rate.NewLimiter(rate.Limit(rps), burst),
+			pending: map[string]pendingToken{},
+		})
+		b.buckets[bucket] = elem
+	}
+
+	item := elem.Value.(*tokenBucket)
+	if item.limiter.Limit() != rate.Limit(rps) || item.limiter.Burst() != burst {
+		return TokenBucketReservation{}, fmt.Errorf("%w: bucket %s has rate %v and burst %d",
+			ErrTokenBucketMismatch, bucket, float64(item.limiter.Limit()), item.limiter.Burst())
+	}
+
+	for id, token := range item.pending {
+		if !token.spentAt.After(now) {
+			delete(item.pending, id)
+		}
+	}
+
+	reservation := item.limiter.ReserveN(now, 1)
+	result := TokenBucketReservation{Delay: reservation.DelayFrom(now)}
+	if cancelable && result.Delay > 0 {
+		b.lastID++
+		result.ID = strconv.FormatUint(b.lastID, 10)
+		item.pending[result.ID] = pendingToken{reservation: reservation, spentAt: now.Add(result.Delay)}
+	}
+
+	item.lastUsed = now.Add(result.Delay)
+	b.recent.MoveToFront(elem)
+	b.evict(now)
+
+	return result, nil
+}
+
+// evict removes idle buckets and the least recently used buckets over the limit. It must be called under the lock.
+func (b *MemoryTokenBucketBackend) evict(now time.Time) {
+	for elem := b.recent.Back(); elem != nil; elem = b.recent.Back() {
+		item := elem.Value.(*tokenBucket)
+		overLimit := b.maxBuckets > 0 && b.recent.Len() > b.maxBuckets
+		idle := b.idleTimeout > 0 && now.Sub(item.lastUsed) > b.idleTimeout
+
+		if !overLimit && !idle {
+			return
+		}
+
+		b.recent.Remove(elem)
+		delete(b.buckets, item.name)
+	}
+}
+
+// TokenBucketRequest is the request to the token bucket coordinator.
+type TokenBucketRequest struct {
+	Bucket string  `json:"bucket"`
+	Rate   float64 `json:"rate"`
+	Burst  int     `json:"burst"`
+	Cancel string  `json:"cancel,omitempty"` // ID of the reservation which must be canceled instead of taking a token.
+}
+
+// TokenBucketResponse is the response of the token bucket coordinator.
+type TokenBucketResponse struct {
+	Success  bool          `json:"success"`
+	Delay    time.Duration `json:"delay,omitempty"`
+	ID       string        `json:"id,omitempty"` // ID of the reservation if the backend implements TokenBucketCanceler.
+	ErrorMsg string        `json:"errorMsg,omitempty"`
+}
+
+// NewTokenBucketHandler returns http.Handler which serves the backend to the HTTPTokenBucketBackend instances.
+// It accepts POST requests with TokenBucketRequest body and responds with TokenBucketResponse. Requests with the rate
+// or burst which doesn't match the existing bucket are responded with 409 status code. Reservations can be canceled
+// if the backend implements TokenBucketCanceler.
+//
+// Example:
+//
+//	log.Fatal(http.ListenAndServe(":8080", retailcrm.NewTokenBucketHandler(retailcrm.NewMemoryTokenBucketBackend())))
+func NewTokenBucketHandler(backend TokenBucketBackend) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeTokenBucketResponse(w, http.StatusMethodNotAllowed, TokenBucketResponse{ErrorMsg: "method not allowed"})
+			return
+		}
+
+		var req TokenBucketRequest
+		body := http.MaxBytesReader(w, r.Body, maxTokenBucketRequestSize)
+		if err := json.NewDecoder(body).Decode(&req); err != nil || req.Bucket == "" {
+			writeTokenBucketResponse(w, http.StatusBadRequest, TokenBucketResponse{ErrorMsg: "invalid request"})
+			return
+		}
+
+		canceler, cancelable := backend.(TokenBucketCanceler)
+		if req.Cancel != "" {
+			if !cancelable {
+				writeTokenBucketResponse(w, http.StatusBadRequest, TokenBucketResponse{ErrorMsg: "cancel is not supported"})
+				return
+			}
+
+			if err := canceler.Cancel(r.Context(), req.Bucket, req.Cancel); err != nil {
+				writeTokenBucketResponse(w, http.StatusBadRequest, TokenBucketResponse{ErrorMsg: err.Error()})
+				return
+			}
+
+			writeTokenBucketResponse(w, http.StatusOK, TokenBucketResponse{Success: true})
+			return
+		}
+
+		var (
+			reservation TokenBucketReservation
+			err         error
+		)
+		if cancelable {
+			reservation, err = canceler.Reserve(r.Context(), req.Bucket, req.Rate, req.Burst)
+		} else {
+			reservation.Delay, err = backend.Take(r.Context(), req.Bucket, req.Rate, req.Burst)
+		}
+
+		if errors.Is(err, ErrTokenBucketMismatch) {
+			writeTokenBucketResponse(w, http.StatusConflict, TokenBucketResponse{ErrorMsg: err.Error()})
+			return
+		}
+
+		if err != nil {
+			writeTokenBucketResponse(w, http.StatusBadRequest, TokenBucketResponse{ErrorMsg: err.Error()})
+			return
+		}
+
+		writeTokenBucketResponse(w, http.StatusOK,
+			TokenBucketResponse{Success: true, Delay: reservation.Delay, ID: reservation.ID})
+	})
+}
+
+func writeTokenBucketResponse(w http.ResponseWriter, status int, resp TokenBucketResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// HTTPTokenBucketBackend uses the coordinator which is served by NewTokenBucketHandler.
+type HTTPTokenBucketBackend struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewHTTPTokenBucketBackend instantiates new HTTPTokenBucketBackend for the coordinator URL.
+func NewHTTPTokenBucketBackend(url string) *HTTPTokenBucketBackend {
+	return &HTTPTokenBucketBackend{
+		url:        url,
+		httpClient: &http.Client{Timeout: 5 * time.Second}, // nolint:gomnd
+	}
+}
+
+// WithHTTPClient sets the provided HTTP client instance into the HTTPTokenBucketBackend.
+func (b *HTTPTokenBucketBackend) WithHTTPClient(client *http.Client) *HTTPTokenBucketBackend {
+	b.httpClient = client
+	return b
+}
+
+// Take reserves a token from the bucket and returns the delay after which the request can be sent.
+func (b *HTTPTokenBucketBackend) Take(ctx context.Context, bucket string, rps float64, burst int) (time.Duration, error) {
+	reservation, err := b.Reserve(ctx, bucket, rps, burst)
+	return reservation.Delay, err
+}
+
+// Reserve reserves a token from the bucket. Reservation ID is empty if the coordinator backend doesn't implement
+// TokenBucketCanceler.
+func (b *HTTPTokenBucketBackend) Reserve(
+	ctx context.Context, bucket string, rps float64, burst int,
+) (TokenBucketReservation, error) {
+	result, err := b.send(ctx, TokenBucketRequest{Bucket: bucket, Rate: rps, Burst: burst})
+	if err != nil {
+		return TokenBucketReservation{}, err
+	}
+
+	return TokenBucketReservation{ID: result.ID, Delay: result.Delay}, nil
+}
+
+// Cancel gives back the reserved token.
+func (b *HTTPTokenBucketBackend) Cancel(ctx context.Context, bucket, id string) error {
+	_, err := b.send(ctx, TokenBucketRequest{Bucket: bucket, Cancel: id})
+	return err
+}
+
+func (b *HTTPTokenBucketBackend) send(ctx context.Context, request TokenBucketRequest) (TokenBucketResponse, error) {
+	var result TokenBucketResponse
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return result, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.url, bytes.NewReader(body))
+	if err != nil {
+		return result, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return result, err
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return result, fmt.Errorf("invalid token bucket response (status %d): %w", resp.StatusCode, err)
+	}
+
+	if resp.StatusCode == http.StatusConflict {
+		return result, fmt.Errorf("%w: %s", ErrTokenBucketMismatch, result.ErrorMsg)
+	}
+
+	if !result.Success {
+		return result, fmt.Errorf("token bucket error (status %d): %s", resp.StatusCode, result.ErrorMsg)
+	}
+
+	return result, nil
+}
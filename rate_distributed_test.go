@@ -0,0 +1,225 @@
+package retailcrm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryTokenBucketBackend_Take(t *testing.T) {
+	backend := NewMemoryTokenBucketBackend()
+
+	delay, err := backend.Take(context.Background(), "bucket", 10, 1)
+	require.NoError(t, err)
+	assert.Zero(t, delay)
+
+	delay, err = backend.Take(context.Background(), "bucket", 10, 1)
+	require.NoError(t, err)
+	assert.InDelta(t, 100*time.Millisecond, delay, float64(10*time.Millisecond))
+
+	delay, err = backend.Take(context.Background(), "other", 10, 1)
+	require.NoError(t, err)
+	assert.Zero(t, delay)
+
+	_, err = backend.Take(context.Background(), "bucket", 0, 1)
+	assert.Error(t, err)
+
+	_, err = backend.Take(context.Background(), "bucket", 20, 1)
+	require.ErrorIs(t, err, ErrTokenBucketMismatch)
+
+	_, err = backend.Take(context.Background(), "bucket", 10, 5)
+	require.ErrorIs(t, err, ErrTokenBucketMismatch)
+
+	delay, err = backend.Take(context.Background(), "bucket", 10, 1)
+	require.NoError(t, err)
+	assert.InDelta(t, 200*time.Millisecond, delay, float64(10*time.Millisecond),
+		"Rejected requests must not change the bucket")
+}
+
+func TestMemoryTokenBucketBackend_Eviction(t *testing.T) {
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	backend := NewMemoryTokenBucketBackend().WithIdleTimeout(time.Minute)
+	backend.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	_, err := backend.Take(ctx, "first", 1, 1)
+	require.NoError(t, err)
+
+	delay, err := backend.Take(ctx, "first", 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, delay)
+
+	now = now.Add(time.Minute)
+	_, err = backend.Take(ctx, "second", 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, backend.Len(), "Bucket must not be evicted before the reserved token is spent")
+
+	now = now.Add(2 * time.Second)
+	_, err = backend.Take(ctx, "second", 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, backend.Len(), "Idle bucket must be evicted")
+
+	_, err = backend.Take(ctx, "first", 2, 1)
+	require.NoError(t, err, "Evicted bucket must be created with the new rate")
+
+	backend.WithMaxBuckets(1)
+	assert.Equal(t, 1, backend.Len())
+
+	backend.mutex.Lock()
+	_, ok := backend.buckets["first"]
+	backend.mutex.Unlock()
+	assert.True(t, ok, "Least recently used bucket must be evicted")
+}
+
+func TestDistributedLimiter_SharedBudget(t *testing.T) {
+	backend := NewMemoryTokenBucketBackend()
+	first := NewDistributedLimiter(backend)
+	second := NewDistributedLimiter(backend)
+	ctx := context.Background()
+
+	require.NoError(t, first.Limit(ctx, "/orders", "key"))
+
+	start := time.Now()
+	require.NoError(t, second.Limit(ctx, "/orders", "key"))
+	assert.GreaterOrEqual(t, time.Since(start), regularDelay-20*time.Millisecond,
+		"Second limiter must wait for the budget of the first one")
+
+	start = time.Now()
+	require.NoError(t, second.Limit(ctx, "/telephony/manager", "key"))
+	require.NoError(t, second.Limit(ctx, "/orders", "other-key"))
+	assert.Less(t, time.Since(start), 20*time.Millisecond)
+}
+
+func TestDistributedLimiter_ContextCancellation(t *testing.T) {
+	backend := NewMemoryTokenBucketBackend()
+	limiter := NewDistributedLimiter(backend)
+
+	require.NoError(t, limiter.Limit(context.Background(), "/orders", "key"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	require.ErrorIs(t, limiter.Limit(ctx, "/orders", "key"), context.DeadlineExceeded)
+
+	delay, err := backend.Take(context.Background(), tokenBucketName("key", "regular"), RPS, 1)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, delay, regularDelay, "Token of the canceled request must be given back")
+}
+
+func TestMemoryTokenBucketBackend_Cancel(t *testing.T) {
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	backend := NewMemoryTokenBucketBackend()
+	backend.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	reservation, err := backend.Reserve(ctx, "bucket", 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, TokenBucketReservation{}, reservation, "Immediate reservation must not be cancelable")
+
+	reservation, err = backend.Reserve(ctx, "bucket", 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, reservation.Delay)
+	require.NotEmpty(t, reservation.ID)
+
+	require.NoError(t, backend.Cancel(ctx, "bucket", reservation.ID))
+	require.NoError(t, backend.Cancel(ctx, "bucket", reservation.ID))
+	require.NoError(t, backend.Cancel(ctx, "unknown", "1"))
+
+	delay, err := backend.Take(ctx, "bucket", 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, delay, "Canceled token must be given back")
+
+	now = now.Add(2 * time.Second)
+	_, err = backend.Reserve(ctx, "bucket", 1, 1)
+	require.NoError(t, err)
+
+	backend.mutex.Lock()
+	assert.Empty(t, backend.buckets["bucket"].Value.(*tokenBucket).pending, "Spent reservations must be removed")
+	backend.mutex.Unlock()
+}
+
+func TestHTTPTokenBucketBackend(t *testing.T) {
+	memory := NewMemoryTokenBucketBackend()
+	server := httptest.NewServer(NewTokenBucketHandler(memory))
+	defer server.Close()
+
+	backend := NewHTTPTokenBucketBackend(server.URL).WithHTTPClient(server.Client())
+
+	delay, err := backend.Take(context.Background(), "bucket", 10, 1)
+	require.NoError(t, err)
+	assert.Zero(t, delay)
+
+	delay, err = memory.Take(context.Background(), "bucket", 10, 1)
+	require.NoError(t, err)
+	assert.Positive(t, delay, "Token must be taken from the coordinator bucket")
+
+	_, err = backend.Take(context.Background(), "bucket", -1, 1)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rate and burst must be positive")
+
+	_, err = backend.Take(context.Background(), "bucket", 20, 1)
+	require.ErrorIs(t, err, ErrTokenBucketMismatch)
+
+	reservation, err := backend.Reserve(context.Background(), "bucket", 10, 1)
+	require.NoError(t, err)
+	require.NotEmpty(t, reservation.ID)
+	require.NoError(t, backend.Cancel(context.Background(), "bucket", reservation.ID))
+
+	delay, err = memory.Take(context.Background(), "bucket", 10, 1)
+	require.NoError(t, err)
+	assert.InDelta(t, 200*time.Millisecond, delay, float64(20*time.Millisecond),
+		"Canceled token must be given back to the coordinator bucket")
+
+	limiter := NewDistributedLimiter(backend)
+	require.NoError(t, limiter.Limit(context.Background(), "/orders", "09jIJ"))
+
+	memory.mutex.Lock()
+	for bucket := range memory.buckets {
+		assert.NotContains(t, bucket, "09jIJ", "API key must not be sent to the coordinator")
+	}
+	memory.mutex.Unlock()
+}
+
+func TestTokenBucketHandler_InvalidRequest(t *testing.T) {
+	handler := NewTokenBucketHandler(NewMemoryTokenBucketBackend())
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"rate": 10}`)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{"success": false, "errorMsg": "invalid request"}`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	body := `{"bucket": "` + strings.Repeat("a", maxTokenBucketRequestSize) + `", "rate": 10, "burst": 1}`
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+type takeOnlyBackend struct {
+	TokenBucketBackend
+}
+
+func TestTokenBucketHandler_CancelNotSupported(t *testing.T) {
+	handler := NewTokenBucketHandler(takeOnlyBackend{NewMemoryTokenBucketBackend()})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/",
+		strings.NewReader(`{"bucket": "bucket", "rate": 10, "burst": 1}`)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"success": true}`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/",
+		strings.NewReader(`{"bucket": "bucket", "cancel": "1"}`)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{"success": false, "errorMsg": "cancel is not supported"}`, rec.Body.String())
+}
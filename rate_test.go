@@ -249,3 +249,64 @@ func BenchmarkSingleKeyLimiter_Limit_Telephony(b *testing.B) {
 		_ = limiter.Limit(ctx, "/telephony/test", "test-key")
 	}
 }
+
+func TestMultiKeyLimiter_SeparateKeys(t *testing.T) {
+	limiter := NewMultiKeyLimiter()
+	ctx := context.Background()
+
+	require.NoError(t, limiter.Limit(ctx, "/api/orders", "first"))
+
+	start := time.Now()
+	require.NoError(t, limiter.Limit(ctx, "/api/orders", "second"))
+	assert.Less(t, time.Since(start), 50*time.Millisecond, "Second key was delayed by the first key budget")
+
+	start = time.Now()
+	require.NoError(t, limiter.Limit(ctx, "/api/orders", "first"))
+	assert.GreaterOrEqual(t, time.Since(start), regularDelay-50*time.Millisecond)
+	assert.Equal(t, 2, limiter.Len())
+}
+
+func TestMultiKeyLimiter_IdleEviction(t *testing.T) {
+	now := time.Now()
+	limiter := NewMultiKeyLimiter().WithIdleTimeout(time.Minute)
+	limiter.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	require.NoError(t, limiter.Limit(ctx, "/api/orders", "first"))
+	now = now.Add(30 * time.Second)
+	require.NoError(t, limiter.Limit(ctx, "/api/orders", "second"))
+	assert.Equal(t, 2, limiter.Len())
+
+	now = now.Add(45 * time.Second)
+	require.NoError(t, limiter.Limit(ctx, "/telephony/calls", "second"))
+	assert.Equal(t, 1, limiter.Len(), "Idle key must be evicted")
+
+	_, ok := limiter.limiters["second"]
+	assert.True(t, ok)
+}
+
+func TestMultiKeyLimiter_MaxKeys(t *testing.T) {
+	limiter := NewMultiKeyLimiter().WithMaxKeys(2)
+	ctx := context.Background()
+
+	require.NoError(t, limiter.Limit(ctx, "/telephony/calls", "first"))
+	require.NoError(t, limiter.Limit(ctx, "/telephony/calls", "second"))
+	require.NoError(t, limiter.Limit(ctx, "/telephony/calls", "first"))
+	require.NoError(t, limiter.Limit(ctx, "/telephony/calls", "third"))
+
+	assert.Equal(t, 2, limiter.Len())
+
+	_, ok := limiter.limiters["second"]
+	assert.False(t, ok, "Least recently used key must be evicted")
+}
+
+func TestMultiKeyLimiter_SharedBetweenClients(t *testing.T) {
+	limiter := NewMultiKeyLimiter()
+
+	first := New("https://first.retailcrm.pro", "first-key").EnableCustomRateLimiter(limiter, 0)
+	second := New("https://second.retailcrm.pro", "second-key").EnableCustomRateLimiter(limiter, 0)
+
+	require.NoError(t, first.applyRateLimit(context.Background(), "/orders"))
+	require.NoError(t, second.applyRateLimit(context.Background(), "/orders"))
+	assert.Equal(t, 2, limiter.Len())
+}
@@ -0,0 +1,232 @@
+package retailcrm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultReferenceCacheTTL is the lifetime of the cached reference used if zero TTL was provided.
+const DefaultReferenceCacheTTL = 5 * time.Minute
+
+const referencePrefix = "/reference/"
+
+// referenceDependencies contains dictionaries which responses include data of other dictionaries.
+var referenceDependencies = map[string][]string{
+	"statuses": {"status-groups"},
+}
+
+// ReferenceCache stores responses of the reference methods (/reference/*). Keys of the same dictionary
+// share the same prefix, so the whole dictionary can be removed at once.
+type ReferenceCache interface {
+	// Get returns the cached response if it exists and is not expired.
+	Get(key string) ([]byte, bool)
+	// Set stores the response for the provided time.
+	Set(key string, data []byte, ttl time.Duration)
+	// DeletePrefix removes all responses which keys start with the prefix.
+	DeletePrefix(prefix string)
+}
+
+// MemoryReferenceCache keeps the responses in memory. It can be shared between clients: responses
+// of different accounts and keys are isolated.
+type MemoryReferenceCache struct {
+	entries map[string]referenceCacheEntry
+	mutex   sync.RWMutex
+}
+
+type referenceCacheEntry struct {
+	data      []byte
+	expiresAt time.Time
+}
+
+// NewMemoryReferenceCache instantiates new MemoryReferenceCache.
+func NewMemoryReferenceCache() *MemoryReferenceCache {
+	return &MemoryReferenceCache{entries: map[string]referenceCacheEntry{}}
+}
+
+// Get returns the cached response if it exists and is not expired.
+func (c *MemoryReferenceCache) Get(key string) ([]byte, bool) {
+	c.mutex.RLock()
+	entry, ok := c.entries[key]
+	c.mutex.RUnlock()
+
+	if !ok {
+		return nil, false
+	}
+
+	if time.Now().After(entry.expiresAt) {
+		c.mutex.Lock()
+		if current, ok := c.entries[key]; ok && current.expiresAt.Equal(entry.expiresAt) {
+			delete(c.entries, key)
+		}
+		c.mutex.Unlock()
+
+		return nil, false
+	}
+
+	return append([]byte(nil), entry.data...), true
+}
+
+// Set stores the response for the provided time.
+func (c *MemoryReferenceCache) Set(key string, data []byte, ttl time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries[key] = referenceCacheEntry{
+		data:      append([]byte(nil), data...),
+		expiresAt: time.Now().Add(ttl),
+	}
+}
+
+// DeletePrefix removes all responses which keys start with the prefix.
+func (c *MemoryReferenceCache) DeletePrefix(prefix string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for key := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// WithReferenceCache enables caching of the reference methods (/reference/*) responses, e.g. Statuses or Sites.
+// Edit methods of the references invalidate the cached responses of their dictionary automatically.
+// DefaultReferenceCacheTTL is used if ttl is zero. Pass nil cache to disable caching.
+//
+// Example:
+//
+//	var client = retailcrm.New("https://demo.url", "09jIJ").
+//		WithReferenceCache(retailcrm.NewMemoryReferenceCache(), 10*time.Minute)
+func (c *Client) WithReferenceCache(cache ReferenceCache, ttl time.Duration) *Client {
+	if ttl == 0 {
+		ttl = DefaultReferenceCacheTTL
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.referenceCache = cache
+	c.referenceCacheTTL = ttl
+
+	return c
+}
+
+// InvalidateReferences removes cached responses of the provided dictionaries, e.g. "statuses" or "sites".
+// All cached references of the client are removed if no dictionaries were provided.
+func (c *Client) InvalidateReferences(dictionaries ...string) {
+	cache, _ := c.currentReferenceCache()
+	if cache == nil {
+		return
+	}
+
+	c.referenceMutex.Lock()
+	defer c.referenceMutex.Unlock()
+
+	c.referenceVersion++
+
+	if len(dictionaries) == 0 {
+		cache.DeletePrefix(c.referenceCachePrefix())
+		return
+	}
+
+	for _, dictionary := range dictionaries {
+		cache.DeletePrefix(c.referenceCachePrefix() + dictionary + " ")
+
+		for _, dependent := range referenceDependencies[dictionary] {
+			cache.DeletePrefix(c.referenceCachePrefix() + dependent + " ")
+		}
+	}
+}
+
+func (c *Client) currentReferenceCache() (ReferenceCache, time.Duration) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.referenceCache, c.referenceCacheTTL
+}
+
+// referenceCachePrefix returns the prefix which isolates cached responses of the account and the key.
+func (c *Client) referenceCachePrefix() string {
+	hash := sha256.Sum256([]byte(c.URL + "\x00" + c.Key))
+	return hex.EncodeToString(hash[:16]) + " "
+}
+
+// referenceCacheKey returns the cache key for the reference method URI. It returns false for other methods.
+func (c *Client) referenceCacheKey(uri string) (string, bool) {
+	dictionary, ok := referenceDictionary(uri)
+	if !ok {
+		return "", false
+	}
+
+	return c.referenceCachePrefix() + dictionary + " " + uri, true
+}
+
+// referenceDictionary returns the dictionary name from the reference method URI, e.g. "statuses"
+// for "/reference/statuses/new/edit".
+func referenceDictionary(uri string) (string, bool) {
+	if !strings.HasPrefix(uri, referencePrefix) {
+		return "", false
+	}
+
+	dictionary := strings.TrimPrefix(uri, referencePrefix)
+	if i := strings.IndexAny(dictionary, "/?"); i >= 0 {
+		dictionary = dictionary[:i]
+	}
+
+	return dictionary, dictionary != ""
+}
+
+// cachedReference returns the cached response of the reference method.
+func (c *Client) cachedReference(uri string) ([]byte, bool) {
+	cache, _ := c.currentReferenceCache()
+	if cache == nil {
+		return nil, false
+	}
+
+	key, ok := c.referenceCacheKey(uri)
+	if !ok {
+		return nil, false
+	}
+
+	return cache.Get(key)
+}
+
+// currentReferenceVersion returns the version of the cached references. It must be taken before the request
+// and passed to storeReference.
+func (c *Client) currentReferenceVersion() uint64 {
+	c.referenceMutex.Lock()
+	defer c.referenceMutex.Unlock()
+
+	return c.referenceVersion
+}
+
+// storeReference caches the successful response of the reference method. The response is not cached if
+// the references were invalidated after the provided version was taken, because it may be outdated.
+func (c *Client) storeReference(uri string, data []byte, version uint64) {
+	cache, ttl := c.currentReferenceCache()
+	if cache == nil {
+		return
+	}
+
+	key, ok := c.referenceCacheKey(uri)
+	if !ok {
+		return
+	}
+
+	c.referenceMutex.Lock()
+	defer c.referenceMutex.Unlock()
+
+	if c.referenceVersion == version {
+		cache.Set(key, data, ttl)
+	}
+}
+
+// invalidateReferenceURI removes cached responses of the dictionary which is modified by the reference method.
+func (c *Client) invalidateReferenceURI(uri string) {
+	if dictionary, ok := referenceDictionary(uri); ok {
+		c.InvalidateReferences(dictionary)
+	}
+}
@@ -0,0 +1,225 @@
+package retailcrm
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gock "gopkg.in/h2non/gock.v1"
+)
+
+func TestMemoryReferenceCache(t *testing.T) {
+	cache := NewMemoryReferenceCache()
+	cache.Set("a statuses 1", []byte("1"), time.Minute)
+	cache.Set("a sites 1", []byte("2"), time.Minute)
+	cache.Set("a expired 1", []byte("3"), -time.Second)
+
+	data, ok := cache.Get("a statuses 1")
+	require.True(t, ok)
+	assert.Equal(t, "1", string(data))
+
+	data[0] = 'x'
+	data, _ = cache.Get("a statuses 1")
+	assert.Equal(t, "1", string(data), "cached data must not be modified by the caller")
+
+	_, ok = cache.Get("a expired 1")
+	assert.False(t, ok)
+
+	cache.DeletePrefix("a statuses ")
+	_, ok = cache.Get("a statuses 1")
+	assert.False(t, ok)
+	_, ok = cache.Get("a sites 1")
+	assert.True(t, ok)
+}
+
+func TestReferenceDictionary(t *testing.T) {
+	cases := map[string]string{
+		"/reference/statuses":               "statuses",
+		"/reference/statuses/new/edit":      "statuses",
+		"/reference/sites?limit=20":         "sites",
+		"/reference/payment-types/create":   "payment-types",
+		"/reference/mg-channels/templates?": "mg-channels",
+	}
+
+	for uri, expected := range cases {
+		dictionary, ok := referenceDictionary(uri)
+		assert.True(t, ok, uri)
+		assert.Equal(t, expected, dictionary, uri)
+	}
+
+	_, ok := referenceDictionary("/orders")
+	assert.False(t, ok)
+	_, ok = referenceDictionary("/reference/")
+	assert.False(t, ok)
+}
+
+func TestClient_ReferenceCache(t *testing.T) {
+	defer gock.Off()
+
+	c := client().WithReferenceCache(NewMemoryReferenceCache(), time.Minute)
+
+	gock.New(crmURL).
+		Get("/reference/statuses").
+		Times(1).
+		Reply(http.StatusOK).
+		BodyString(`{"success": true, "statuses": {"new": {"code": "new", "name": "New"}}}`)
+
+	for i := 0; i < 3; i++ {
+		data, st, err := c.Statuses()
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, st)
+		assert.Equal(t, "New", data.Statuses["new"].Name)
+	}
+
+	assert.True(t, gock.IsDone())
+}
+
+func TestClient_ReferenceCache_EditInvalidates(t *testing.T) {
+	defer gock.Off()
+
+	c := client().WithReferenceCache(NewMemoryReferenceCache(), time.Minute)
+
+	gock.New(crmURL).
+		Get("/reference/statuses").
+		Reply(http.StatusOK).
+		BodyString(`{"success": true, "statuses": {"new": {"code": "new", "name": "New"}}}`)
+	gock.New(crmURL).
+		Get("/reference/status-groups").
+		Reply(http.StatusOK).
+		BodyString(`{"success": true}`)
+	gock.New(crmURL).
+		Get("/reference/sites").
+		Reply(http.StatusOK).
+		BodyString(`{"success": true}`)
+
+	_, _, err := c.Statuses()
+	require.NoError(t, err)
+	_, _, err = c.StatusGroups()
+	require.NoError(t, err)
+	_, _, err = c.Sites()
+	require.NoError(t, err)
+	require.True(t, gock.IsDone())
+
+	gock.New(crmURL).
+		Post("/reference/statuses/new/edit").
+		Reply(http.StatusOK).
+		BodyString(`{"success": true}`)
+
+	_, _, err = c.StatusEdit(Status{Code: "new", Name: "Renamed", Group: "new"})
+	require.NoError(t, err)
+
+	gock.New(crmURL).
+		Get("/reference/statuses").
+		Reply(http.StatusOK).
+		BodyString(`{"success": true, "statuses": {"new": {"code": "new", "name": "Renamed"}}}`)
+	gock.New(crmURL).
+		Get("/reference/status-groups").
+		Reply(http.StatusOK).
+		BodyString(`{"success": true}`)
+
+	data, _, err := c.Statuses()
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed", data.Statuses["new"].Name)
+	_, _, err = c.StatusGroups()
+	require.NoError(t, err)
+	_, _, err = c.Sites()
+	require.NoError(t, err)
+
+	assert.True(t, gock.IsDone(), "sites must stay cached, statuses and status groups must be requested again")
+}
+
+func TestClient_ReferenceCache_Isolation(t *testing.T) {
+	defer gock.Off()
+
+	cache := NewMemoryReferenceCache()
+	first := New(crmURL, "first").WithReferenceCache(cache, time.Minute)
+	second := New(crmURL, "second").WithReferenceCache(cache, time.Minute)
+
+	gock.New(crmURL).
+		Get("/reference/sites").
+		MatchHeader("X-API-KEY", "first").
+		Reply(http.StatusOK).
+		BodyString(`{"success": true}`)
+	gock.New(crmURL).
+		Get("/reference/sites").
+		MatchHeader("X-API-KEY", "second").
+		Reply(http.StatusOK).
+		BodyString(`{"success": true}`)
+
+	_, _, err := first.Sites()
+	require.NoError(t, err)
+	_, _, err = second.Sites()
+	require.NoError(t, err)
+	assert.True(t, gock.IsDone())
+
+	first.InvalidateReferences()
+	_, ok := second.cachedReference("/reference/sites")
+	assert.True(t, ok)
+	_, ok = first.cachedReference("/reference/sites")
+	assert.False(t, ok)
+}
+
+func TestClient_ReferenceCache_SkipsErrors(t *testing.T) {
+	defer gock.Off()
+
+	c := client().WithReferenceCache(NewMemoryReferenceCache(), time.Minute)
+
+	gock.New(crmURL).
+		Get("/reference/sites").
+		Reply(http.StatusForbidden).
+		BodyString(`{"success": false, "errorMsg": "Access denied"}`)
+	gock.New(crmURL).
+		Get("/reference/sites").
+		Reply(http.StatusOK).
+		BodyString(`{"success": true}`)
+
+	_, _, err := c.Sites()
+	require.Error(t, err)
+	_, st, err := c.Sites()
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, st)
+	assert.True(t, gock.IsDone())
+}
+
+func TestClient_ReferenceCache_SkipsOutdated(t *testing.T) {
+	c := client().WithReferenceCache(NewMemoryReferenceCache(), time.Minute)
+
+	version := c.currentReferenceVersion()
+	c.InvalidateReferences("statuses")
+	c.storeReference("/reference/statuses", []byte(`{"success": true}`), version)
+
+	_, ok := c.cachedReference("/reference/statuses")
+	assert.False(t, ok, "response received before the invalidation must not be cached")
+
+	c.storeReference("/reference/statuses", []byte(`{"success": true}`), c.currentReferenceVersion())
+
+	_, ok = c.cachedReference("/reference/statuses")
+	assert.True(t, ok)
+}
+
+func TestClient_ReferenceCache_Observer(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(crmURL).
+		Get("/reference/sites").
+		Times(1).
+		Reply(http.StatusOK).
+		BodyString(`{"success": true, "sites": {}}`)
+
+	observer := &recordingObserver{}
+	c := client().WithReferenceCache(NewMemoryReferenceCache(), time.Minute).WithObserver(observer)
+
+	for i := 0; i < 2; i++ {
+		_, _, err := c.Sites()
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, []string{"start", "done", "start", "done"}, observer.events)
+	assert.Equal(t, RequestInfo{Method: "Sites", HTTPMethod: http.MethodGet, Path: "/reference/sites"}, observer.infos[1])
+	assert.False(t, observer.results[0].Cached)
+	assert.True(t, observer.results[1].Cached)
+	assert.Equal(t, http.StatusOK, observer.results[1].StatusCode)
+	assert.Equal(t, uint(0), observer.results[1].Attempts)
+}
@@ -0,0 +1,284 @@
+package retailcrm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ErrReferencesNotLoaded will be returned by References.ValidateOrder if References.Load was not called.
+var ErrReferencesNotLoaded = errors.New("references are not loaded")
+
+// References provides typed lookups over the reference dictionaries of the account. Dictionaries are loaded by Load
+// and kept until the next Load call. Use Client.WithReferenceCache to avoid requesting them from the API every time.
+//
+// Example:
+//
+//	var client = retailcrm.New("https://demo.url", "09jIJ")
+//
+//	refs := retailcrm.NewReferences(client)
+//	if err := refs.Load(context.Background()); err != nil {
+//		log.Fatalf("cannot load references: %s", err)
+//	}
+//
+//	if err := refs.ValidateOrder(order); err != nil {
+//		log.Fatalf("invalid order: %s", err)
+//	}
+//
+//	data, status, err := client.OrderCreate(order)
+type References struct {
+	client   *Client
+	snapshot *referencesSnapshot
+	mutex    sync.RWMutex
+}
+
+type referencesSnapshot struct {
+	statuses      map[string]Status
+	statusGroups  map[string]StatusGroup
+	paymentTypes  map[string]PaymentType
+	deliveryTypes map[string]DeliveryType
+	sites         map[string]Site
+	stores        map[string]Store
+	orderTypes    map[string]OrderType
+	orderMethods  map[string]OrderMethod
+}
+
+// NewReferences instantiates new References. Call Load before using the lookups.
+func NewReferences(client *Client) *References {
+	return &References{client: client}
+}
+
+// Load requests all dictionaries from the API and replaces the loaded ones. Previously loaded dictionaries
+// are kept if any of the requests fails.
+func (r *References) Load(ctx context.Context) error {
+	snapshot := &referencesSnapshot{}
+
+	statuses, _, err := r.client.StatusesCtx(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot load statuses: %w", err)
+	}
+	snapshot.statuses = statuses.Statuses
+
+	groups, _, err := r.client.StatusGroupsCtx(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot load status groups: %w", err)
+	}
+	snapshot.statusGroups = groups.StatusGroups
+
+	paymentTypes, _, err := r.client.PaymentTypesCtx(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot load payment types: %w", err)
+	}
+	snapshot.paymentTypes = paymentTypes.PaymentTypes
+
+	deliveryTypes, _, err := r.client.DeliveryTypesCtx(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot load delivery types: %w", err)
+	}
+	snapshot.deliveryTypes = deliveryTypes.DeliveryTypes
+
+	sites, _, err := r.client.SitesCtx(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot load sites: %w", err)
+	}
+	snapshot.sites = sites.Sites
+
+	stores, _, err := r.client.StoresCtx(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot load stores: %w", err)
+	}
+	snapshot.stores = make(map[string]Store, len(stores.Stores))
+	for _, store := range stores.Stores {
+		snapshot.stores[store.Code] = store
+	}
+
+	orderTypes, _, err := r.client.OrderTypesCtx(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot load order types: %w", err)
+	}
+	snapshot.orderTypes = orderTypes.OrderTypes
+
+	orderMethods, _, err := r.client.OrderMethodsCtx(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot load order methods: %w", err)
+	}
+	snapshot.orderMethods = orderMethods.OrderMethods
+
+	r.mutex.Lock()
+	r.snapshot = snapshot
+	r.mutex.Unlock()
+
+	return nil
+}
+
+// Loaded returns true if the dictionaries were loaded.
+func (r *References) Loaded() bool {
+	return r.current() != nil
+}
+
+// StatusByCode returns the order status with the provided code.
+func (r *References) StatusByCode(code string) (Status, bool) {
+	return lookupReference(r, code, func(s *referencesSnapshot) map[string]Status { return s.statuses })
+}
+
+// StatusGroupByCode returns the status group with the provided code.
+func (r *References) StatusGroupByCode(code string) (StatusGroup, bool) {
+	return lookupReference(r, code, func(s *referencesSnapshot) map[string]StatusGroup { return s.statusGroups })
+}
+
+// StatusGroupOf returns the group of the order status with the provided code.
+func (r *References) StatusGroupOf(statusCode string) (StatusGroup, bool) {
+	status, ok := r.StatusByCode(statusCode)
+	if !ok {
+		return StatusGroup{}, false
+	}
+
+	return r.StatusGroupByCode(status.Group)
+}
+
+// StatusesInGroup returns statuses of the group sorted by their ordering.
+func (r *References) StatusesInGroup(groupCode string) []Status {
+	snapshot := r.current()
+	if snapshot == nil {
+		return nil
+	}
+
+	var statuses []Status
+	for _, status := range snapshot.statuses {
+		if status.Group == groupCode {
+			statuses = append(statuses, status)
+		}
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].Ordering == statuses[j].Ordering {
+			return statuses[i].Code < statuses[j].Code
+		}
+
+		return statuses[i].Ordering < statuses[j].Ordering
+	})
+
+	return statuses
+}
+
+// PaymentTypeByCode returns the payment type with the provided code.
+func (r *References) PaymentTypeByCode(code string) (PaymentType, bool) {
+	return lookupReference(r, code, func(s *referencesSnapshot) map[string]PaymentType { return s.paymentTypes })
+}
+
+// DeliveryTypeByCode returns the delivery type with the provided code.
+func (r *References) DeliveryTypeByCode(code string) (DeliveryType, bool) {
+	return lookupReference(r, code, func(s *referencesSnapshot) map[string]DeliveryType { return s.deliveryTypes })
+}
+
+// SiteByCode returns the site (store in the UI) with the provided code.
+func (r *References) SiteByCode(code string) (Site, bool) {
+	return lookupReference(r, code, func(s *referencesSnapshot) map[string]Site { return s.sites })
+}
+
+// StoreByCode returns the warehouse with the provided code.
+func (r *References) StoreByCode(code string) (Store, bool) {
+	return lookupReference(r, code, func(s *referencesSnapshot) map[string]Store { return s.stores })
+}
+
+// OrderTypeByCode returns the order type with the provided code.
+func (r *References) OrderTypeByCode(code string) (OrderType, bool) {
+	return lookupReference(r, code, func(s *referencesSnapshot) map[string]OrderType { return s.orderTypes })
+}
+
+// OrderMethodByCode returns the order method with the provided code.
+func (r *References) OrderMethodByCode(code string) (OrderMethod, bool) {
+	return lookupReference(r, code, func(s *referencesSnapshot) map[string]OrderMethod { return s.orderMethods })
+}
+
+// ValidateOrder checks that status, order type, order method, delivery type, payment types and site of the order
+// exist and are active. Empty fields are skipped. Returned error can be matched with ErrValidation, the invalid
+// fields are available via APIError.Errors in the same format as the API returns them.
+//
+// Example:
+//
+//	if err := refs.ValidateOrder(order); err != nil {
+//		if apiErr, ok := retailcrm.AsAPIError(err); ok {
+//			for field, msg := range apiErr.Errors() {
+//				log.Printf("%s: %s\n", field, msg)
+//			}
+//		}
+//	}
+func (r *References) ValidateOrder(order Order) error {
+	if !r.Loaded() {
+		return ErrReferencesNotLoaded
+	}
+
+	errs := APIErrorsList{}
+
+	if order.Status != "" {
+		status, ok := r.StatusByCode(order.Status)
+		checkReference(errs, "status", "status", order.Status, ok, status.Active)
+	}
+
+	if order.OrderType != "" {
+		orderType, ok := r.OrderTypeByCode(order.OrderType)
+		checkReference(errs, "orderType", "order type", order.OrderType, ok, orderType.Active)
+	}
+
+	if order.OrderMethod != "" {
+		method, ok := r.OrderMethodByCode(order.OrderMethod)
+		checkReference(errs, "orderMethod", "order method", order.OrderMethod, ok, method.Active)
+	}
+
+	if order.Delivery != nil && order.Delivery.Code != "" {
+		deliveryType, ok := r.DeliveryTypeByCode(order.Delivery.Code)
+		checkReference(errs, "delivery[code]", "delivery type", order.Delivery.Code, ok, deliveryType.Active)
+	}
+
+	for key, payment := range order.Payments {
+		if payment.Type == "" {
+			continue
+		}
+
+		paymentType, ok := r.PaymentTypeByCode(payment.Type)
+		checkReference(errs, fmt.Sprintf("payments[%s][type]", key), "payment type", payment.Type, ok, paymentType.Active)
+	}
+
+	if order.Site != "" {
+		if _, ok := r.SiteByCode(order.Site); !ok {
+			errs["site"] = fmt.Sprintf(`site "%s" does not exist`, order.Site)
+		}
+	}
+
+	if len(errs) > 0 {
+		return NewAPIError("order references are invalid").withWrapped(ErrValidation).withErrors(errs)
+	}
+
+	return nil
+}
+
+func (r *References) current() *referencesSnapshot {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return r.snapshot
+}
+
+func lookupReference[T any](r *References, code string, dictionary func(*referencesSnapshot) map[string]T) (T, bool) {
+	var empty T
+
+	snapshot := r.current()
+	if snapshot == nil {
+		return empty, false
+	}
+
+	item, ok := dictionary(snapshot)[code]
+	return item, ok
+}
+
+func checkReference(errs APIErrorsList, field, name, code string, exists, active bool) {
+	switch {
+	case !exists:
+		errs[field] = fmt.Sprintf(`%s "%s" does not exist`, name, code)
+	case !active:
+		errs[field] = fmt.Sprintf(`%s "%s" is not active`, name, code)
+	}
+}
@@ -0,0 +1,163 @@
+package retailcrm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gock "gopkg.in/h2non/gock.v1"
+)
+
+func mockReferences() {
+	gock.New(crmURL).
+		Get("/reference/statuses").
+		Reply(http.StatusOK).
+		BodyString(`{"success": true, "statuses": {
+			"new": {"code": "new", "name": "New", "active": true, "ordering": 10, "group": "new"},
+			"availability-confirmed": {"code": "availability-confirmed", "active": true, "ordering": 20, "group": "new"},
+			"old": {"code": "old", "active": false, "ordering": 5, "group": "new"},
+			"complete": {"code": "complete", "active": true, "ordering": 10, "group": "complete"}
+		}}`)
+	gock.New(crmURL).
+		Get("/reference/status-groups").
+		Reply(http.StatusOK).
+		BodyString(`{"success": true, "statusGroups": {
+			"new": {"code": "new", "name": "New", "active": true, "statuses": ["new", "availability-confirmed", "old"]},
+			"complete": {"code": "complete", "name": "Complete", "active": true, "statuses": ["complete"]}
+		}}`)
+	gock.New(crmURL).
+		Get("/reference/payment-types").
+		Reply(http.StatusOK).
+		BodyString(`{"success": true, "paymentTypes": {
+			"cash": {"code": "cash", "active": true},
+			"bank-card": {"code": "bank-card", "active": false}
+		}}`)
+	gock.New(crmURL).
+		Get("/reference/delivery-types").
+		Reply(http.StatusOK).
+		BodyString(`{"success": true, "deliveryTypes": {"courier": {"code": "courier", "active": true}}}`)
+	gock.New(crmURL).
+		Get("/reference/sites").
+		Reply(http.StatusOK).
+		BodyString(`{"success": true, "sites": {"shop": {"code": "shop", "name": "Shop"}}}`)
+	gock.New(crmURL).
+		Get("/reference/stores").
+		Reply(http.StatusOK).
+		BodyString(`{"success": true, "stores": [{"code": "main", "name": "Main", "active": true}]}`)
+	gock.New(crmURL).
+		Get("/reference/order-types").
+		Reply(http.StatusOK).
+		BodyString(`{"success": true, "orderTypes": {"eshop-individual": {"code": "eshop-individual", "active": true}}}`)
+	gock.New(crmURL).
+		Get("/reference/order-methods").
+		Reply(http.StatusOK).
+		BodyString(`{"success": true, "orderMethods": {"phone": {"code": "phone", "active": true}}}`)
+}
+
+func loadedReferences(t *testing.T) *References {
+	mockReferences()
+
+	refs := NewReferences(client())
+	require.NoError(t, refs.Load(context.Background()))
+	require.True(t, gock.IsDone())
+
+	return refs
+}
+
+func TestReferences_Lookups(t *testing.T) {
+	defer gock.Off()
+
+	refs := loadedReferences(t)
+
+	status, ok := refs.StatusByCode("new")
+	require.True(t, ok)
+	assert.Equal(t, "New", status.Name)
+
+	group, ok := refs.StatusGroupOf("complete")
+	require.True(t, ok)
+	assert.Equal(t, "Complete", group.Name)
+
+	var codes []string
+	for _, status := range refs.StatusesInGroup("new") {
+		codes = append(codes, status.Code)
+	}
+	assert.Equal(t, []string{"old", "new", "availability-confirmed"}, codes)
+
+	_, ok = refs.PaymentTypeByCode("cash")
+	assert.True(t, ok)
+	_, ok = refs.DeliveryTypeByCode("courier")
+	assert.True(t, ok)
+	site, ok := refs.SiteByCode("shop")
+	assert.True(t, ok)
+	assert.Equal(t, "Shop", site.Name)
+	store, ok := refs.StoreByCode("main")
+	assert.True(t, ok)
+	assert.Equal(t, "Main", store.Name)
+
+	_, ok = refs.StatusByCode("unknown")
+	assert.False(t, ok)
+	_, ok = refs.StatusGroupOf("unknown")
+	assert.False(t, ok)
+}
+
+func TestReferences_NotLoaded(t *testing.T) {
+	refs := NewReferences(client())
+
+	_, ok := refs.StatusByCode("new")
+	assert.False(t, ok)
+	assert.Empty(t, refs.StatusesInGroup("new"))
+	assert.ErrorIs(t, refs.ValidateOrder(Order{Status: "new"}), ErrReferencesNotLoaded)
+}
+
+func TestReferences_LoadError(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(crmURL).
+		Get("/reference/statuses").
+		Reply(http.StatusForbidden).
+		BodyString(`{"success": false, "errorMsg": "Access denied."}`)
+
+	refs := NewReferences(client())
+	err := refs.Load(context.Background())
+
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrAccessDenied))
+	assert.False(t, refs.Loaded())
+}
+
+func TestReferences_ValidateOrder(t *testing.T) {
+	defer gock.Off()
+
+	refs := loadedReferences(t)
+
+	assert.NoError(t, refs.ValidateOrder(Order{
+		Status:      "new",
+		OrderType:   "eshop-individual",
+		OrderMethod: "phone",
+		Site:        "shop",
+		Delivery:    &OrderDelivery{Code: "courier"},
+		Payments:    OrderPayments{"1": {Type: "cash"}},
+	}))
+
+	err := refs.ValidateOrder(Order{
+		Status:      "old",
+		OrderType:   "unknown",
+		OrderMethod: "phone",
+		Site:        "other",
+		Payments:    OrderPayments{"1": {Type: "bank-card"}},
+	})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrValidation)
+
+	apiErr, ok := AsAPIError(err)
+	require.True(t, ok)
+	assert.Equal(t, APIErrorsList{
+		"status":            `status "old" is not active`,
+		"orderType":         `order type "unknown" does not exist`,
+		"site":              `site "other" does not exist`,
+		"payments[1][type]": `payment type "bank-card" is not active`,
+	}, apiErr.Errors())
+}
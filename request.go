@@ -124,6 +124,13 @@ type UsersRequest struct {
 	Page   int         `url:"page,omitempty"`
 }
 
+// TelephonyManagerRequest type.
+type TelephonyManagerRequest struct {
+	Phone        string `url:"phone"`
+	Details      bool   `url:"details,omitempty,int"`
+	IgnoreStatus bool   `url:"ignoreStatus,omitempty,int"`
+}
+
 // UserGroupsRequest type.
 type UserGroupsRequest struct {
 	Limit int `url:"limit,omitempty"`
@@ -445,6 +445,27 @@ type IntegrationModuleResponse struct {
 	IntegrationModule *IntegrationModule `json:"integrationModule,omitempty"`
 }
 
+// TelephonySettingResponse type.
+type TelephonySettingResponse struct {
+	Success       bool                    `json:"success"`
+	Configuration *TelephonyConfiguration `json:"configuration,omitempty"`
+}
+
+// TelephonyCallsUploadResponse type.
+type TelephonyCallsUploadResponse struct {
+	Success             bool     `json:"success"`
+	ProcessedCallsCount int      `json:"processedCallsCount,omitempty"`
+	DuplicateCalls      []string `json:"duplicateCalls,omitempty"`
+}
+
+// TelephonyManagerResponse type.
+type TelephonyManagerResponse struct {
+	Success  bool                   `json:"success"`
+	Manager  *TelephonyManager      `json:"manager,omitempty"`
+	Customer *Customer              `json:"customer,omitempty"`
+	Links    *TelephonyManagerLinks `json:"links,omitempty"`
+}
+
 // UpdateScopesResponse update scopes response.
 type UpdateScopesResponse struct {
 	ErrorResponse
@@ -0,0 +1,252 @@
+package retailcrm
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultRetryBaseDelay is the minimal delay between attempts used by BackoffRetryPolicy.
+	DefaultRetryBaseDelay = 100 * time.Millisecond
+	// DefaultRetryMaxDelay is the maximal delay between attempts used by BackoffRetryPolicy.
+	DefaultRetryMaxDelay = 30 * time.Second
+)
+
+// RetryReason describes why the request attempt can be retried.
+type RetryReason int
+
+const (
+	// RetryReasonNone means that the result is final and the request must not be retried.
+	RetryReasonNone RetryReason = iota
+	// RetryReasonRateLimited is used for 429 and 503 responses. The request was not processed by the system.
+	RetryReasonRateLimited
+	// RetryReasonServerError is used for other 5xx responses. The request may have been processed.
+	RetryReasonServerError
+	// RetryReasonNetworkError is used for transport errors. The request may have been processed.
+	RetryReasonNetworkError
+)
+
+// String returns text representation of the reason.
+func (r RetryReason) String() string {
+	switch r {
+	case RetryReasonRateLimited:
+		return "rate limited"
+	case RetryReasonServerError:
+		return "server error"
+	case RetryReasonNetworkError:
+		return "network error"
+	default:
+		return "none"
+	}
+}
+
+// ClassifyRetry returns the reason to retry the request attempt with the provided result.
+// Context cancellation is never considered retryable.
+func ClassifyRetry(statusCode int, err error) RetryReason {
+	switch {
+	case statusCode == http.StatusServiceUnavailable || statusCode == http.StatusTooManyRequests:
+		return RetryReasonRateLimited
+	case statusCode >= http.StatusInternalServerError:
+		return RetryReasonServerError
+	case statusCode == 0 && err != nil:
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return RetryReasonNone
+		}
+
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return RetryReasonNetworkError
+		}
+	}
+
+	return RetryReasonNone
+}
+
+// RetryAttempt contains the result of the finished request attempt.
+type RetryAttempt struct {
+	Method     string
+	URI        string
+	Attempt    uint           // Number of the finished attempt starting from 1.
+	StatusCode int            // Zero if the response was not received.
+	Response   *http.Response // Can be nil if the response was not received.
+	Err        error
+	Reason     RetryReason
+	Idempotent bool          // False for POST requests unless the retries were allowed via AllowNonIdempotentRetries.
+	PrevDelay  time.Duration // Delay before the finished attempt, zero for the first attempt.
+}
+
+// RetryPolicy decides whether the request must be sent again and how long to wait before the next attempt.
+// Policy is shared between concurrent requests, so every state of the single request is passed via RetryAttempt.
+type RetryPolicy interface {
+	// Retry returns the delay before the next attempt. Request will not be retried if false is returned.
+	Retry(attempt RetryAttempt) (time.Duration, bool)
+}
+
+type nonIdempotentRetriesKey struct{}
+
+// AllowNonIdempotentRetries returns the context which allows retrying non-idempotent requests made with it
+// on server and network errors. Use it only if the repeated request cannot produce duplicates, e.g. when the order
+// is created with an externalId.
+//
+// Example:
+//
+//	ctx := retailcrm.AllowNonIdempotentRetries(context.Background())
+//	data, status, err := client.OrderCreateCtx(ctx, retailcrm.Order{ExternalID: "ext-1"})
+func AllowNonIdempotentRetries(ctx context.Context) context.Context {
+	return context.WithValue(ctx, nonIdempotentRetriesKey{}, true)
+}
+
+// isIdempotentRequest returns true if the request with the provided method can be safely sent again.
+func isIdempotentRequest(ctx context.Context, method string) bool {
+	if method != http.MethodPost {
+		return true
+	}
+
+	allowed, _ := ctx.Value(nonIdempotentRetriesKey{}).(bool)
+	return allowed
+}
+
+// RetryAfter returns the delay from the Retry-After response header. Both seconds and HTTP-date formats are supported.
+func RetryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	value := strings.TrimSpace(resp.Header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+
+		return delay, true
+	}
+
+	return 0, false
+}
+
+// BackoffRetryPolicy retries rate limited requests, server and network errors using decorrelated jitter backoff.
+// Delay from the Retry-After header is used if it is present. Non-idempotent requests are retried only when
+// they were rate limited, unless the retries were allowed for the policy or for the request context.
+//
+// Example:
+//
+//	var client = retailcrm.New("https://demo.url", "09jIJ").
+//		WithRetryPolicy(retailcrm.NewBackoffRetryPolicy(5).WithDelays(time.Second, time.Minute))
+type BackoffRetryPolicy struct {
+	maxAttempts        uint
+	baseDelay          time.Duration
+	maxDelay           time.Duration
+	retryNonIdempotent bool
+	classify           func(attempt RetryAttempt) RetryReason
+}
+
+// NewBackoffRetryPolicy instantiates new BackoffRetryPolicy with the specified attempts (0 = infinite).
+func NewBackoffRetryPolicy(maxAttempts uint) *BackoffRetryPolicy {
+	return &BackoffRetryPolicy{
+		maxAttempts: maxAttempts,
+		baseDelay:   DefaultRetryBaseDelay,
+		maxDelay:    DefaultRetryMaxDelay,
+	}
+}
+
+// WithDelays sets the minimal and the maximal delays between attempts. Retry-After delay is capped by maxDelay too.
+func (p *BackoffRetryPolicy) WithDelays(baseDelay, maxDelay time.Duration) *BackoffRetryPolicy {
+	p.baseDelay = baseDelay
+	p.maxDelay = maxDelay
+	return p
+}
+
+// WithNonIdempotentRetries allows retrying POST requests on server and network errors.
+func (p *BackoffRetryPolicy) WithNonIdempotentRetries() *BackoffRetryPolicy {
+	p.retryNonIdempotent = true
+	return p
+}
+
+// WithClassifier replaces the classification of the attempts. Attempt.Reason contains the default classification.
+func (p *BackoffRetryPolicy) WithClassifier(classify func(attempt RetryAttempt) RetryReason) *BackoffRetryPolicy {
+	p.classify = classify
+	return p
+}
+
+// Retry returns the delay before the next attempt.
+func (p *BackoffRetryPolicy) Retry(attempt RetryAttempt) (time.Duration, bool) {
+	reason := attempt.Reason
+	if p.classify != nil {
+		reason = p.classify(attempt)
+	}
+
+	if reason == RetryReasonNone || (p.maxAttempts != 0 && attempt.Attempt >= p.maxAttempts) {
+		return 0, false
+	}
+
+	if reason != RetryReasonRateLimited && !attempt.Idempotent && !p.retryNonIdempotent {
+		return 0, false
+	}
+
+	if delay, ok := RetryAfter(attempt.Response); ok {
+		if delay > p.maxDelay {
+			delay = p.maxDelay
+		}
+
+		return delay, true
+	}
+
+	return p.jitter(attempt.PrevDelay), true
+}
+
+// jitter returns random delay between baseDelay and tripled previous delay capped by maxDelay.
+func (p *BackoffRetryPolicy) jitter(prev time.Duration) time.Duration {
+	if prev < p.baseDelay {
+		prev = p.baseDelay
+	}
+
+	upper := prev * 3 // nolint:gomnd
+	if upper > p.maxDelay || upper <= 0 {
+		upper = p.maxDelay
+	}
+
+	if upper <= p.baseDelay {
+		return upper
+	}
+
+	return p.baseDelay + time.Duration(rand.Int63n(int64(upper-p.baseDelay))) // nolint:gosec
+}
+
+// rateLimitRetryPolicy is used by the rate limiter if no RetryPolicy was provided.
+// It retries only rate limited requests using the exponential backoff: baseDelay * 2^(attempt-1).
+type rateLimitRetryPolicy struct {
+	maxAttempts uint // Maximum number of retry attempts (0 = infinite).
+}
+
+// Retry returns the delay before the next attempt.
+func (p rateLimitRetryPolicy) Retry(attempt RetryAttempt) (time.Duration, bool) {
+	if attempt.Reason != RetryReasonRateLimited || (p.maxAttempts != 0 && attempt.Attempt >= p.maxAttempts) {
+		return 0, false
+	}
+
+	baseDelay := regularDelay
+	if strings.HasPrefix(attempt.URI, "/telephony") {
+		baseDelay = telephonyDelay
+	}
+
+	return baseDelay * (1 << (attempt.Attempt - 1)), true
+}
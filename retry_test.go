@@ -0,0 +1,230 @@
+package retailcrm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gock "gopkg.in/h2non/gock.v1"
+)
+
+func TestClassifyRetry(t *testing.T) {
+	assert.Equal(t, RetryReasonRateLimited, ClassifyRetry(http.StatusTooManyRequests, nil))
+	assert.Equal(t, RetryReasonRateLimited, ClassifyRetry(http.StatusServiceUnavailable, nil))
+	assert.Equal(t, RetryReasonServerError, ClassifyRetry(http.StatusBadGateway, ErrGeneric))
+	assert.Equal(t, RetryReasonNone, ClassifyRetry(http.StatusBadRequest, ErrGeneric))
+	assert.Equal(t, RetryReasonNone, ClassifyRetry(http.StatusOK, nil))
+	assert.Equal(t, RetryReasonNetworkError, ClassifyRetry(0, &url.Error{Op: "Get", Err: errors.New("reset")}))
+	assert.Equal(t, RetryReasonNone, ClassifyRetry(0, &url.Error{Op: "Get", Err: context.Canceled}))
+	assert.Equal(t, RetryReasonNone, ClassifyRetry(0, errors.New("invalid post data")))
+}
+
+func TestRetryAfter(t *testing.T) {
+	delay, ok := RetryAfter(&http.Response{Header: http.Header{"Retry-After": {"3"}}})
+	require.True(t, ok)
+	assert.Equal(t, 3*time.Second, delay)
+
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	delay, ok = RetryAfter(&http.Response{Header: http.Header{"Retry-After": {date}}})
+	require.True(t, ok)
+	assert.InDelta(t, time.Minute, delay, float64(2*time.Second))
+
+	_, ok = RetryAfter(&http.Response{Header: http.Header{"Retry-After": {"soon"}}})
+	assert.False(t, ok)
+
+	_, ok = RetryAfter(nil)
+	assert.False(t, ok)
+}
+
+func TestBackoffRetryPolicy(t *testing.T) {
+	policy := NewBackoffRetryPolicy(3).WithDelays(10*time.Millisecond, 100*time.Millisecond)
+
+	t.Run("Decorrelated jitter", func(t *testing.T) {
+		prev := time.Duration(0)
+		for i := uint(1); i < 3; i++ {
+			delay, retry := policy.Retry(RetryAttempt{
+				Attempt: i, Reason: RetryReasonServerError, Idempotent: true, PrevDelay: prev,
+			})
+			require.True(t, retry)
+			assert.GreaterOrEqual(t, delay, 10*time.Millisecond)
+			assert.LessOrEqual(t, delay, 100*time.Millisecond)
+			assert.LessOrEqual(t, delay, 3*(prev+10*time.Millisecond))
+			prev = delay
+		}
+	})
+
+	t.Run("Max attempts", func(t *testing.T) {
+		_, retry := policy.Retry(RetryAttempt{Attempt: 3, Reason: RetryReasonRateLimited, Idempotent: true})
+		assert.False(t, retry)
+	})
+
+	t.Run("Retry-After is capped", func(t *testing.T) {
+		delay, retry := policy.Retry(RetryAttempt{
+			Attempt:  1,
+			Reason:   RetryReasonRateLimited,
+			Response: &http.Response{Header: http.Header{"Retry-After": {"60"}}},
+		})
+		require.True(t, retry)
+		assert.Equal(t, 100*time.Millisecond, delay)
+	})
+
+	t.Run("Non-idempotent requests", func(t *testing.T) {
+		_, retry := policy.Retry(RetryAttempt{Attempt: 1, Reason: RetryReasonNetworkError})
+		assert.False(t, retry)
+
+		_, retry = policy.Retry(RetryAttempt{Attempt: 1, Reason: RetryReasonRateLimited})
+		assert.True(t, retry, "Rate limited requests were not processed and can be retried")
+
+		_, retry = NewBackoffRetryPolicy(3).WithNonIdempotentRetries().
+			Retry(RetryAttempt{Attempt: 1, Reason: RetryReasonNetworkError})
+		assert.True(t, retry)
+	})
+
+	t.Run("Custom classifier", func(t *testing.T) {
+		custom := NewBackoffRetryPolicy(3).WithClassifier(func(attempt RetryAttempt) RetryReason {
+			if attempt.StatusCode == http.StatusGatewayTimeout {
+				return RetryReasonNone
+			}
+
+			return attempt.Reason
+		})
+
+		_, retry := custom.Retry(RetryAttempt{
+			Attempt: 1, StatusCode: http.StatusGatewayTimeout, Reason: RetryReasonServerError, Idempotent: true,
+		})
+		assert.False(t, retry)
+	})
+}
+
+func TestClient_WithRetryPolicy(t *testing.T) {
+	policy := NewBackoffRetryPolicy(3).WithDelays(time.Millisecond, 10*time.Millisecond)
+
+	t.Run("Server errors are retried for GET requests", func(t *testing.T) {
+		c := client().WithRetryPolicy(policy)
+
+		defer gock.OffAll()
+
+		gock.New(crmURL).
+			Get("/api/v5/orders").
+			Reply(http.StatusBadGateway)
+
+		gock.New(crmURL).
+			Get("/api/v5/orders").
+			ReplyError(errors.New("connection reset by peer"))
+
+		gock.New(crmURL).
+			Get("/api/v5/orders").
+			Reply(http.StatusOK).
+			BodyString(`{"success": true, "orders": [{"id": 1}]}`)
+
+		data, status, err := c.Orders(OrdersRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Len(t, data.Orders, 1)
+		assert.True(t, gock.IsDone())
+	})
+
+	t.Run("Server errors are not retried for POST requests", func(t *testing.T) {
+		c := client().WithRetryPolicy(policy)
+
+		defer gock.OffAll()
+
+		gock.New(crmURL).
+			Post("/api/v5/orders/create").
+			Reply(http.StatusBadGateway)
+
+		gock.New(crmURL).
+			Post("/api/v5/orders/create").
+			Reply(http.StatusCreated).
+			BodyString(`{"success": true, "id": 1}`)
+
+		_, status, err := c.OrderCreate(Order{ExternalID: "ext-1"})
+		require.Error(t, err)
+		assert.Equal(t, http.StatusBadGateway, status)
+		assert.False(t, gock.IsDone(), "Order must not be created twice")
+	})
+
+	t.Run("POST requests are retried if allowed by the context", func(t *testing.T) {
+		c := client().WithRetryPolicy(policy)
+
+		defer gock.OffAll()
+
+		gock.New(crmURL).
+			Post("/api/v5/orders/create").
+			Reply(http.StatusBadGateway)
+
+		gock.New(crmURL).
+			Post("/api/v5/orders/create").
+			Reply(http.StatusCreated).
+			BodyString(`{"success": true, "id": 1}`)
+
+		data, status, err := c.OrderCreateCtx(AllowNonIdempotentRetries(context.Background()), Order{ExternalID: "ext-1"})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, status)
+		assert.Equal(t, 1, data.ID)
+		assert.True(t, gock.IsDone())
+	})
+
+	t.Run("Retried POST requests resend the reader body", func(t *testing.T) {
+		for name, body := range map[string]func() io.Reader{
+			"reader":        func() io.Reader { return io.MultiReader(strings.NewReader("file "), strings.NewReader("content")) },
+			"seekable file": func() io.Reader { return strings.NewReader("file content") },
+		} {
+			t.Run(name, func(t *testing.T) {
+				c := client().WithRetryPolicy(policy)
+				matchBody := func(req *http.Request, _ *gock.Request) (bool, error) {
+					data, err := io.ReadAll(req.Body)
+					req.Body = io.NopCloser(bytes.NewReader(data))
+
+					return string(data) == "file content", err
+				}
+
+				defer gock.OffAll()
+
+				gock.New(crmURL).
+					Post("/api/v5/files/upload").
+					AddMatcher(matchBody).
+					Reply(http.StatusBadGateway)
+
+				gock.New(crmURL).
+					Post("/api/v5/files/upload").
+					AddMatcher(matchBody).
+					Reply(http.StatusOK).
+					BodyString(`{"success": true, "file": {"id": 1}}`)
+
+				data, status, err := c.FileUploadCtx(AllowNonIdempotentRetries(context.Background()), body())
+				require.NoError(t, err)
+				assert.Equal(t, http.StatusOK, status)
+				require.NotNil(t, data.File)
+				assert.Equal(t, 1, data.File.ID)
+				assert.True(t, gock.IsDone())
+			})
+		}
+	})
+
+	t.Run("Rate limited requests return ErrRateLimited", func(t *testing.T) {
+		c := client().WithRetryPolicy(NewBackoffRetryPolicy(2).WithDelays(time.Millisecond, time.Millisecond))
+
+		defer gock.OffAll()
+
+		gock.New(crmURL).
+			Post("/api/v5/orders/create").
+			Times(2).
+			Reply(http.StatusTooManyRequests).
+			SetHeader("Retry-After", "0").
+			BodyString(`{"success": false, "errorMsg": "Rate limit exceeded"}`)
+
+		_, status, err := c.OrderCreate(Order{ExternalID: "ext-1"})
+		require.ErrorIs(t, err, ErrRateLimited)
+		assert.Equal(t, http.StatusTooManyRequests, status)
+		assert.True(t, gock.IsDone())
+	})
+}
@@ -0,0 +1,200 @@
+package retailcrm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+var (
+	// ErrStoreActionUnhandled will be returned if there is no handler for the store action.
+	ErrStoreActionUnhandled = errors.New("unhandled store action")
+	// ErrStoreActionClient will be returned by StoreAction helpers if the client resolver is not set.
+	ErrStoreActionClient = errors.New("store action client is not configured")
+)
+
+// StoreAction is the callback which is sent to Action.URL when the store action is triggered in the CRM.
+// All the request parameters are available in Params.
+type StoreAction struct {
+	ClientID  string
+	Code      string
+	CallPoint string
+	OrderIDs  []int
+	Params    url.Values
+	// Client is the client of the account which triggered the action. It is nil if StoreActionHandler
+	// doesn't have the client resolver.
+	Client *Client
+}
+
+// UploadInventories sends the stock balances of the warehouse back to the account using InventoriesUpload.
+func (a StoreAction) UploadInventories(
+	ctx context.Context, inventories []InventoryUpload, site ...string,
+) (StoreUploadResponse, error) {
+	if a.Client == nil {
+		return StoreUploadResponse{}, ErrStoreActionClient
+	}
+
+	resp, _, err := a.Client.InventoriesUploadCtx(ctx, inventories, site...)
+	return resp, err
+}
+
+// CreatePack creates the pack of the order item in the account using PackCreate and returns its ID.
+func (a StoreAction) CreatePack(ctx context.Context, pack Pack) (int, error) {
+	if a.Client == nil {
+		return 0, ErrStoreActionClient
+	}
+
+	resp, _, err := a.Client.PackCreateCtx(ctx, pack)
+	return resp.ID, err
+}
+
+// StoreActionFunc processes the store action.
+type StoreActionFunc func(ctx context.Context, action StoreAction) error
+
+// StoreActionHandler is the http.Handler which dispatches the callbacks of the warehouse integration by Action.Code.
+// Request paths are matched with Action.URL, so every action must have its own URL path. Use http.StripPrefix
+// if the handler isn't mounted at the root of the module host.
+//
+// Handler errors are responded with 500 status code, actions without the handler are responded with 422 status code.
+//
+// Example:
+//
+//	warehouse := retailcrm.Warehouse{
+//		Actions: []retailcrm.Action{
+//			{Code: "sync", URL: "https://wms.example.com/store/sync", CallPoints: []string{"inventories"}},
+//		},
+//	}
+//
+//	handler := retailcrm.NewStoreActionHandler("client-id", warehouse).
+//		WithClientResolver(func(ctx context.Context, clientID string) (*retailcrm.Client, error) {
+//			return retailcrm.New("https://demo.url", "09jIJ"), nil
+//		}).
+//		Handle("sync", func(ctx context.Context, action retailcrm.StoreAction) error {
+//			_, err := action.UploadInventories(ctx, wms.Inventories())
+//			return err
+//		})
+//
+//	http.Handle("/store/", handler)
+type StoreActionHandler struct {
+	codes    map[string]string
+	handlers map[string]StoreActionFunc
+	checker  ClientIDChecker
+	resolver func(ctx context.Context, clientID string) (*Client, error)
+}
+
+// NewStoreActionHandler instantiates new StoreActionHandler which accepts callbacks with the provided clientId.
+func NewStoreActionHandler(clientID string, warehouse Warehouse) *StoreActionHandler {
+	codes := make(map[string]string, len(warehouse.Actions))
+	for _, action := range warehouse.Actions {
+		codes[normalizeActionPath(action.URL)] = action.Code
+	}
+
+	return &StoreActionHandler{
+		codes:    codes,
+		handlers: map[string]StoreActionFunc{},
+		checker:  staticClientID(clientID),
+	}
+}
+
+// WithClientIDChecker replaces the clientId check, e.g. with StoreClientIDChecker to accept callbacks from all
+// the accounts in the ConnectionStore.
+func (h *StoreActionHandler) WithClientIDChecker(checker ClientIDChecker) *StoreActionHandler {
+	h.checker = checker
+	return h
+}
+
+// WithClientResolver sets the function which returns the Client of the account by clientId. The Client is passed
+// in StoreAction and is used by its helpers.
+func (h *StoreActionHandler) WithClientResolver(
+	resolver func(ctx context.Context, clientID string) (*Client, error),
+) *StoreActionHandler {
+	h.resolver = resolver
+	return h
+}
+
+// Handle sets the handler for the action with the provided Action.Code.
+func (h *StoreActionHandler) Handle(code string, handler StoreActionFunc) *StoreActionHandler {
+	h.handlers[code] = handler
+	return h
+}
+
+// ServeHTTP dispatches the store action.
+func (h *StoreActionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	code, ok := h.codes[normalizeActionPath(r.URL.Path)]
+	if !ok {
+		writeErrorResponse(w, http.StatusNotFound, "unknown action")
+		return
+	}
+
+	action, err := parseStoreAction(r, code)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !checkClientID(w, r, h.checker, action.ClientID) {
+		return
+	}
+
+	handler, ok := h.handlers[code]
+	if !ok {
+		writeErrorResponse(w, http.StatusUnprocessableEntity, ErrStoreActionUnhandled.Error())
+		return
+	}
+
+	if h.resolver != nil {
+		if action.Client, err = h.resolver(r.Context(), action.ClientID); err != nil {
+			writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
+	if err := handler(r.Context(), action); err != nil {
+		writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, SuccessfulResponse{Success: true})
+}
+
+// parseStoreAction reads StoreAction from the query or form parameters. Order IDs are accepted as the JSON array
+// in "orderIds" or as repeated "orderIds[]" and "orderId" parameters.
+func parseStoreAction(r *http.Request, code string) (StoreAction, error) {
+	if err := r.ParseForm(); err != nil {
+		return StoreAction{}, err
+	}
+
+	action := StoreAction{
+		ClientID:  r.Form.Get("clientId"),
+		Code:      code,
+		CallPoint: r.Form.Get("callPoint"),
+		Params:    r.Form,
+	}
+
+	if ids := r.Form.Get("orderIds"); ids != "" {
+		if err := json.Unmarshal([]byte(ids), &action.OrderIDs); err != nil {
+			return action, fmt.Errorf("invalid orderIds: %w", err)
+		}
+
+		return action, nil
+	}
+
+	values := make([]string, 0, len(r.Form["orderIds[]"])+len(r.Form["orderId"]))
+	values = append(values, r.Form["orderIds[]"]...)
+	values = append(values, r.Form["orderId"]...)
+
+	for _, value := range values {
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			return action, fmt.Errorf("invalid order ID: %w", err)
+		}
+
+		action.OrderIDs = append(action.OrderIDs, id)
+	}
+
+	return action, nil
+}
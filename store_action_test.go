@@ -0,0 +1,132 @@
+package retailcrm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gock "gopkg.in/h2non/gock.v1"
+)
+
+func testWarehouse() Warehouse {
+	return Warehouse{
+		Actions: []Action{
+			{Code: "sync", URL: "https://wms.example.com/store/sync", CallPoints: []string{"inventories"}},
+			{Code: "pack", URL: "https://wms.example.com/store/pack", CallPoints: []string{"order"}},
+			{Code: "unhandled", URL: "/store/unhandled"},
+		},
+	}
+}
+
+func storeActionResolver(_ context.Context, clientID string) (*Client, error) {
+	if clientID != "client" {
+		return nil, errors.New("unknown account")
+	}
+
+	return New(connectionAccountURL, "key"), nil
+}
+
+func TestStoreActionHandler_UploadInventories(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(connectionAccountURL).
+		Post(prefix+"/store/inventories/upload").
+		MatchHeader("X-API-KEY", "key").
+		BodyString(`offers=.*store-1`).
+		Reply(http.StatusOK).
+		BodyString(`{"success": true, "processedOffersCount": 1}`)
+
+	var received StoreAction
+	handler := NewStoreActionHandler("client", testWarehouse()).
+		WithClientResolver(storeActionResolver).
+		Handle("sync", func(ctx context.Context, action StoreAction) error {
+			received = action
+
+			resp, err := action.UploadInventories(ctx, []InventoryUpload{
+				{XMLID: "offer-1", Stores: []InventoryUploadStore{{Code: "store-1", Available: 5}}},
+			})
+			if err == nil && resp.ProcessedOffersCount != 1 {
+				return errors.New("offer is not processed")
+			}
+
+			return err
+		})
+
+	rec, resp := serveWebhook(handler,
+		httptest.NewRequest(http.MethodGet, "/store/sync?clientId=client&callPoint=inventories&extra=1", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, resp.Success)
+	assert.Equal(t, "sync", received.Code)
+	assert.Equal(t, "inventories", received.CallPoint)
+	assert.Equal(t, "1", received.Params.Get("extra"))
+	assert.True(t, gock.IsDone())
+}
+
+func TestStoreActionHandler_CreatePack(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(connectionAccountURL).
+		Post(prefix + "/orders/packs/create").
+		Reply(http.StatusCreated).
+		BodyString(`{"success": true, "id": 42}`)
+
+	var packs []int
+	handler := NewStoreActionHandler("client", testWarehouse()).
+		WithClientResolver(storeActionResolver).
+		Handle("pack", func(ctx context.Context, action StoreAction) error {
+			for _, id := range action.OrderIDs {
+				packID, err := action.CreatePack(ctx, Pack{ItemID: id, Store: "store-1", Quantity: 1})
+				if err != nil {
+					return err
+				}
+
+				packs = append(packs, packID)
+			}
+
+			return nil
+		})
+
+	form := url.Values{"clientId": {"client"}, "orderIds[]": {"7"}}
+	req := httptest.NewRequest(http.MethodPost, "/store/pack", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	rec, _ := serveWebhook(handler, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, []int{42}, packs)
+}
+
+func TestStoreActionHandler_Errors(t *testing.T) {
+	var received StoreAction
+	handler := NewStoreActionHandler("client", testWarehouse()).
+		Handle("pack", func(ctx context.Context, action StoreAction) error {
+			received = action
+			_, err := action.CreatePack(ctx, Pack{})
+			return err
+		})
+
+	rec, _ := serveWebhook(handler, httptest.NewRequest(http.MethodGet, "/store/unknown?clientId=client", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec, _ = serveWebhook(handler, httptest.NewRequest(http.MethodGet, "/store/pack?clientId=other", nil))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec, _ = serveWebhook(handler, httptest.NewRequest(http.MethodGet, "/store/pack?clientId=client&orderIds=%5B1", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec, resp := serveWebhook(handler, httptest.NewRequest(http.MethodGet, "/store/unhandled?clientId=client", nil))
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, ErrStoreActionUnhandled.Error(), resp.ErrorMessage)
+
+	rec, resp = serveWebhook(handler, httptest.NewRequest(http.MethodGet, "/store/pack?clientId=client&orderIds=%5B1%2C2%5D", nil))
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, ErrStoreActionClient.Error(), resp.ErrorMessage)
+	assert.Equal(t, []int{1, 2}, received.OrderIDs)
+}
@@ -0,0 +1,176 @@
+package retailcrm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// User statuses which are sent to Telephony.ChangeUserStatusURL.
+const (
+	UserStatusFree   = "free"
+	UserStatusBusy   = "busy"
+	UserStatusDinner = "dinner"
+	UserStatusBreak  = "break"
+)
+
+// MakeCallRequest is the callback which is sent to Telephony.MakeCallURL when the manager clicks the phone number.
+type MakeCallRequest struct {
+	ClientID      string
+	Code          string
+	Phone         string
+	UserID        int
+	ExternalPhone string
+}
+
+// ChangeUserStatusRequest is the callback which is sent to Telephony.ChangeUserStatusURL when the manager
+// changes the status, e.g. to UserStatusBreak.
+type ChangeUserStatusRequest struct {
+	ClientID string
+	UserID   int
+	Status   string
+}
+
+// TelephonyProvider is implemented by the telephony integration, e.g. by the bridge to the PBX.
+type TelephonyProvider interface {
+	// MakeCall starts the call from the manager's phone to the requested phone number.
+	MakeCall(ctx context.Context, req MakeCallRequest) error
+	// ChangeUserStatus applies the manager status, e.g. stops routing the calls to the busy manager.
+	ChangeUserStatus(ctx context.Context, req ChangeUserStatusRequest) error
+}
+
+// TelephonyHandler serves the callbacks of the telephony integration. Requests are decoded from the query or form
+// parameters, the clientId parameter is checked and the TelephonyProvider is called. Provider errors are responded
+// with 500 status code.
+//
+// Example:
+//
+//	handler := retailcrm.NewTelephonyHandler("client-id", provider)
+//
+//	http.Handle("/telephony/make-call", handler.MakeCallHandler())
+//	http.Handle("/telephony/change-status", handler.ChangeUserStatusHandler())
+//
+//	_, _, err := client.IntegrationModuleEdit(retailcrm.IntegrationModule{
+//		Code:            "pbx",
+//		IntegrationCode: "pbx",
+//		ClientID:        "client-id",
+//		Integrations: &retailcrm.Integrations{
+//			Telephony: &retailcrm.Telephony{
+//				MakeCallURL:         "https://pbx.example.com/telephony/make-call",
+//				ChangeUserStatusURL: "https://pbx.example.com/telephony/change-status",
+//			},
+//		},
+//	})
+type TelephonyHandler struct {
+	provider TelephonyProvider
+	checker  ClientIDChecker
+}
+
+// NewTelephonyHandler instantiates new TelephonyHandler which accepts callbacks with the provided clientId.
+func NewTelephonyHandler(clientID string, provider TelephonyProvider) *TelephonyHandler {
+	return &TelephonyHandler{provider: provider, checker: staticClientID(clientID)}
+}
+
+// WithClientIDChecker replaces the clientId check, e.g. with StoreClientIDChecker to accept callbacks from all
+// the accounts in the ConnectionStore.
+func (h *TelephonyHandler) WithClientIDChecker(checker ClientIDChecker) *TelephonyHandler {
+	h.checker = checker
+	return h
+}
+
+// MakeCallHandler returns the http.Handler for Telephony.MakeCallURL.
+func (h *TelephonyHandler) MakeCallHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req, err := parseMakeCallRequest(r)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if !checkClientID(w, r, h.checker, req.ClientID) {
+			return
+		}
+
+		h.respond(w, h.provider.MakeCall(r.Context(), req))
+	})
+}
+
+// ChangeUserStatusHandler returns the http.Handler for Telephony.ChangeUserStatusURL.
+func (h *TelephonyHandler) ChangeUserStatusHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req, err := parseChangeUserStatusRequest(r)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if !checkClientID(w, r, h.checker, req.ClientID) {
+			return
+		}
+
+		h.respond(w, h.provider.ChangeUserStatus(r.Context(), req))
+	})
+}
+
+func (h *TelephonyHandler) respond(w http.ResponseWriter, err error) {
+	if err != nil {
+		writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, SuccessfulResponse{Success: true})
+}
+
+func parseMakeCallRequest(r *http.Request) (MakeCallRequest, error) {
+	req := MakeCallRequest{
+		ClientID:      r.FormValue("clientId"),
+		Code:          r.FormValue("code"),
+		Phone:         r.FormValue("phone"),
+		ExternalPhone: r.FormValue("externalPhone"),
+	}
+
+	if req.Phone == "" {
+		return req, errors.New("phone is required")
+	}
+
+	userID, err := telephonyUserID(r, false)
+	req.UserID = userID
+
+	return req, err
+}
+
+func parseChangeUserStatusRequest(r *http.Request) (ChangeUserStatusRequest, error) {
+	req := ChangeUserStatusRequest{
+		ClientID: r.FormValue("clientId"),
+		Status:   r.FormValue("status"),
+	}
+
+	if req.Status == "" {
+		return req, errors.New("status is required")
+	}
+
+	userID, err := telephonyUserID(r, true)
+	req.UserID = userID
+
+	return req, err
+}
+
+func telephonyUserID(r *http.Request, required bool) (int, error) {
+	value := r.FormValue("userId")
+	if value == "" {
+		if required {
+			return 0, errors.New("userId is required")
+		}
+
+		return 0, nil
+	}
+
+	userID, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid userId: %w", err)
+	}
+
+	return userID, nil
+}
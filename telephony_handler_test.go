@@ -0,0 +1,103 @@
+package retailcrm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testTelephonyProvider struct {
+	calls    []MakeCallRequest
+	statuses []ChangeUserStatusRequest
+	err      error
+}
+
+func (p *testTelephonyProvider) MakeCall(_ context.Context, req MakeCallRequest) error {
+	p.calls = append(p.calls, req)
+	return p.err
+}
+
+func (p *testTelephonyProvider) ChangeUserStatus(_ context.Context, req ChangeUserStatusRequest) error {
+	p.statuses = append(p.statuses, req)
+	return p.err
+}
+
+func TestTelephonyHandler_MakeCall(t *testing.T) {
+	provider := &testTelephonyProvider{}
+	handler := NewTelephonyHandler("client", provider).MakeCallHandler()
+
+	rec, resp := serveWebhook(handler, httptest.NewRequest(
+		http.MethodGet, "/make-call?clientId=client&code=101&phone=%2B79991234567&userId=5&externalPhone=%2B74950000000", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, resp.Success)
+	assert.Equal(t, []MakeCallRequest{{
+		ClientID:      "client",
+		Code:          "101",
+		Phone:         "+79991234567",
+		UserID:        5,
+		ExternalPhone: "+74950000000",
+	}}, provider.calls)
+}
+
+func TestTelephonyHandler_ChangeUserStatus(t *testing.T) {
+	provider := &testTelephonyProvider{}
+	handler := NewTelephonyHandler("client", provider).ChangeUserStatusHandler()
+
+	form := url.Values{"clientId": {"client"}, "userId": {"5"}, "status": {UserStatusBreak}}
+	req := httptest.NewRequest(http.MethodPost, "/change-status", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	rec, _ := serveWebhook(handler, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, []ChangeUserStatusRequest{{ClientID: "client", UserID: 5, Status: UserStatusBreak}}, provider.statuses)
+}
+
+func TestTelephonyHandler_Errors(t *testing.T) {
+	provider := &testTelephonyProvider{}
+	handler := NewTelephonyHandler("client", provider)
+
+	rec, resp := serveWebhook(handler.MakeCallHandler(),
+		httptest.NewRequest(http.MethodGet, "/make-call?clientId=other&phone=123", nil))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Equal(t, ErrInvalidClientID.Error(), resp.ErrorMessage)
+
+	rec, _ = serveWebhook(handler.MakeCallHandler(), httptest.NewRequest(http.MethodGet, "/make-call?clientId=client", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec, _ = serveWebhook(handler.ChangeUserStatusHandler(),
+		httptest.NewRequest(http.MethodGet, "/change-status?clientId=client&userId=abc&status=busy", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Empty(t, provider.calls)
+	assert.Empty(t, provider.statuses)
+
+	provider.err = errors.New("pbx is unavailable")
+	rec, resp = serveWebhook(handler.MakeCallHandler(),
+		httptest.NewRequest(http.MethodGet, "/make-call?clientId=client&phone=123", nil))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, "pbx is unavailable", resp.ErrorMessage)
+}
+
+func TestTelephonyHandler_ClientIDChecker(t *testing.T) {
+	store := NewMemoryConnectionStore()
+	_ = store.Save(context.Background(), ConnectionAccount{URL: connectionAccountURL, APIKey: "key", ClientID: "account"})
+
+	provider := &testTelephonyProvider{}
+	handler := NewTelephonyHandler("", provider).
+		WithClientIDChecker(StoreClientIDChecker(store)).
+		ChangeUserStatusHandler()
+
+	rec, _ := serveWebhook(handler, httptest.NewRequest(http.MethodGet, "/change-status?clientId=account&userId=1&status=free", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec, _ = serveWebhook(handler, httptest.NewRequest(http.MethodGet, "/change-status?clientId=&userId=1&status=free", nil))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Len(t, provider.statuses, 1)
+}
@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"strings"
 	"sync"
+	"time"
 )
 
 // ByID is "id" constant to use as `by` property in methods.
@@ -15,21 +16,38 @@ const ByID = "id"
 // ByExternalID is "externalId" constant to use as `by` property in methods.
 const ByExternalID = "externalId"
 
+// Call types which are used in CallEvent and TelephonyCall.
+const (
+	CallTypeIn     = "in"
+	CallTypeOut    = "out"
+	CallTypeHangup = "hangup"
+)
+
 // HTTPStatusUnknown can return for the method `/api/v5/customers/upload`, `/api/v5/customers-corporate/upload`,
 // `/api/v5/orders/upload`.
 const HTTPStatusUnknown = 460
 
 // Client type.
 type Client struct {
-	ctx         context.Context
-	URL         string
-	Key         string
-	Debug       bool
-	httpClient  *http.Client
-	logger      BasicLogger
-	limiter     Limiter
-	maxAttempts uint // Maximum number of retry attempts (0 = infinite).
-	mutex       sync.RWMutex
+	ctx               context.Context
+	URL               string
+	Key               string
+	Debug             bool
+	httpClient        *http.Client
+	logger            BasicLogger
+	limiter           Limiter
+	maxAttempts       uint // Maximum number of retry attempts (0 = infinite).
+	retryPolicy       RetryPolicy
+	middlewares       []Middleware
+	observer          Observer
+	structuredLogger  StructuredLogger
+	redactor          *LogRedactor
+	referenceCache    ReferenceCache
+	referenceCacheTTL time.Duration
+	referenceVersion  uint64     // Incremented on invalidation, so responses received before it are not cached.
+	referenceMutex    sync.Mutex // Guards referenceVersion and serializes invalidation with caching.
+	validator         *Validator
+	mutex             sync.RWMutex
 }
 
 // Pagination type.
@@ -1355,6 +1373,68 @@ type ExternalPhone struct {
 	ExternalPhone string `json:"externalPhone,omitempty"`
 }
 
+// TelephonyConfiguration type.
+type TelephonyConfiguration struct {
+	ClientID             string           `json:"clientId,omitempty"`
+	Code                 string           `json:"code,omitempty"`
+	Name                 string           `json:"name,omitempty"`
+	Image                string           `json:"image,omitempty"`
+	Active               bool             `json:"active,omitempty"`
+	MakeCallURL          string           `json:"makeCallUrl,omitempty"`
+	AllowEdit            bool             `json:"allowEdit,omitempty"`
+	InputEventSupported  bool             `json:"inputEventSupported,omitempty"`
+	OutputEventSupported bool             `json:"outputEventSupported,omitempty"`
+	HangupEventSupported bool             `json:"hangupEventSupported,omitempty"`
+	ChangeUserStatusURL  string           `json:"changeUserStatusUrl,omitempty"`
+	AdditionalCodes      []AdditionalCode `json:"additionalCodes,omitempty"`
+	ExternalPhones       []ExternalPhone  `json:"externalPhones,omitempty"`
+}
+
+// CallEvent type.
+type CallEvent struct {
+	Phone          string   `json:"phone"`
+	Type           string   `json:"type"`
+	Codes          []string `json:"codes,omitempty"`
+	UserIDs        []int    `json:"userIds,omitempty"`
+	Site           string   `json:"site,omitempty"`
+	CallExternalID string   `json:"callExternalId,omitempty"`
+	HangupStatus   string   `json:"hangupStatus,omitempty"`
+	ExternalPhone  string   `json:"externalPhone,omitempty"`
+}
+
+// TelephonyCall type.
+type TelephonyCall struct {
+	Date          string `json:"date"`
+	Type          string `json:"type"`
+	Phone         string `json:"phone"`
+	Code          string `json:"code,omitempty"`
+	UserID        int    `json:"userId,omitempty"`
+	Result        string `json:"result,omitempty"`
+	Duration      int    `json:"duration,omitempty"`
+	ExternalID    string `json:"externalId,omitempty"`
+	RecordURL     string `json:"recordUrl,omitempty"`
+	Site          string `json:"site,omitempty"`
+	ExternalPhone string `json:"externalPhone,omitempty"`
+}
+
+// TelephonyManager type.
+type TelephonyManager struct {
+	ID         int    `json:"id,omitempty"`
+	FirstName  string `json:"firstName,omitempty"`
+	LastName   string `json:"lastName,omitempty"`
+	Patronymic string `json:"patronymic,omitempty"`
+	Email      string `json:"email,omitempty"`
+	Code       string `json:"code,omitempty"`
+}
+
+// TelephonyManagerLinks type.
+type TelephonyManagerLinks struct {
+	NewOrderLink    string `json:"newOrderLink,omitempty"`
+	LastOrderLink   string `json:"lastOrderLink,omitempty"`
+	NewCustomerLink string `json:"newCustomerLink,omitempty"`
+	CustomerLink    string `json:"customerLink,omitempty"`
+}
+
 // Warehouse type.
 type Warehouse struct {
 	Actions []Action `json:"actions,omitempty"`
@@ -0,0 +1,260 @@
+package retailcrm
+
+import (
+	"fmt"
+	"net/mail"
+	"regexp"
+	"time"
+
+	"github.com/retailcrm/api-client-go/v2/constant"
+)
+
+const dateLayout = "2006-01-02"
+
+// paymentsPrecision is the allowed difference between the sum of the payments and the order total.
+const paymentsPrecision = 0.01
+
+// DefaultPhonePattern matches phone numbers which contain only digits, the leading plus and the usual separators.
+// Number of digits is checked separately.
+var DefaultPhonePattern = regexp.MustCompile(`^\+?[0-9()\-.\s]+$`)
+
+// Validator checks Order and Customer payloads before sending them to the API. It finds only the obvious mistakes:
+// missing required fields, invalid `by` values, dates, emails and phones, negative quantities and payments which
+// exceed the order total. Errors are returned as APIError wrapping ErrValidation, the invalid fields are available
+// via APIError.Errors in the same format as the API returns them, e.g. "items[0][quantity]".
+//
+// Example:
+//
+//	var client = retailcrm.New("https://demo.url", "09jIJ").WithValidator(retailcrm.NewValidator())
+//
+//	_, _, err := client.OrderCreate(order)
+//	if apiErr, ok := retailcrm.AsAPIError(err); ok && errors.Is(err, retailcrm.ErrValidation) {
+//		for field, msg := range apiErr.Errors() {
+//			log.Printf("%s: %s\n", field, msg)
+//		}
+//	}
+type Validator struct {
+	phonePattern *regexp.Regexp
+}
+
+// NewValidator instantiates new Validator.
+func NewValidator() *Validator {
+	return &Validator{phonePattern: DefaultPhonePattern}
+}
+
+// WithPhonePattern replaces the pattern which is used to check phone numbers. Number of digits is checked anyway.
+func (v *Validator) WithPhonePattern(pattern *regexp.Regexp) *Validator {
+	v.phonePattern = pattern
+	return v
+}
+
+// WithValidator enables validation of the OrderCreate, OrderEdit, CustomerCreate and CustomerEdit payloads.
+// Invalid payloads are not sent to the API. Pass nil to disable validation.
+func (c *Client) WithValidator(validator *Validator) *Client {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.validator = validator
+	return c
+}
+
+// ValidateOrderCreate checks the order before OrderCreate.
+func (v *Validator) ValidateOrderCreate(order Order) error {
+	errs := APIErrorsList{}
+	v.checkOrder(errs, order)
+
+	for i, item := range order.Items {
+		if item.Quantity < 0 {
+			errs[fmt.Sprintf("items[%d][quantity]", i)] = "must be positive"
+		}
+	}
+
+	return validationResult(errs)
+}
+
+// ValidateOrderEdit checks the order before OrderEdit.
+func (v *Validator) ValidateOrderEdit(order Order, by string) error {
+	errs := APIErrorsList{}
+	checkIdentifier(errs, by, order.ID, order.ExternalID)
+	v.checkOrder(errs, order)
+
+	return validationResult(errs)
+}
+
+// ValidateCustomerCreate checks the customer before CustomerCreate.
+func (v *Validator) ValidateCustomerCreate(customer Customer) error {
+	errs := APIErrorsList{}
+
+	if customer.FirstName == "" && customer.LastName == "" && customer.Email == "" && len(customer.Phones) == 0 {
+		errs["customer"] = "name, email or phone is required"
+	}
+
+	v.checkCustomer(errs, customer)
+
+	return validationResult(errs)
+}
+
+// ValidateCustomerEdit checks the customer before CustomerEdit.
+func (v *Validator) ValidateCustomerEdit(customer Customer, by string) error {
+	errs := APIErrorsList{}
+	checkIdentifier(errs, by, customer.ID, customer.ExternalID)
+	v.checkCustomer(errs, customer)
+
+	return validationResult(errs)
+}
+
+func (v *Validator) checkOrder(errs APIErrorsList, order Order) {
+	checkDateTime(errs, "createdAt", order.CreatedAt)
+	checkDateTime(errs, "statusUpdatedAt", order.StatusUpdatedAt)
+	checkDateTime(errs, "markDatetime", order.MarkDatetime)
+	checkDateTime(errs, "fullPaidAt", order.FullPaidAt)
+	checkDate(errs, "shipmentDate", order.ShipmentDate)
+	v.checkEmail(errs, "email", order.Email)
+	v.checkPhone(errs, "phone", order.Phone)
+	v.checkPhone(errs, "additionalPhone", order.AdditionalPhone)
+
+	if order.Delivery != nil {
+		checkDate(errs, "delivery[date]", order.Delivery.Date)
+
+		if order.Delivery.Cost < 0 {
+			errs["delivery[cost]"] = "must not be negative"
+		}
+	}
+
+	for i, item := range order.Items {
+		field := fmt.Sprintf("items[%d]", i)
+
+		// Existing items are identified by ID, so the edit may contain only the changed fields.
+		if item.ID == 0 && item.Offer.ID == 0 && item.Offer.ExternalID == "" && item.Offer.XMLID == "" &&
+			item.ProductName == "" {
+			errs[field+"[offer]"] = "offer or productName is required"
+		}
+
+		if item.InitialPrice < 0 {
+			errs[field+"[initialPrice]"] = "must not be negative"
+		}
+	}
+
+	var paid float64
+	for key, payment := range order.Payments {
+		field := fmt.Sprintf("payments[%s]", key)
+
+		if payment.Type == "" {
+			errs[field+"[type]"] = "is required"
+		}
+
+		if payment.Amount < 0 {
+			errs[field+"[amount]"] = "must not be negative"
+		}
+
+		checkDateTime(errs, field+"[paidAt]", payment.PaidAt)
+		paid += float64(payment.Amount)
+	}
+
+	if order.TotalSumm > 0 && paid-float64(order.TotalSumm) > paymentsPrecision {
+		errs["payments"] = fmt.Sprintf("sum of the payments %.2f exceeds the order total %.2f", paid, order.TotalSumm)
+	}
+}
+
+func (v *Validator) checkCustomer(errs APIErrorsList, customer Customer) {
+	checkDateTime(errs, "createdAt", customer.CreatedAt)
+	checkDateTime(errs, "emailMarketingUnsubscribedAt", customer.EmailMarketingUnsubscribedAt)
+	checkDate(errs, "birthday", customer.Birthday)
+	v.checkEmail(errs, "email", customer.Email)
+
+	for i, phone := range customer.Phones {
+		field := fmt.Sprintf("phones[%d][number]", i)
+
+		if phone.Number == "" {
+			errs[field] = "is required"
+			continue
+		}
+
+		v.checkPhone(errs, field, phone.Number)
+	}
+}
+
+func (v *Validator) checkEmail(errs APIErrorsList, field, email string) {
+	if email == "" {
+		return
+	}
+
+	if address, err := mail.ParseAddress(email); err != nil || address.Address != email {
+		errs[field] = fmt.Sprintf(`"%s" is not a valid email`, email)
+	}
+}
+
+func (v *Validator) checkPhone(errs APIErrorsList, field, phone string) {
+	if phone == "" {
+		return
+	}
+
+	digits := 0
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			digits++
+		}
+	}
+
+	if digits < 5 || digits > 15 || (v.phonePattern != nil && !v.phonePattern.MatchString(phone)) { // nolint:gomnd
+		errs[field] = fmt.Sprintf(`"%s" is not a valid phone`, phone)
+	}
+}
+
+// checkIdentifier checks the `by` value and the corresponding identifier of the edited entity.
+func checkIdentifier(errs APIErrorsList, by string, id int, externalID string) {
+	switch by {
+	case ByID:
+		if id == 0 {
+			errs["id"] = "is required when by=id"
+		}
+	case ByExternalID:
+		if externalID == "" {
+			errs["externalId"] = "is required when by=externalId"
+		}
+	default:
+		errs["by"] = fmt.Sprintf(`must be "%s" or "%s", got "%s"`, ByID, ByExternalID, by)
+	}
+}
+
+// checkDateTime checks that the value is formatted as systemTimeLayout or constant.DateTimeWithZoneFormat.
+func checkDateTime(errs APIErrorsList, field, value string) {
+	if value == "" {
+		return
+	}
+
+	if _, err := time.Parse(systemTimeLayout, value); err == nil {
+		return
+	}
+
+	if _, err := time.Parse(constant.DateTimeWithZoneFormat, value); err == nil {
+		return
+	}
+
+	errs[field] = fmt.Sprintf(`"%s" does not match "%s" format`, value, systemTimeLayout)
+}
+
+func checkDate(errs APIErrorsList, field, value string) {
+	if value == "" {
+		return
+	}
+
+	if _, err := time.Parse(dateLayout, value); err != nil {
+		errs[field] = fmt.Sprintf(`"%s" does not match "%s" format`, value, dateLayout)
+	}
+}
+
+func validationResult(errs APIErrorsList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return NewAPIError("client-side validation error").withWrapped(ErrValidation).withErrors(errs)
+}
+
+func (c *Client) currentValidator() *Validator {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.validator
+}
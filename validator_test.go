@@ -0,0 +1,136 @@
+package retailcrm
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gock "gopkg.in/h2non/gock.v1"
+)
+
+func validationErrors(t *testing.T, err error) APIErrorsList {
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ErrValidation))
+
+	apiErr, ok := AsAPIError(err)
+	require.True(t, ok)
+
+	return apiErr.Errors()
+}
+
+func TestValidator_ValidateOrderCreate(t *testing.T) {
+	v := NewValidator()
+
+	assert.NoError(t, v.ValidateOrderCreate(Order{
+		CreatedAt: "2024-01-02 10:00:00",
+		Email:     "john@example.com",
+		Phone:     "+7 (900) 123-45-67",
+		TotalSumm: 100,
+		Items:     []OrderItem{{Offer: Offer{ExternalID: "sku"}, Quantity: 2}},
+		Payments:  OrderPayments{"1": {Type: "cash", Amount: 100, PaidAt: "2024-01-02 10:00:00+03:00"}},
+	}))
+
+	errs := validationErrors(t, v.ValidateOrderCreate(Order{
+		CreatedAt:    "02.01.2024",
+		ShipmentDate: "2024-01-02 10:00:00",
+		Email:        "john",
+		Phone:        "call me",
+		TotalSumm:    100,
+		Items:        []OrderItem{{ProductName: "Item", Quantity: -1}, {}, {ProductName: "Item"}},
+		Payments: OrderPayments{
+			"1": {Type: "cash", Amount: 80},
+			"2": {Amount: 40, PaidAt: "yesterday"},
+		},
+	}))
+
+	assert.Equal(t, APIErrorsList{
+		"createdAt":           `"02.01.2024" does not match "2006-01-02 15:04:05" format`,
+		"shipmentDate":        `"2024-01-02 10:00:00" does not match "2006-01-02" format`,
+		"email":               `"john" is not a valid email`,
+		"phone":               `"call me" is not a valid phone`,
+		"items[0][quantity]":  "must be positive",
+		"items[1][offer]":     "offer or productName is required",
+		"payments[2][type]":   "is required",
+		"payments[2][paidAt]": `"yesterday" does not match "2006-01-02 15:04:05" format`,
+		"payments":            "sum of the payments 120.00 exceeds the order total 100.00",
+	}, errs)
+}
+
+func TestValidator_ValidateOrderEdit(t *testing.T) {
+	v := NewValidator()
+
+	assert.NoError(t, v.ValidateOrderEdit(Order{ID: 1}, ByID))
+	assert.NoError(t, v.ValidateOrderEdit(Order{ExternalID: "ext"}, ByExternalID))
+	assert.NoError(t, v.ValidateOrderEdit(Order{ID: 1, Items: []OrderItem{{ID: 5, Comment: "gift wrap"}}}, ByID),
+		"Partial item edits must not require the offer and quantity")
+
+	assert.Equal(t, APIErrorsList{"id": "is required when by=id"},
+		validationErrors(t, v.ValidateOrderEdit(Order{ExternalID: "ext"}, ByID)))
+	assert.Equal(t, APIErrorsList{"externalId": "is required when by=externalId"},
+		validationErrors(t, v.ValidateOrderEdit(Order{ID: 1}, ByExternalID)))
+	assert.Equal(t, APIErrorsList{"by": `must be "id" or "externalId", got "number"`},
+		validationErrors(t, v.ValidateOrderEdit(Order{ID: 1}, "number")))
+}
+
+func TestValidator_ValidateCustomer(t *testing.T) {
+	v := NewValidator()
+
+	assert.NoError(t, v.ValidateCustomerCreate(Customer{
+		FirstName: "John",
+		Birthday:  "1990-05-01",
+		Phones:    []Phone{{Number: "89001234567"}},
+	}))
+
+	assert.Equal(t, APIErrorsList{"customer": "name, email or phone is required"},
+		validationErrors(t, v.ValidateCustomerCreate(Customer{})))
+
+	assert.Equal(t, APIErrorsList{
+		"birthday":          `"01.05.1990" does not match "2006-01-02" format`,
+		"email":             `"John <john@example.com>" is not a valid email`,
+		"phones[0][number]": "is required",
+		"phones[1][number]": `"123" is not a valid phone`,
+	}, validationErrors(t, v.ValidateCustomerEdit(Customer{
+		ID:       1,
+		Birthday: "01.05.1990",
+		Email:    "John <john@example.com>",
+		Phones:   []Phone{{}, {Number: "123"}},
+	}, ByID)))
+}
+
+func TestValidator_WithPhonePattern(t *testing.T) {
+	v := NewValidator().WithPhonePattern(regexp.MustCompile(`^\+[0-9]+$`))
+
+	assert.NoError(t, v.ValidateCustomerCreate(Customer{Phones: []Phone{{Number: "+79001234567"}}}))
+	assert.Contains(t, validationErrors(t, v.ValidateCustomerCreate(Customer{
+		Phones: []Phone{{Number: "8 900 123 45 67"}},
+	})), "phones[0][number]")
+}
+
+func TestClient_WithValidator(t *testing.T) {
+	defer gock.Off()
+
+	c := client().WithValidator(NewValidator())
+
+	_, status, err := c.OrderCreate(Order{Email: "invalid"})
+	assert.Equal(t, 0, status)
+	assert.Contains(t, validationErrors(t, err), "email")
+
+	_, _, err = c.OrderEdit(Order{}, ByID)
+	assert.Contains(t, validationErrors(t, err), "id")
+
+	_, _, err = c.CustomerCreate(Customer{})
+	assert.Contains(t, validationErrors(t, err), "customer")
+
+	gock.New(crmURL).
+		Post("/customers/1/edit").
+		Reply(http.StatusOK).
+		BodyString(`{"success": true, "id": 1}`)
+
+	_, _, err = c.CustomerEdit(Customer{ID: 1}, ByID)
+	require.NoError(t, err, "valid payload must be sent")
+
+	assert.True(t, gock.IsDone())
+}
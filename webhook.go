@@ -0,0 +1,506 @@
+package retailcrm
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// WebhookSignatureHeader contains hex-encoded HMAC-SHA256 of the request body signed with the shared secret.
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	// WebhookEventHeader contains the event type. The "event" payload field is used if the header is empty.
+	// The header is ignored for the signed requests because it isn't covered by the signature.
+	WebhookEventHeader = "X-Webhook-Event"
+	// WebhookIDHeader contains the unique event ID. The "eventId" payload field is used if the header is empty.
+	// The header is ignored for the signed requests because it isn't covered by the signature.
+	WebhookIDHeader = "X-Webhook-ID"
+
+	// DefaultWebhookMaxBodySize is the maximum size of the webhook request body.
+	DefaultWebhookMaxBodySize = 1 << 20
+	// DefaultWebhookDeduplicationTTL is the time during which the processed event ID is remembered.
+	DefaultWebhookDeduplicationTTL = 24 * time.Hour
+)
+
+var (
+	// ErrWebhookSignature will be returned if the webhook signature or the shared secret is invalid.
+	ErrWebhookSignature = errors.New("invalid webhook signature")
+	// ErrWebhookUnhandled will be returned if there is no handler for the webhook.
+	ErrWebhookUnhandled = errors.New("unhandled webhook")
+	// ErrWebhookInProgress will be returned by WebhookDeduplicator if the event is being processed.
+	ErrWebhookInProgress = errors.New("webhook is being processed")
+)
+
+// WebhookEvent is the parsed webhook request. JSON body fields and form parameters are available in the Payload.
+// Form parameters which are not valid JSON are stored as JSON strings.
+type WebhookEvent struct {
+	ID      string
+	Type    string
+	Payload map[string]json.RawMessage
+	Request *http.Request
+}
+
+// Decode unmarshals the payload field into the provided value.
+func (e WebhookEvent) Decode(field string, v interface{}) error {
+	data, ok := e.Payload[field]
+	if !ok {
+		return fmt.Errorf("webhook field %s is missing", field)
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// Field returns the payload field as string. Non-string values are returned in the JSON form.
+func (e WebhookEvent) Field(field string) string {
+	data, ok := e.Payload[field]
+	if !ok {
+		return ""
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err == nil {
+		return value
+	}
+
+	return string(data)
+}
+
+// WebhookDeduplicator remembers IDs of the processed events, so the retried requests are not processed twice.
+type WebhookDeduplicator interface {
+	// Claim atomically marks the event as being processed. It returns false if the event was already processed
+	// and ErrWebhookInProgress if the event is being processed by the concurrent request.
+	Claim(ctx context.Context, id string) (bool, error)
+	// Complete marks the claimed event as processed.
+	Complete(ctx context.Context, id string) error
+	// Release removes the claim of the event which wasn't processed, so the retried request is processed again.
+	Release(ctx context.Context, id string) error
+}
+
+type webhookExpiration struct {
+	id        string
+	expiresAt time.Time
+}
+
+// MemoryWebhookDeduplicator keeps IDs of the processed events in memory. Expired IDs are removed in the order
+// of expiration, so every request takes amortized constant time.
+type MemoryWebhookDeduplicator struct {
+	ttl        time.Duration
+	processed  map[string]time.Time
+	inProgress map[string]struct{}
+	queue      []webhookExpiration
+	now        func() time.Time
+	mutex      sync.Mutex
+}
+
+// NewMemoryWebhookDeduplicator instantiates new MemoryWebhookDeduplicator. DefaultWebhookDeduplicationTTL is used
+// if zero TTL was provided.
+func NewMemoryWebhookDeduplicator(ttl time.Duration) *MemoryWebhookDeduplicator {
+	if ttl == 0 {
+		ttl = DefaultWebhookDeduplicationTTL
+	}
+
+	return &MemoryWebhookDeduplicator{
+		ttl:        ttl,
+		processed:  map[string]time.Time{},
+		inProgress: map[string]struct{}{},
+		now:        time.Now,
+	}
+}
+
+// Claim marks the event as being processed if it wasn't processed during the TTL.
+func (d *MemoryWebhookDeduplicator) Claim(_ context.Context, id string) (bool, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.sweep(d.now())
+
+	if _, ok := d.processed[id]; ok {
+		return false, nil
+	}
+
+	if _, ok := d.inProgress[id]; ok {
+		return false, ErrWebhookInProgress
+	}
+
+	d.inProgress[id] = struct{}{}
+	return true, nil
+}
+
+// Complete marks the event as processed during the TTL.
+func (d *MemoryWebhookDeduplicator) Complete(_ context.Context, id string) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	expiresAt := d.now().Add(d.ttl)
+
+	delete(d.inProgress, id)
+	d.processed[id] = expiresAt
+	d.queue = append(d.queue, webhookExpiration{id: id, expiresAt: expiresAt})
+
+	return nil
+}
+
+// Release removes the claim of the event.
+func (d *MemoryWebhookDeduplicator) Release(_ context.Context, id string) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	delete(d.inProgress, id)
+	return nil
+}
+
+// sweep removes the expired events. The queue is ordered by expiration because the TTL is the same for all events.
+func (d *MemoryWebhookDeduplicator) sweep(now time.Time) {
+	expired := 0
+	for expired < len(d.queue) && now.After(d.queue[expired].expiresAt) {
+		item := d.queue[expired]
+		if d.processed[item.id].Equal(item.expiresAt) {
+			delete(d.processed, item.id)
+		}
+
+		d.queue[expired] = webhookExpiration{}
+		expired++
+	}
+
+	// The backing array is reallocated with the remaining items only when it is full.
+	d.queue = d.queue[expired:]
+}
+
+// WebhookHandlerFunc processes the webhook event.
+type WebhookHandlerFunc func(ctx context.Context, event WebhookEvent) error
+
+// WebhookReceiver is the http.Handler which receives requests from the CRM triggers and integration callbacks.
+// It accepts JSON bodies, form bodies and query parameters. Entities are expected in the "order", "customer"
+// and "task" fields as JSON, e.g. `order={{ order|json_encode }}` in the trigger parameters.
+//
+// Requests are verified using the shared secret: either the WebhookSignatureHeader header must contain HMAC-SHA256
+// of the body (of the query for GET requests) or the "secret" field of the POST body must be equal to the secret.
+// The secret is not accepted in the query, so it doesn't get into the access logs. The payload, the event type
+// and the event ID of the signed requests are taken only from the signed body, query parameters and headers
+// are ignored for them. Handlers are called in the following order:
+// the OnEvent handler for the event type, then the OnOrder, OnCustomer or OnTask handler for the first entity
+// found in the payload. Handler errors are responded with 500 status code and a generic message, so the CRM retries
+// the request.
+//
+// Example:
+//
+//	receiver := retailcrm.NewWebhookReceiver("secret").
+//		WithDeduplicator(retailcrm.NewMemoryWebhookDeduplicator(time.Hour)).
+//		OnOrder(func(ctx context.Context, event retailcrm.WebhookEvent, order retailcrm.Order) error {
+//			log.Printf("%s: order #%d", event.Type, order.ID)
+//			return nil
+//		})
+//
+//	http.Handle("/webhook", receiver)
+type WebhookReceiver struct {
+	secret       string
+	insecure     bool
+	deduplicator WebhookDeduplicator
+	maxBodySize  int64
+	events       map[string]WebhookHandlerFunc
+	orders       func(context.Context, WebhookEvent, Order) error
+	customers    func(context.Context, WebhookEvent, Customer) error
+	tasks        func(context.Context, WebhookEvent, Task) error
+}
+
+// NewWebhookReceiver instantiates new WebhookReceiver. All the requests are rejected if the secret is empty,
+// unless the verification is disabled with Insecure.
+func NewWebhookReceiver(secret string) *WebhookReceiver {
+	return &WebhookReceiver{
+		secret:      secret,
+		maxBodySize: DefaultWebhookMaxBodySize,
+		events:      map[string]WebhookHandlerFunc{},
+	}
+}
+
+// Insecure disables verification of the requests if the secret is empty. Use it only if the requests are verified
+// before they reach the receiver, e.g. by the reverse proxy.
+func (r *WebhookReceiver) Insecure() *WebhookReceiver {
+	r.insecure = true
+	return r
+}
+
+// WithDeduplicator enables deduplication of the retried requests. The event ID is taken from the WebhookIDHeader
+// header of the unsigned requests or from the "eventId" field. SHA-256 of the request body is used if both are empty.
+func (r *WebhookReceiver) WithDeduplicator(deduplicator WebhookDeduplicator) *WebhookReceiver {
+	r.deduplicator = deduplicator
+	return r
+}
+
+// WithMaxBodySize sets the maximum size of the request body.
+func (r *WebhookReceiver) WithMaxBodySize(size int64) *WebhookReceiver {
+	r.maxBodySize = size
+	return r
+}
+
+// OnEvent sets the handler for the event type.
+func (r *WebhookReceiver) OnEvent(eventType string, handler WebhookHandlerFunc) *WebhookReceiver {
+	r.events[eventType] = handler
+	return r
+}
+
+// OnOrder sets the handler for the webhooks which contain the "order" field.
+func (r *WebhookReceiver) OnOrder(handler func(context.Context, WebhookEvent, Order) error) *WebhookReceiver {
+	r.orders = handler
+	return r
+}
+
+// OnCustomer sets the handler for the webhooks which contain the "customer" field.
+func (r *WebhookReceiver) OnCustomer(handler func(context.Context, WebhookEvent, Customer) error) *WebhookReceiver {
+	r.customers = handler
+	return r
+}
+
+// OnTask sets the handler for the webhooks which contain the "task" field.
+func (r *WebhookReceiver) OnTask(handler func(context.Context, WebhookEvent, Task) error) *WebhookReceiver {
+	r.tasks = handler
+	return r
+}
+
+// ServeHTTP parses, verifies and dispatches the webhook.
+func (r *WebhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, err := r.readBody(req)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	event, err := parseWebhook(req, body, r.signed(req))
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !r.verify(req, body, event) {
+		writeErrorResponse(w, http.StatusUnauthorized, ErrWebhookSignature.Error())
+		return
+	}
+
+	delete(event.Payload, "secret")
+
+	if r.deduplicator == nil {
+		r.respond(w, r.dispatch(req.Context(), event))
+		return
+	}
+
+	r.dispatchOnce(w, req, event)
+}
+
+// dispatchOnce claims the event in the deduplicator, so concurrent retries are not processed twice. The claim
+// is released if the event wasn't processed.
+func (r *WebhookReceiver) dispatchOnce(w http.ResponseWriter, req *http.Request, event WebhookEvent) {
+	claimed, err := r.deduplicator.Claim(req.Context(), event.ID)
+	switch {
+	case errors.Is(err, ErrWebhookInProgress):
+		writeErrorResponse(w, http.StatusConflict, err.Error())
+		return
+	case err != nil:
+		writeErrorResponse(w, http.StatusInternalServerError, internalErrorMessage)
+		return
+	case !claimed:
+		writeJSONResponse(w, http.StatusOK, SuccessfulResponse{Success: true})
+		return
+	}
+
+	completed := false
+	defer func() {
+		if !completed {
+			_ = r.deduplicator.Release(context.Background(), event.ID)
+		}
+	}()
+
+	if err := r.dispatch(req.Context(), event); err != nil {
+		r.respond(w, err)
+		return
+	}
+
+	if err := r.deduplicator.Complete(req.Context(), event.ID); err != nil {
+		writeErrorResponse(w, http.StatusInternalServerError, internalErrorMessage)
+		return
+	}
+
+	completed = true
+	writeJSONResponse(w, http.StatusOK, SuccessfulResponse{Success: true})
+}
+
+func (r *WebhookReceiver) respond(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrWebhookUnhandled):
+		writeErrorResponse(w, http.StatusUnprocessableEntity, err.Error())
+	case err != nil:
+		writeErrorResponse(w, http.StatusInternalServerError, internalErrorMessage)
+	default:
+		writeJSONResponse(w, http.StatusOK, SuccessfulResponse{Success: true})
+	}
+}
+
+func (r *WebhookReceiver) readBody(req *http.Request) ([]byte, error) {
+	if req.Method == http.MethodGet {
+		return []byte(req.URL.RawQuery), nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(req.Body, r.maxBodySize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(body)) > r.maxBodySize {
+		return nil, errors.New("request body is too large")
+	}
+
+	return body, nil
+}
+
+// signed returns true if the request must be verified using WebhookSignatureHeader.
+func (r *WebhookReceiver) signed(req *http.Request) bool {
+	return r.secret != "" && req.Header.Get(WebhookSignatureHeader) != ""
+}
+
+func (r *WebhookReceiver) verify(req *http.Request, body []byte, event WebhookEvent) bool {
+	if r.secret == "" {
+		return r.insecure
+	}
+
+	if r.signed(req) {
+		return VerifyWebhookSignature(r.secret, body, req.Header.Get(WebhookSignatureHeader))
+	}
+
+	// The payload of GET requests is read from the query, which must not contain the secret.
+	if req.Method == http.MethodGet {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(event.Field("secret")), []byte(r.secret)) == 1
+}
+
+func (r *WebhookReceiver) dispatch(ctx context.Context, event WebhookEvent) error {
+	if handler, ok := r.events[event.Type]; ok {
+		return handler(ctx, event)
+	}
+
+	switch {
+	case r.orders != nil && event.Payload["order"] != nil:
+		var order Order
+		if err := event.Decode("order", &order); err != nil {
+			return wrapError(ErrWebhookUnhandled, "cannot decode order", err)
+		}
+
+		return r.orders(ctx, event, order)
+	case r.customers != nil && event.Payload["customer"] != nil:
+		var customer Customer
+		if err := event.Decode("customer", &customer); err != nil {
+			return wrapError(ErrWebhookUnhandled, "cannot decode customer", err)
+		}
+
+		return r.customers(ctx, event, customer)
+	case r.tasks != nil && event.Payload["task"] != nil:
+		var task Task
+		if err := event.Decode("task", &task); err != nil {
+			return wrapError(ErrWebhookUnhandled, "cannot decode task", err)
+		}
+
+		return r.tasks(ctx, event, task)
+	}
+
+	return ErrWebhookUnhandled
+}
+
+// SignWebhook returns hex-encoded HMAC-SHA256 of the body for the WebhookSignatureHeader header.
+func SignWebhook(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhookSignature returns true if the signature matches the body.
+func VerifyWebhookSignature(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(SignWebhook(secret, body)), []byte(strings.ToLower(signature)))
+}
+
+// parseWebhook parses JSON body or form parameters into the WebhookEvent. Query parameters of POST requests and
+// headers are used only if the request is not signed, so the signed request can't be altered. The secret is never
+// taken from the query of POST requests.
+func parseWebhook(req *http.Request, body []byte, signed bool) (WebhookEvent, error) {
+	event := WebhookEvent{Request: req, Payload: map[string]json.RawMessage{}}
+
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if mediaType == "application/json" {
+		if err := json.Unmarshal(body, &event.Payload); err != nil {
+			return event, fmt.Errorf("cannot parse webhook body: %w", err)
+		}
+	} else {
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return event, fmt.Errorf("cannot parse webhook body: %w", err)
+		}
+
+		if req.Method != http.MethodGet && !signed {
+			for key, value := range req.URL.Query() {
+				if _, ok := values[key]; !ok && key != "secret" {
+					values[key] = value
+				}
+			}
+		}
+
+		for key := range values {
+			event.Payload[key] = formValueJSON(values.Get(key))
+		}
+	}
+
+	if !signed {
+		event.Type = req.Header.Get(WebhookEventHeader)
+		event.ID = req.Header.Get(WebhookIDHeader)
+	}
+
+	if event.Type == "" {
+		event.Type = event.Field("event")
+	}
+
+	if event.ID == "" {
+		event.ID = event.Field("eventId")
+	}
+
+	if event.ID == "" {
+		hash := sha256.Sum256(body)
+		event.ID = hex.EncodeToString(hash[:])
+	}
+
+	return event, nil
+}
+
+// formValueJSON returns the form value as is if it is a JSON object or array. Other values are encoded as strings.
+func formValueJSON(value string) json.RawMessage {
+	trimmed := strings.TrimSpace(value)
+	if (strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")) && json.Valid([]byte(trimmed)) {
+		return json.RawMessage(trimmed)
+	}
+
+	data, _ := json.Marshal(value)
+	return data
+}
+
+// internalErrorMessage is responded instead of the handler errors, so their details are not sent to the caller.
+const internalErrorMessage = "internal error"
+
+func writeJSONResponse(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeErrorResponse(w http.ResponseWriter, status int, msg string) {
+	writeJSONResponse(w, status, ErrorResponse{ErrorMessage: msg})
+}
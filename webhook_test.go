@@ -0,0 +1,318 @@
+package retailcrm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveWebhook(receiver http.Handler, req *http.Request) (*httptest.ResponseRecorder, ErrorResponse) {
+	rec := httptest.NewRecorder()
+	receiver.ServeHTTP(rec, req)
+
+	var resp ErrorResponse
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+
+	return rec, resp
+}
+
+func TestWebhookReceiver_FormOrder(t *testing.T) {
+	var received Order
+	var eventType string
+
+	receiver := NewWebhookReceiver("secret").
+		OnOrder(func(_ context.Context, event WebhookEvent, order Order) error {
+			received = order
+			eventType = event.Type
+			return nil
+		})
+
+	form := url.Values{
+		"event":  {"order_created"},
+		"secret": {"secret"},
+		"order":  {`{"id": 10, "externalId": "ext-10", "status": "new"}`},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	rec, resp := serveWebhook(receiver, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, resp.Success)
+	assert.Equal(t, "order_created", eventType)
+	assert.Equal(t, 10, received.ID)
+	assert.Equal(t, "new", received.Status)
+}
+
+func TestWebhookReceiver_JSONSignature(t *testing.T) {
+	var received Customer
+
+	receiver := NewWebhookReceiver("secret").
+		OnCustomer(func(_ context.Context, _ WebhookEvent, customer Customer) error {
+			received = customer
+			return nil
+		})
+
+	body := []byte(`{"customer": {"id": 5, "firstName": "John"}}`)
+
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookSignatureHeader, SignWebhook("secret", body))
+
+	rec, _ := serveWebhook(receiver, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "John", received.FirstName)
+
+	req = httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookSignatureHeader, SignWebhook("other", body))
+
+	rec, resp := serveWebhook(receiver, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, ErrWebhookSignature.Error(), resp.ErrorMessage)
+}
+
+func TestWebhookReceiver_SignedPayloadOnly(t *testing.T) {
+	var orders []int
+	var events []string
+
+	receiver := NewWebhookReceiver("secret").
+		WithDeduplicator(NewMemoryWebhookDeduplicator(time.Hour)).
+		OnEvent("order_deleted", func(_ context.Context, event WebhookEvent) error {
+			events = append(events, event.Type)
+			return nil
+		}).
+		OnOrder(func(_ context.Context, _ WebhookEvent, order Order) error {
+			orders = append(orders, order.ID)
+			return nil
+		})
+
+	body := []byte(url.Values{"customer": {`{"id": 1}`}, "eventId": {"event-1"}}.Encode())
+	signed := func(target string, headers map[string]string) int {
+		req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(string(body)))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set(WebhookSignatureHeader, SignWebhook("secret", body))
+
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+
+		rec, _ := serveWebhook(receiver, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusUnprocessableEntity, signed("/webhook?order=%7B%22id%22%3A666%7D", nil),
+		"unsigned query parameters must not be added to the signed payload")
+	assert.Equal(t, http.StatusUnprocessableEntity, signed("/webhook", map[string]string{
+		WebhookEventHeader: "order_deleted",
+		WebhookIDHeader:    "event-2",
+	}), "unsigned headers must not change the event type")
+	assert.Empty(t, orders)
+	assert.Empty(t, events)
+
+	body = []byte(url.Values{"order": {`{"id": 7}`}, "eventId": {"event-3"}}.Encode())
+	assert.Equal(t, http.StatusOK, signed("/webhook", nil))
+	assert.Equal(t, http.StatusOK, signed("/webhook", map[string]string{WebhookIDHeader: "event-4"}))
+	assert.Equal(t, []int{7}, orders, "replayed request with the new unsigned ID must be deduplicated")
+}
+
+func TestWebhookReceiver_InvalidSecret(t *testing.T) {
+	called := false
+	receiver := NewWebhookReceiver("secret").
+		OnTask(func(context.Context, WebhookEvent, Task) error {
+			called = true
+			return nil
+		})
+
+	req := httptest.NewRequest(http.MethodGet, "/webhook?secret=wrong&task=%7B%22id%22%3A1%7D", nil)
+	rec, _ := serveWebhook(receiver, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.False(t, called)
+}
+
+func TestWebhookReceiver_SecretLocation(t *testing.T) {
+	calls := 0
+	receiver := NewWebhookReceiver("secret").
+		OnEvent("order_created", func(context.Context, WebhookEvent) error {
+			calls++
+			return errors.New("database is unavailable")
+		})
+
+	rec, _ := serveWebhook(receiver, httptest.NewRequest(http.MethodGet, "/webhook?event=order_created&secret=secret", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code, "secret must not be accepted in the GET query")
+
+	req := httptest.NewRequest(http.MethodPost, "/webhook?secret=secret", strings.NewReader(`event=order_created`))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec, _ = serveWebhook(receiver, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code, "secret must not be accepted in the POST query")
+	assert.Zero(t, calls)
+
+	req = httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(`event=order_created&secret=secret`))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec, resp := serveWebhook(receiver, req)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, internalErrorMessage, resp.ErrorMessage, "handler error must not be sent to the caller")
+	assert.Equal(t, 1, calls)
+}
+
+func TestWebhookReceiver_EmptySecret(t *testing.T) {
+	calls := 0
+	handler := func(context.Context, WebhookEvent) error {
+		calls++
+		return nil
+	}
+
+	rec, _ := serveWebhook(NewWebhookReceiver("").OnEvent("order_created", handler),
+		httptest.NewRequest(http.MethodGet, "/webhook?event=order_created", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Zero(t, calls)
+
+	rec, _ = serveWebhook(NewWebhookReceiver("").Insecure().OnEvent("order_created", handler),
+		httptest.NewRequest(http.MethodGet, "/webhook?event=order_created", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 1, calls)
+}
+
+func TestWebhookReceiver_EventHandlerAndDeduplication(t *testing.T) {
+	calls := 0
+	receiver := NewWebhookReceiver("").Insecure().
+		WithDeduplicator(NewMemoryWebhookDeduplicator(time.Hour)).
+		OnEvent("task_completed", func(_ context.Context, event WebhookEvent) error {
+			calls++
+
+			var task Task
+			require.NoError(t, event.Decode("task", &task))
+			assert.Equal(t, 7, task.ID)
+			assert.Equal(t, "manager", event.Field("source"))
+
+			if calls == 1 {
+				return errors.New("temporary failure")
+			}
+
+			return nil
+		})
+
+	send := func() int {
+		req := httptest.NewRequest(http.MethodGet, "/webhook?event=task_completed&source=manager&task=%7B%22id%22%3A7%7D", nil)
+		req.Header.Set(WebhookIDHeader, "event-1")
+		rec, _ := serveWebhook(receiver, req)
+
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusInternalServerError, send())
+	assert.Equal(t, http.StatusOK, send())
+	assert.Equal(t, http.StatusOK, send())
+	assert.Equal(t, 2, calls, "failed event must be retried, processed event must be skipped")
+}
+
+func TestWebhookReceiver_Unhandled(t *testing.T) {
+	receiver := NewWebhookReceiver("").Insecure()
+
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(`{"order": {"id": 1}}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec, _ := serveWebhook(receiver, req)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+
+	receiver.OnOrder(func(context.Context, WebhookEvent, Order) error { return nil })
+	req = httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(`{"order": {"id": "abc"}}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec, resp := serveWebhook(receiver, req)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, resp.ErrorMessage, "cannot decode order")
+
+	var typeErr *json.UnmarshalTypeError
+	err := receiver.dispatch(context.Background(), WebhookEvent{Payload: map[string]json.RawMessage{"order": []byte(`{"id": "abc"}`)}})
+	assert.ErrorIs(t, err, ErrWebhookUnhandled)
+	assert.ErrorAs(t, err, &typeErr)
+
+	req = httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(`{"order":`))
+	req.Header.Set("Content-Type", "application/json")
+	rec, _ = serveWebhook(receiver, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestWebhookReceiver_MaxBodySize(t *testing.T) {
+	receiver := NewWebhookReceiver("").Insecure().WithMaxBodySize(10)
+
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(`{"order": {"id": 1}}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec, _ := serveWebhook(receiver, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestWebhookReceiver_ConcurrentRetry(t *testing.T) {
+	started := make(chan struct{})
+	finish := make(chan struct{})
+	calls := 0
+
+	receiver := NewWebhookReceiver("").Insecure().
+		WithDeduplicator(NewMemoryWebhookDeduplicator(time.Hour)).
+		OnEvent("order_created", func(context.Context, WebhookEvent) error {
+			calls++
+			close(started)
+			<-finish
+			return nil
+		})
+
+	send := func() int {
+		rec, _ := serveWebhook(receiver, httptest.NewRequest(http.MethodGet, "/webhook?event=order_created&eventId=1", nil))
+		return rec.Code
+	}
+
+	first := make(chan int)
+	go func() { first <- send() }()
+
+	<-started
+	assert.Equal(t, http.StatusConflict, send(), "concurrent retry must not be processed")
+	close(finish)
+
+	assert.Equal(t, http.StatusOK, <-first)
+	assert.Equal(t, http.StatusOK, send())
+	assert.Equal(t, 1, calls)
+}
+
+func TestMemoryWebhookDeduplicator(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	deduplicator := NewMemoryWebhookDeduplicator(time.Minute)
+	deduplicator.now = func() time.Time { return now }
+
+	claimed, err := deduplicator.Claim(ctx, "id")
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	_, err = deduplicator.Claim(ctx, "id")
+	assert.ErrorIs(t, err, ErrWebhookInProgress)
+
+	require.NoError(t, deduplicator.Release(ctx, "id"))
+	claimed, _ = deduplicator.Claim(ctx, "id")
+	assert.True(t, claimed, "released event must be claimed again")
+
+	require.NoError(t, deduplicator.Complete(ctx, "id"))
+	claimed, err = deduplicator.Claim(ctx, "id")
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	now = now.Add(30 * time.Second)
+	_, _ = deduplicator.Claim(ctx, "other")
+	require.NoError(t, deduplicator.Complete(ctx, "other"))
+
+	now = now.Add(45 * time.Second)
+	claimed, _ = deduplicator.Claim(ctx, "id")
+	assert.True(t, claimed, "expired event must be claimed again")
+	assert.NotContains(t, deduplicator.processed, "id")
+	assert.Contains(t, deduplicator.processed, "other")
+	assert.Len(t, deduplicator.queue, 1)
+}